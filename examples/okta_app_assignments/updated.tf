@@ -0,0 +1,48 @@
+resource "okta_app_oauth" "test" {
+  label          = "testAcc_replace_with_uuid"
+  type           = "web"
+  grant_types    = ["implicit", "authorization_code"]
+  redirect_uris  = ["http://d.com/"]
+  response_types = ["code", "token", "id_token"]
+  issuer_mode    = "ORG_URL"
+
+  lifecycle {
+    ignore_changes = [users, groups]
+  }
+}
+
+resource "okta_user" "test1" {
+  first_name = "TestAcc"
+  last_name  = "Smith"
+  login      = "testAcc_replace_with_uuid_1@example.com"
+  email      = "testAcc_replace_with_uuid_1@example.com"
+}
+
+resource "okta_user" "test2" {
+  first_name = "TestAcc"
+  last_name  = "Jones"
+  login      = "testAcc_replace_with_uuid_2@example.com"
+  email      = "testAcc_replace_with_uuid_2@example.com"
+}
+
+resource "okta_group" "test1" {
+  name = "testAcc_replace_with_uuid"
+}
+
+resource "okta_group" "test2" {
+  name = "testAcc_replace_with_uuid_2"
+}
+
+resource "okta_app_assignments" "test" {
+  app_id = okta_app_oauth.test.id
+
+  user {
+    id       = okta_user.test1.id
+    username = "testAcc_replace_with_uuid_renamed@example.com"
+  }
+
+  group {
+    id       = okta_group.test2.id
+    priority = 1
+  }
+}
@@ -0,0 +1,16 @@
+resource "okta_app_oauth" "test" {
+  label          = "testAcc_replace_with_uuid"
+  type           = "web"
+  grant_types    = ["authorization_code"]
+  response_types = ["code"]
+  redirect_uris  = ["http://d.com/"]
+}
+
+resource "okta_app_oauth_scope_consent_grant" "test" {
+  app_id = okta_app_oauth.test.id
+  scope  = "okta.users.read"
+}
+
+data "okta_app_oauth_grants" "test" {
+  app_id = okta_app_oauth_scope_consent_grant.test.app_id
+}
@@ -0,0 +1,16 @@
+resource "okta_app_oauth" "test" {
+  label          = "testAcc_replace_with_uuid"
+  type           = "web"
+  grant_types    = ["authorization_code", "refresh_token"]
+  response_types = ["code"]
+  redirect_uris  = ["http://d.com/"]
+}
+
+data "okta_app_oauth_grants" "test" {
+  app_id = okta_app_oauth.test.id
+}
+
+resource "okta_app_oauth_token_revocation" "test" {
+  app_id    = okta_app_oauth.test.id
+  token_ids = data.okta_app_oauth_grants.test.refresh_tokens[*].id
+}
@@ -0,0 +1,25 @@
+resource "okta_app_oauth" "test" {
+  label          = "testAcc_replace_with_uuid"
+  type           = "web"
+  grant_types    = ["authorization_code"]
+  redirect_uris  = ["http://d.com/"]
+  response_types = ["code"]
+}
+
+data "okta_app_signon_policy" "test" {
+  app_id = okta_app_oauth.test.id
+}
+
+resource "okta_device_assurance_policy" "test" {
+  name               = "testAcc_replace_with_uuid"
+  platform           = "IOS"
+  os_version_minimum = "16.0.0"
+  jailbreak          = false
+}
+
+resource "okta_app_signon_policy_rule" "test" {
+  name                      = "testAcc_replace_with_uuid"
+  policy_id                 = data.okta_app_signon_policy.test.id
+  device_is_registered      = true
+  device_assurance_included = [okta_device_assurance_policy.test.id]
+}
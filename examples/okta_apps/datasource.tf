@@ -0,0 +1,34 @@
+resource "okta_app_oauth" "test" {
+  label          = "testAcc_replace_with_uuid"
+  type           = "web"
+  grant_types    = ["implicit", "authorization_code"]
+  redirect_uris  = ["http://d.com/"]
+  response_types = ["code", "token", "id_token"]
+  issuer_mode    = "ORG_URL"
+  consent_method = "TRUSTED"
+}
+
+resource "okta_app_bookmark" "test" {
+  label = "testAcc_replace_with_uuid"
+  url   = "https://test.com"
+}
+
+data "okta_apps" "test" {
+  q = "testAcc_replace_with_uuid"
+
+  depends_on = [okta_app_oauth.test, okta_app_bookmark.test]
+}
+
+data "okta_apps" "oidc" {
+  q            = "testAcc_replace_with_uuid"
+  sign_on_mode = "OPENID_CONNECT"
+
+  depends_on = [okta_app_oauth.test, okta_app_bookmark.test]
+}
+
+data "okta_apps" "regex" {
+  label_regex = "^testAcc_replace_with_uuid$"
+  name        = "bookmark"
+
+  depends_on = [okta_app_oauth.test, okta_app_bookmark.test]
+}
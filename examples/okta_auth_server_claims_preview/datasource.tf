@@ -0,0 +1,57 @@
+resource "okta_auth_server" "test" {
+  name        = "testAcc_replace_with_uuid"
+  description = "test"
+  audiences   = ["whatever.rise.zone"]
+}
+
+resource "okta_auth_server_scope" "test" {
+  auth_server_id = okta_auth_server.test.id
+  name           = "api:read"
+}
+
+resource "okta_auth_server_claim" "department" {
+  auth_server_id = okta_auth_server.test.id
+  name           = "department"
+  claim_type     = "RESOURCE"
+  value          = "String.toUpperCase(user.department)"
+  scopes         = [okta_auth_server_scope.test.name]
+}
+
+resource "okta_auth_server_claim" "email_domain" {
+  auth_server_id = okta_auth_server.test.id
+  name           = "email_domain"
+  claim_type     = "IDENTITY"
+  value          = "String.substringAfter(user.email, \"@\")"
+}
+
+resource "okta_auth_server_claim" "groups" {
+  auth_server_id    = okta_auth_server.test.id
+  name              = "groups"
+  claim_type        = "IDENTITY"
+  value_type        = "GROUPS"
+  group_filter_type = "STARTS_WITH"
+  value             = "App-"
+}
+
+data "okta_auth_server_claims_preview" "test" {
+  auth_server_id = okta_auth_server.test.id
+  scopes         = ["openid", "api:read"]
+
+  user_profile = {
+    email      = "john.doe@example.com"
+    department = "sales"
+  }
+
+  groups {
+    name = "Everyone"
+  }
+  groups {
+    name = "App-Admins"
+  }
+
+  depends_on = [
+    okta_auth_server_claim.department,
+    okta_auth_server_claim.email_domain,
+    okta_auth_server_claim.groups,
+  ]
+}
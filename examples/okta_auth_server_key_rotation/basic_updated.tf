@@ -0,0 +1,11 @@
+resource "okta_auth_server" "test" {
+  audiences                 = ["whatever.rise.zone"]
+  credentials_rotation_mode = "MANUAL"
+  name                      = "testAcc_replace_with_uuid"
+}
+
+resource "okta_auth_server_key_rotation" "test" {
+  auth_server_id   = okta_auth_server.test.id
+  rotation_trigger = "2"
+  overlap          = "0s"
+}
@@ -0,0 +1,25 @@
+data "okta_group" "all" {
+  name = "Everyone"
+}
+
+resource "okta_authenticator_enrollment_policy" "test" {
+  name            = "testAcc_replace_with_uuid"
+  status          = "ACTIVE"
+  description     = "Terraform Acceptance Test Authenticator Enrollment Policy"
+  groups_included = [data.okta_group.all.id]
+
+  authenticator {
+    key    = "okta_password"
+    enroll = "REQUIRED"
+  }
+
+  authenticator {
+    key    = "okta_email"
+    enroll = "REQUIRED"
+  }
+
+  authenticator {
+    key    = "okta_verify"
+    enroll = "OPTIONAL"
+  }
+}
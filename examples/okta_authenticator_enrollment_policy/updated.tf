@@ -0,0 +1,25 @@
+data "okta_group" "all" {
+  name = "Everyone"
+}
+
+resource "okta_authenticator_enrollment_policy" "test" {
+  name            = "testAcc_replace_with_uuid_updated"
+  status          = "INACTIVE"
+  description     = "Terraform Acceptance Test Authenticator Enrollment Policy Updated"
+  groups_included = [data.okta_group.all.id]
+
+  authenticator {
+    key    = "okta_password"
+    enroll = "REQUIRED"
+  }
+
+  authenticator {
+    key    = "okta_email"
+    enroll = "OPTIONAL"
+  }
+
+  authenticator {
+    key    = "okta_verify"
+    enroll = "NOT_ALLOWED"
+  }
+}
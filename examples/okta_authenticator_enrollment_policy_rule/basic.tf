@@ -0,0 +1,21 @@
+data "okta_group" "all" {
+  name = "Everyone"
+}
+
+resource "okta_authenticator_enrollment_policy" "test" {
+  name            = "testAcc_replace_with_uuid"
+  description     = "Terraform Acceptance Test Authenticator Enrollment Policy"
+  groups_included = [data.okta_group.all.id]
+
+  authenticator {
+    key    = "okta_email"
+    enroll = "REQUIRED"
+  }
+}
+
+resource "okta_authenticator_enrollment_policy_rule" "test" {
+  policy_id    = okta_authenticator_enrollment_policy.test.id
+  name         = "testAcc_replace_with_uuid"
+  enroll       = "CHALLENGE"
+  grace_period = "P7D"
+}
@@ -0,0 +1,7 @@
+resource "okta_device_assurance_policy" "test" {
+  name                    = "testAcc_replace_with_uuid"
+  platform                = "MACOS"
+  os_version_minimum      = "12.4.5"
+  disk_encryption_types   = ["ALL_INTERNAL_VOLUMES"]
+  secure_hardware_present = true
+}
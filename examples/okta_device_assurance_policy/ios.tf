@@ -0,0 +1,7 @@
+resource "okta_device_assurance_policy" "test" {
+  name               = "testAcc_replace_with_uuid"
+  platform           = "IOS"
+  os_version_minimum = "16.0.0"
+  screen_lock_types  = ["BIOMETRIC", "PASSCODE"]
+  jailbreak          = false
+}
@@ -0,0 +1,6 @@
+resource "okta_device_assurance_policy" "test" {
+  name                    = "testAcc_replace_with_uuid_updated"
+  platform                = "MACOS"
+  os_version_minimum      = "13.0.0"
+  secure_hardware_present = false
+}
@@ -0,0 +1,6 @@
+data "okta_event_hook_event_types" "all" {
+}
+
+data "okta_event_hook_event_types" "user_lifecycle" {
+  prefix = "user.lifecycle."
+}
@@ -0,0 +1,10 @@
+data "okta_group" "all" {
+  name = "Everyone"
+}
+
+resource "okta_global_session_policy" "test" {
+  name            = "testAcc_replace_with_uuid"
+  status          = "ACTIVE"
+  description     = "Terraform Acceptance Test Global Session Policy"
+  groups_included = [data.okta_group.all.id]
+}
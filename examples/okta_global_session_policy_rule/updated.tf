@@ -0,0 +1,20 @@
+data "okta_group" "all" {
+  name = "Everyone"
+}
+
+resource "okta_global_session_policy" "test" {
+  name            = "testAcc_replace_with_uuid"
+  description     = "Terraform Acceptance Test Global Session Policy"
+  groups_included = [data.okta_group.all.id]
+}
+
+resource "okta_global_session_policy_rule" "test" {
+  policy_id      = okta_global_session_policy.test.id
+  name           = "testAcc_replace_with_uuid"
+  status         = "INACTIVE"
+  primary_factor = "PASSWORD_IDP"
+  mfa_required   = true
+  mfa_prompt     = "SESSION"
+  mfa_lifetime   = 60
+  session_idle   = 120
+}
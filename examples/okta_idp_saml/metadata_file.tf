@@ -0,0 +1,8 @@
+resource "okta_idp_saml" "test" {
+  name                     = "testAcc_replace_with_uuid"
+  acs_type                 = "INSTANCE"
+  metadata_file            = "../examples/okta_idp_saml/idp_metadata.xml"
+  username_template        = "idpuser.email"
+  request_signature_scope  = "REQUEST"
+  response_signature_scope = "ANY"
+}
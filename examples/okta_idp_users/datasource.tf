@@ -0,0 +1,34 @@
+resource "okta_idp_social" "test" {
+  type          = "GOOGLE"
+  protocol_type = "OIDC"
+  name          = "testAcc_google_replace_with_uuid"
+
+  scopes = [
+    "profile",
+    "email",
+    "openid",
+  ]
+
+  client_id         = "abcd123"
+  client_secret     = "abcd123"
+  username_template = "idpuser.email"
+}
+
+resource "okta_user" "test" {
+  first_name = "TestAcc"
+  last_name  = "Smith"
+  login      = "testAcc-replace_with_uuid@example.com"
+  email      = "testAcc-replace_with_uuid@example.com"
+}
+
+resource "okta_idp_user_link" "test" {
+  idp_id      = okta_idp_social.test.id
+  user_id     = okta_user.test.id
+  external_id = "118296312345678901234"
+}
+
+data "okta_idp_users" "test" {
+  idp_id = okta_idp_social.test.id
+
+  depends_on = [okta_idp_user_link.test]
+}
@@ -0,0 +1,32 @@
+resource "okta_group" "test" {
+  name = "testAcc_replace_with_uuid"
+}
+
+resource "okta_policy_signon" "test" {
+  name            = "testAcc_replace_with_uuid"
+  status          = "ACTIVE"
+  description     = "Terraform Acceptance Test SignOn Policy"
+  groups_included = [okta_group.test.id]
+}
+
+resource "okta_policy_rule_signon" "mobile" {
+  policy_id = okta_policy_signon.test.id
+  name      = "testAcc_replace_with_uuid_mobile"
+  status    = "ACTIVE"
+  priority  = 1
+  access    = "DENY"
+}
+
+data "okta_policy_evaluation" "member" {
+  policy_id = okta_policy_signon.test.id
+  group_ids = [okta_group.test.id]
+
+  depends_on = [okta_policy_rule_signon.mobile]
+}
+
+data "okta_policy_evaluation" "other" {
+  policy_id = okta_policy_signon.test.id
+  group_ids = ["00g0000000000000000"]
+
+  depends_on = [okta_policy_rule_signon.mobile]
+}
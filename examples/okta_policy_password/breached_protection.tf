@@ -0,0 +1,13 @@
+data "okta_group" "all" {
+  name = "Everyone"
+}
+
+resource "okta_policy_password" "test" {
+  name                                           = "testAcc_replace_with_uuid"
+  status                                         = "ACTIVE"
+  description                                    = "Terraform Acceptance Test Password Policy"
+  password_dictionary_lookup                     = true
+  password_breached_protection_expire_after_days = 0
+  password_breached_protection_logout_enabled    = true
+  groups_included                                = [data.okta_group.all.id]
+}
@@ -0,0 +1,35 @@
+data "okta_group" "all" {
+  name = "Everyone"
+}
+
+resource "okta_policy_signon" "test" {
+  name            = "testAcc_replace_with_uuid"
+  status          = "ACTIVE"
+  description     = "Terraform Acceptance Test SignOn Policy"
+  groups_included = [data.okta_group.all.id]
+}
+
+resource "okta_policy_rule_signon" "test" {
+  policy_id = okta_policy_signon.test.id
+  name      = "testAcc_replace_with_uuid"
+  status    = "ACTIVE"
+  access    = "DENY"
+}
+
+data "okta_policy_export" "test" {
+  policy_id = okta_policy_signon.test.id
+
+  depends_on = [okta_policy_rule_signon.test]
+}
+
+locals {
+  snapshot = jsondecode(data.okta_policy_export.test.json)
+}
+
+# restores a copy of the policy under another name
+resource "okta_policy_restore" "test" {
+  json = jsonencode({
+    policy = merge(local.snapshot.policy, { name = "testAcc_replace_with_uuid_restored" })
+    rules  = local.snapshot.rules
+  })
+}
@@ -0,0 +1,34 @@
+data "okta_group" "all" {
+  name = "Everyone"
+}
+
+resource "okta_app_signon_policy" "test" {
+  name        = "testAcc_replace_with_uuid"
+  description = "Terraform Acceptance Test Authentication Policy"
+}
+
+resource "okta_policy_rule" "test" {
+  policy_id = okta_app_signon_policy.test.id
+  type      = "ACCESS_POLICY"
+  name      = "testAcc_replace_with_uuid"
+  conditions = jsonencode({
+    people = {
+      groups = {
+        include = [data.okta_group.all.id]
+      }
+    }
+    network = {
+      connection = "ANYWHERE"
+    }
+  })
+  actions = jsonencode({
+    appSignOn = {
+      access = "ALLOW"
+      verificationMethod = {
+        type             = "ASSURANCE"
+        factorMode       = "1FA"
+        reauthenticateIn = "PT2H"
+      }
+    }
+  })
+}
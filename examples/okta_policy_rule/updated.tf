@@ -0,0 +1,30 @@
+data "okta_group" "all" {
+  name = "Everyone"
+}
+
+resource "okta_app_signon_policy" "test" {
+  name        = "testAcc_replace_with_uuid"
+  description = "Terraform Acceptance Test Authentication Policy"
+}
+
+resource "okta_policy_rule" "test" {
+  policy_id = okta_app_signon_policy.test.id
+  type      = "ACCESS_POLICY"
+  name      = "testAcc_replace_with_uuid_updated"
+  status    = "INACTIVE"
+  conditions = jsonencode({
+    people = {
+      groups = {
+        include = [data.okta_group.all.id]
+      }
+    }
+    riskScore = {
+      level = "HIGH"
+    }
+  })
+  actions = jsonencode({
+    appSignOn = {
+      access = "DENY"
+    }
+  })
+}
@@ -0,0 +1,87 @@
+data "okta_policy" "test" {
+  name = "Idp Discovery Policy"
+  type = "IDP_DISCOVERY"
+}
+
+resource "okta_policy_rule_idp_routing" "test" {
+  policy_id = data.okta_policy.test.id
+  priority  = 1
+  name      = "testAcc_replace_with_uuid"
+
+  idp {
+    type = "SAML2"
+    id   = okta_idp_saml.partner_a.id
+  }
+
+  idp {
+    type = "SAML2"
+    id   = okta_idp_saml.partner_b.id
+  }
+
+  conditions {
+    user_identifier {
+      type = "IDENTIFIER"
+
+      patterns {
+        match_type = "SUFFIX"
+        value      = "@partner-a.example.com"
+      }
+
+      patterns {
+        match_type = "SUFFIX"
+        value      = "@partner-b.example.com"
+      }
+    }
+
+    app_include {
+      type = "APP"
+      id   = okta_app_saml.test.id
+    }
+  }
+}
+
+resource "okta_idp_saml" "partner_a" {
+  name                     = "testAcc_replace_with_uuid_a"
+  acs_type                 = "INSTANCE"
+  sso_url                  = "https://idp-a.example.com"
+  sso_destination          = "https://idp-a.example.com"
+  sso_binding              = "HTTP-POST"
+  username_template        = "idpuser.email"
+  issuer                   = "https://idp-a.example.com"
+  request_signature_scope  = "REQUEST"
+  response_signature_scope = "ANY"
+  kid                      = okta_idp_saml_key.test.id
+}
+
+resource "okta_idp_saml" "partner_b" {
+  name                     = "testAcc_replace_with_uuid_b"
+  acs_type                 = "INSTANCE"
+  sso_url                  = "https://idp-b.example.com"
+  sso_destination          = "https://idp-b.example.com"
+  sso_binding              = "HTTP-POST"
+  username_template        = "idpuser.email"
+  issuer                   = "https://idp-b.example.com"
+  request_signature_scope  = "REQUEST"
+  response_signature_scope = "ANY"
+  kid                      = okta_idp_saml_key.test.id
+}
+
+resource "okta_idp_saml_key" "test" {
+  x5c = [okta_app_saml.test.certificate]
+}
+
+resource "okta_app_saml" "test" {
+  label                    = "testAcc_replace_with_uuid"
+  sso_url                  = "http://google.com"
+  recipient                = "http://here.com"
+  destination              = "http://its-about-the-journey.com"
+  audience                 = "http://audience.com"
+  subject_name_id_template = "$${user.userName}"
+  subject_name_id_format   = "urn:oasis:names:tc:SAML:1.1:nameid-format:emailAddress"
+  response_signed          = true
+  signature_algorithm      = "RSA_SHA256"
+  digest_algorithm         = "SHA256"
+  honor_force_authn        = false
+  authn_context_class_ref  = "urn:oasis:names:tc:SAML:2.0:ac:classes:PasswordProtectedTransport"
+}
+
@@ -0,0 +1,26 @@
+data "okta_policy" "test" {
+  name = "Idp Discovery Policy"
+  type = "IDP_DISCOVERY"
+}
+
+resource "okta_policy_rule_idp_routing" "test" {
+  policy_id = data.okta_policy.test.id
+  priority  = 1
+  name      = "testAcc_replace_with_uuid"
+
+  dynamic_idp {
+    provider_expression = "login.identifier.substringAfter('@')"
+    property_name       = "name"
+  }
+
+  conditions {
+    user_identifier {
+      type = "IDENTIFIER"
+
+      patterns {
+        match_type = "SUFFIX"
+        value      = ".example.com"
+      }
+    }
+  }
+}
@@ -0,0 +1,37 @@
+data "okta_group" "all" {
+  name = "Everyone"
+}
+
+resource "okta_policy_signon" "test" {
+  name            = "testAcc_replace_with_uuid"
+  status          = "ACTIVE"
+  description     = "Terraform Acceptance Test SignOn Policy"
+  groups_included = [data.okta_group.all.id]
+}
+
+resource "okta_policy_rule_signon" "first" {
+  policy_id = okta_policy_signon.test.id
+  name      = "testAcc_replace_with_uuid_first"
+  status    = "ACTIVE"
+}
+
+resource "okta_policy_rule_signon" "second" {
+  policy_id = okta_policy_signon.test.id
+  name      = "testAcc_replace_with_uuid_second"
+  status    = "ACTIVE"
+}
+
+resource "okta_policy_rule_signon" "third" {
+  policy_id = okta_policy_signon.test.id
+  name      = "testAcc_replace_with_uuid_third"
+  status    = "ACTIVE"
+}
+
+resource "okta_policy_rule_order" "test" {
+  policy_id = okta_policy_signon.test.id
+  rule_ids = [
+    okta_policy_rule_signon.first.id,
+    okta_policy_rule_signon.second.id,
+    okta_policy_rule_signon.third.id,
+  ]
+}
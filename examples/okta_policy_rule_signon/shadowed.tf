@@ -0,0 +1,24 @@
+data "okta_group" "all" {
+  name = "Everyone"
+}
+
+resource "okta_policy_signon" "test" {
+  name            = "testAcc_replace_with_uuid"
+  status          = "ACTIVE"
+  description     = "Terraform Acceptance Test SignOn Policy"
+  groups_included = [data.okta_group.all.id]
+}
+
+resource "okta_policy_rule_signon" "test" {
+  policy_id = okta_policy_signon.test.id
+  name      = "testAcc_replace_with_uuid"
+  status    = "ACTIVE"
+}
+
+resource "okta_policy_rule_signon" "shadowed" {
+  policy_id          = okta_policy_signon.test.id
+  name               = "testAcc_replace_with_uuid_shadowed"
+  status             = "ACTIVE"
+  network_connection = "ON_NETWORK"
+  shadowing_check    = "ERROR"
+}
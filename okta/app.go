@@ -44,13 +44,30 @@ var (
 			DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
 				return new == ""
 			},
-			StateFunc: localFileStateFunc,
+			StateFunc:     localFileStateFunc,
+			ConflictsWith: []string{"logo_content"},
+		},
+		"logo_content": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			ValidateDiagFunc: imageContentIsValid(),
+			Description:      "Base64 encoded logo of the application.",
+			DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+				return new == ""
+			},
+			StateFunc:     base64ContentStateFunc,
+			ConflictsWith: []string{"logo"},
 		},
 		"logo_url": {
 			Type:        schema.TypeString,
 			Computed:    true,
 			Description: "URL of the application's logo",
 		},
+		"logo_hash": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "SHA-256 hash of the logo served by Okta, used to detect logo changes made outside of Terraform",
+		},
 		"admin_note": {
 			Type:        schema.TypeString,
 			Optional:    true,
@@ -129,10 +146,11 @@ var (
 
 	baseAppSwaSchema = map[string]*schema.Schema{
 		"user_name_template": {
-			Type:        schema.TypeString,
-			Optional:    true,
-			Default:     "${source.login}",
-			Description: "Username template",
+			Type:             schema.TypeString,
+			Optional:         true,
+			Default:          "${source.login}",
+			ValidateDiagFunc: userNameTemplateIsValid,
+			Description:      "Username template",
 		},
 		"user_name_template_suffix": {
 			Type:        schema.TypeString,
@@ -313,13 +331,20 @@ func listApplicationGroupAssignments(ctx context.Context, client *sdk.Client, id
 	return groups, resp, nil
 }
 
-func handleAppLogo(ctx context.Context, d *schema.ResourceData, m interface{}, appID string, links interface{}) error {
-	l, ok := d.GetOk("logo")
-	if !ok {
-		return nil
+func listApplicationUsers(ctx context.Context, client *sdk.Client, id string) ([]*sdk.AppUser, *sdk.Response, error) {
+	users, resp, err := client.Application.ListApplicationUsers(ctx, id, &query.Params{Limit: defaultPaginationLimit})
+	if err != nil {
+		return nil, resp, err
 	}
-	_, err := getOktaClientFromMetadata(m).Application.UploadApplicationLogo(ctx, appID, l.(string))
-	return err
+	for resp.HasNextPage() {
+		var additionalUsers []*sdk.AppUser
+		resp, err = resp.Next(ctx, &additionalUsers)
+		if err != nil {
+			return nil, resp, err
+		}
+		users = append(users, additionalUsers...)
+	}
+	return users, resp, nil
 }
 
 func setAppStatus(ctx context.Context, d *schema.ResourceData, client *sdk.Client, status string) error {
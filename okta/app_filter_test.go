@@ -0,0 +1,42 @@
+package okta
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppDataSourcesListQuery(t *testing.T) {
+	var filter, q string
+	ctx, m := newTestConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		filter, q = r.URL.Query().Get("filter"), r.URL.Query().Get("q")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[]`))
+	})
+	dataSources := map[string]struct {
+		resource *schema.Resource
+		read     func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics
+	}{
+		appOAuth: {dataSourceAppOauth(), dataSourceAppOauthRead},
+		appSaml:  {dataSourceAppSaml(), dataSourceAppSamlRead},
+	}
+	for name, ds := range dataSources {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, ds.resource.Schema, map[string]interface{}{"label": "test"})
+			diags := ds.read(ctx, d, m)
+			require.True(t, diags.HasError())
+			assert.Equal(t, `status eq "ACTIVE"`, filter)
+			assert.Equal(t, "test", q)
+
+			d = schema.TestResourceDataRaw(t, ds.resource.Schema, map[string]interface{}{"label_prefix": "te", "active_only": false})
+			_ = ds.read(ctx, d, m)
+			assert.Empty(t, filter)
+			assert.Equal(t, "te", q)
+		})
+	}
+}
@@ -2,6 +2,9 @@ package okta
 
 import (
 	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestLogoStateFunc(t *testing.T) {
@@ -29,3 +32,23 @@ func TestLogoStateFunc(t *testing.T) {
 		}
 	}
 }
+
+func TestUserNameTemplateIsValid(t *testing.T) {
+	path := cty.GetAttrPath("user_name_template")
+	for _, template := range []string{
+		"${source.login}",
+		"${source.firstName}.${source.lastName}@example.com",
+		"${fn:substringBefore(source.login, \"@\")}",
+		"String.toLowerCase(source.login)",
+		"user.firstName",
+	} {
+		assert.Empty(t, userNameTemplateIsValid(template, path), template)
+	}
+	for _, template := range []string{
+		"${source.login",
+		"${String.toLower(source.login)}",
+		"String.substringBefore(source.login)",
+	} {
+		assert.NotEmpty(t, userNameTemplateIsValid(template, path), template)
+	}
+}
@@ -0,0 +1,260 @@
+package okta
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/okta/terraform-provider-okta/sdk"
+)
+
+func dataSourceAppOAuthGrants() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAppOAuthGrantsRead,
+		Schema: map[string]*schema.Schema{
+			"app_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "ID of the OAuth application",
+			},
+			"user_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "List the consent grants and refresh tokens of this user for the application instead of those of the application",
+			},
+			"grants": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Active scope consent grants",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"issuer": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"scope": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"source": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ADMIN for admin consent, END_USER for the consent of a user",
+						},
+						"user_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"created_by": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"created": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"refresh_tokens": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Active refresh tokens issued to the application",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"issuer": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"scopes": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"user_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"created": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"expires_at": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceAppOAuthGrantsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	appID := d.Get("app_id").(string)
+	var (
+		grants []*sdk.OAuth2ScopeConsentGrant
+		tokens []*sdk.OAuth2RefreshToken
+		err    error
+	)
+	if userID, ok := d.GetOk("user_id"); ok {
+		grants, err = listUserOAuthGrants(ctx, m, userID.(string), appID)
+		if err != nil {
+			return diag.Errorf("failed to list consent grants of user '%s': %v", userID, err)
+		}
+		tokens, err = listUserOAuthRefreshTokens(ctx, m, userID.(string), appID)
+		if err != nil {
+			return diag.Errorf("failed to list refresh tokens of user '%s': %v", userID, err)
+		}
+		d.SetId(appID + "/" + userID.(string))
+	} else {
+		grants, err = listAppOAuthGrants(ctx, m, appID)
+		if err != nil {
+			return diag.Errorf("failed to list application scope consent grants: %v", err)
+		}
+		tokens, err = listAppOAuthRefreshTokens(ctx, m, appID)
+		if err != nil {
+			return diag.Errorf("failed to list application refresh tokens: %v", err)
+		}
+		d.SetId(appID)
+	}
+	_ = d.Set("grants", flattenOAuthGrants(grants))
+	_ = d.Set("refresh_tokens", flattenOAuthRefreshTokens(tokens))
+	return nil
+}
+
+func listAppOAuthGrants(ctx context.Context, m interface{}, appID string) ([]*sdk.OAuth2ScopeConsentGrant, error) {
+	grants, resp, err := getOktaClientFromMetadata(m).Application.ListScopeConsentGrants(ctx, appID, nil)
+	if err != nil {
+		return nil, err
+	}
+	for resp.HasNextPage() {
+		var nextGrants []*sdk.OAuth2ScopeConsentGrant
+		resp, err = resp.Next(ctx, &nextGrants)
+		if err != nil {
+			return nil, err
+		}
+		grants = append(grants, nextGrants...)
+	}
+	return grants, nil
+}
+
+func listUserOAuthGrants(ctx context.Context, m interface{}, userID, appID string) ([]*sdk.OAuth2ScopeConsentGrant, error) {
+	grants, resp, err := getOktaClientFromMetadata(m).User.ListGrantsForUserAndClient(ctx, userID, appID, nil)
+	if err != nil {
+		return nil, err
+	}
+	for resp.HasNextPage() {
+		var nextGrants []*sdk.OAuth2ScopeConsentGrant
+		resp, err = resp.Next(ctx, &nextGrants)
+		if err != nil {
+			return nil, err
+		}
+		grants = append(grants, nextGrants...)
+	}
+	return grants, nil
+}
+
+// listAppOAuthRefreshTokens lists the refresh tokens issued to the
+// application by any authorization server.
+func listAppOAuthRefreshTokens(ctx context.Context, m interface{}, appID string) ([]*sdk.OAuth2RefreshToken, error) {
+	tokens, resp, err := getOktaClientFromMetadata(m).Application.ListOAuth2TokensForApplication(ctx, appID, nil)
+	if err != nil {
+		return nil, err
+	}
+	for resp.HasNextPage() {
+		var nextTokens []*sdk.OAuth2Token
+		resp, err = resp.Next(ctx, &nextTokens)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, nextTokens...)
+	}
+	refreshTokens := make([]*sdk.OAuth2RefreshToken, len(tokens))
+	for i, token := range tokens {
+		refreshTokens[i] = &sdk.OAuth2RefreshToken{
+			ClientId:  token.ClientId,
+			Created:   token.Created,
+			ExpiresAt: token.ExpiresAt,
+			Id:        token.Id,
+			Issuer:    token.Issuer,
+			Scopes:    token.Scopes,
+			Status:    token.Status,
+			UserId:    token.UserId,
+		}
+	}
+	return refreshTokens, nil
+}
+
+func listUserOAuthRefreshTokens(ctx context.Context, m interface{}, userID, appID string) ([]*sdk.OAuth2RefreshToken, error) {
+	tokens, resp, err := getOktaClientFromMetadata(m).User.ListRefreshTokensForUserAndClient(ctx, userID, appID, nil)
+	if err != nil {
+		return nil, err
+	}
+	for resp.HasNextPage() {
+		var nextTokens []*sdk.OAuth2RefreshToken
+		resp, err = resp.Next(ctx, &nextTokens)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, nextTokens...)
+	}
+	return tokens, nil
+}
+
+func flattenOAuthGrants(grants []*sdk.OAuth2ScopeConsentGrant) []interface{} {
+	arr := []interface{}{}
+	for _, grant := range grants {
+		if grant.Status != statusActive {
+			continue
+		}
+		g := map[string]interface{}{
+			"id":         grant.Id,
+			"issuer":     grant.Issuer,
+			"scope":      grant.ScopeId,
+			"source":     grant.Source,
+			"user_id":    grant.UserId,
+			"created_by": "",
+			"created":    formatOAuthTime(grant.Created),
+		}
+		if grant.CreatedBy != nil {
+			g["created_by"] = grant.CreatedBy.Id
+		}
+		arr = append(arr, g)
+	}
+	return arr
+}
+
+func flattenOAuthRefreshTokens(tokens []*sdk.OAuth2RefreshToken) []interface{} {
+	arr := []interface{}{}
+	for _, token := range tokens {
+		if token.Status != statusActive {
+			continue
+		}
+		arr = append(arr, map[string]interface{}{
+			"id":         token.Id,
+			"issuer":     token.Issuer,
+			"scopes":     convertStringSliceToInterfaceSlice(token.Scopes),
+			"user_id":    token.UserId,
+			"created":    formatOAuthTime(token.Created),
+			"expires_at": formatOAuthTime(token.ExpiresAt),
+		})
+	}
+	return arr
+}
+
+func formatOAuthTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,57 @@
+package okta
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/okta/terraform-provider-okta/sdk"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccDataSourceOktaAppOAuthGrants_read(t *testing.T) {
+	mgr := newFixtureManager(appOAuthGrants, t.Name())
+	config := mgr.GetFixtures("datasource.tf", t)
+
+	oktaResourceTest(t, resource.TestCase{
+		PreCheck:          testAccPreCheck(t),
+		ErrorCheck:        testAccErrorChecks(t),
+		ProviderFactories: testAccProvidersFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.okta_app_oauth_grants.test", "grants.#", "1"),
+					resource.TestCheckResourceAttr("data.okta_app_oauth_grants.test", "grants.0.scope", "okta.users.read"),
+					resource.TestCheckResourceAttr("data.okta_app_oauth_grants.test", "grants.0.source", "ADMIN"),
+					resource.TestCheckResourceAttr("data.okta_app_oauth_grants.test", "refresh_tokens.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestFlattenOAuthGrants(t *testing.T) {
+	created := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	grants := flattenOAuthGrants([]*sdk.OAuth2ScopeConsentGrant{
+		{Id: "oag1", Issuer: "https://example.okta.com/oauth2/aus1", ScopeId: "orders:read", Source: "END_USER", UserId: "00u1", Status: statusActive, Created: &created, CreatedBy: &sdk.OAuth2Actor{Id: "00u1", Type: "User"}},
+		{Id: "oag2", ScopeId: "orders:write", Status: "REVOKED"},
+	})
+	assert.Equal(t, []interface{}{map[string]interface{}{
+		"id":         "oag1",
+		"issuer":     "https://example.okta.com/oauth2/aus1",
+		"scope":      "orders:read",
+		"source":     "END_USER",
+		"user_id":    "00u1",
+		"created_by": "00u1",
+		"created":    "2023-06-01T12:00:00Z",
+	}}, grants)
+
+	tokens := flattenOAuthRefreshTokens([]*sdk.OAuth2RefreshToken{
+		{Id: "oar1", Scopes: []string{"openid", "offline_access"}, Status: statusActive, Created: &created},
+		{Id: "oar2", Status: "REVOKED"},
+	})
+	assert.Len(t, tokens, 1)
+	assert.Equal(t, []interface{}{"openid", "offline_access"}, tokens[0].(map[string]interface{})["scopes"])
+	assert.Equal(t, "", tokens[0].(map[string]interface{})["expires_at"])
+}
@@ -297,7 +297,7 @@ func dataSourceAppSamlRead(ctx context.Context, d *schema.ResourceData, m interf
 		app = respApp.(*sdk.SamlApplication)
 	} else {
 		re := getOktaClientFromMetadata(m).GetRequestExecutor()
-		qp := &query.Params{Limit: 1, Filter: filters.Status, Q: filters.getQ()}
+		qp := &query.Params{Limit: 1, Filter: filters.getFilter(), Q: filters.getQ()}
 		req, err := re.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/apps%s", qp.String()), nil)
 		if err != nil {
 			return diag.Errorf("failed to list SAML apps: %v", err)
@@ -0,0 +1,162 @@
+package okta
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/crc32"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceApps() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAppsRead,
+		Schema: map[string]*schema.Schema{
+			"active_only": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Search only ACTIVE applications.",
+			},
+			"q": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Searches the label and name of applications with a starts with match",
+			},
+			"label_regex": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Regular expression the application label must match",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Name of the application in the Okta Integration Network catalog, e.g. `okta_org2org`",
+			},
+			"sign_on_mode": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Sign on mode of the application, e.g. `SAML_2_0` or `OPENID_CONNECT`",
+			},
+			"user_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "Only applications assigned to this user",
+				ConflictsWith: []string{"group_id"},
+			},
+			"group_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "Only applications assigned to this group",
+				ConflictsWith: []string{"user_id"},
+			},
+			"expand_user": {
+				Type:         schema.TypeBool,
+				Optional:     true,
+				Description:  "Embed the app user of `user_id` in each application",
+				RequiredWith: []string{"user_id"},
+			},
+			"apps": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"label": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"sign_on_mode": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"created": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"last_updated": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"links": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Discoverable resources related to the app",
+						},
+						"app_user": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Raw JSON of the app user embedded when `expand_user` is set",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceAppsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	filters := &appFilters{
+		LabelPrefix: d.Get("q").(string),
+		Name:        d.Get("name").(string),
+		SignOnMode:  d.Get("sign_on_mode").(string),
+		UserID:      d.Get("user_id").(string),
+		GroupID:     d.Get("group_id").(string),
+		ExpandUser:  d.Get("expand_user").(bool),
+	}
+	if d.Get("active_only").(bool) {
+		filters.Status = statusActive
+	}
+	if v, ok := d.GetOk("label_regex"); ok {
+		re, err := regexp.Compile(v.(string))
+		if err != nil {
+			return diag.Errorf("invalid label_regex: %v", err)
+		}
+		filters.LabelRegex = re
+	}
+	apps, err := listApps(ctx, getOktaClientFromMetadata(m), filters, defaultPaginationLimit)
+	if err != nil {
+		return diag.Errorf("failed to list apps: %v", err)
+	}
+	arr := make([]map[string]interface{}, len(apps))
+	for i, app := range apps {
+		links, _ := json.Marshal(app.Links)
+		arr[i] = map[string]interface{}{
+			"id":           app.Id,
+			"name":         app.Name,
+			"label":        app.Label,
+			"status":       app.Status,
+			"sign_on_mode": app.SignOnMode,
+			"links":        string(links),
+		}
+		if app.Created != nil {
+			arr[i]["created"] = app.Created.String()
+		}
+		if app.LastUpdated != nil {
+			arr[i]["last_updated"] = app.LastUpdated.String()
+		}
+		if embedded, ok := app.Embedded.(map[string]interface{}); ok && embedded["user"] != nil {
+			appUser, _ := json.Marshal(embedded["user"])
+			arr[i]["app_user"] = string(appUser)
+		}
+	}
+	id := fmt.Sprintf("%s|%s|%s|%s|%s|%s|%t|%s", filters.Status, filters.LabelPrefix, d.Get("label_regex").(string),
+		filters.Name, filters.SignOnMode, filters.UserID, filters.ExpandUser, filters.GroupID)
+	d.SetId(fmt.Sprintf("%d", crc32.ChecksumIEEE([]byte(id))))
+	_ = d.Set("apps", arr)
+	return nil
+}
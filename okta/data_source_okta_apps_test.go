@@ -0,0 +1,31 @@
+package okta
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccOktaDataSourceApps_read(t *testing.T) {
+	mgr := newFixtureManager(apps, t.Name())
+	config := mgr.GetFixtures("datasource.tf", t)
+
+	oktaResourceTest(t, resource.TestCase{
+		PreCheck:          testAccPreCheck(t),
+		ErrorCheck:        testAccErrorChecks(t),
+		ProviderFactories: testAccProvidersFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.okta_apps.test", "apps.#", "2"),
+					resource.TestCheckResourceAttr("data.okta_apps.oidc", "apps.#", "1"),
+					resource.TestCheckResourceAttr("data.okta_apps.oidc", "apps.0.sign_on_mode", "OPENID_CONNECT"),
+					resource.TestCheckResourceAttr("data.okta_apps.regex", "apps.#", "1"),
+					resource.TestCheckResourceAttr("data.okta_apps.regex", "apps.0.name", "bookmark"),
+					resource.TestCheckResourceAttr("data.okta_apps.regex", "apps.0.label", buildResourceName(mgr.Seed)),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,283 @@
+package okta
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/okta/terraform-provider-okta/okta/internal/el"
+	"github.com/okta/terraform-provider-okta/sdk"
+)
+
+func dataSourceAuthServerClaimsPreview() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAuthServerClaimsPreviewRead,
+		Schema: map[string]*schema.Schema{
+			"auth_server_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Auth server ID",
+			},
+			"scopes": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Scopes granted to the token",
+			},
+			"grant_type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "authorization_code",
+				Description: "Grant type of the token request, no user is involved with 'client_credentials'",
+			},
+			"client_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Client ID of the app requesting the token, 'app.clientId' in expressions",
+			},
+			"user_profile": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Profile of the user, 'user' in expressions",
+			},
+			"app_user_profile": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "App profile of the user, 'appuser' in expressions",
+			},
+			"groups": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Groups of the user",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+					},
+				},
+			},
+			"id_token_claims": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Custom claims of the ID token, values other than strings are JSON encoded",
+			},
+			"access_token_claims": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Custom claims of the access token, values other than strings are JSON encoded",
+			},
+		},
+	}
+}
+
+func dataSourceAuthServerClaimsPreviewRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	authServerID := d.Get("auth_server_id").(string)
+	claims, _, err := getOktaClientFromMetadata(m).AuthorizationServer.ListOAuth2Claims(ctx, authServerID)
+	if err != nil {
+		return diag.Errorf("failed to list auth server claims: %v", err)
+	}
+	req := &claimsPreviewRequest{
+		scopes:    convertInterfaceToStringSet(d.Get("scopes")),
+		grantType: d.Get("grant_type").(string),
+		clientID:  d.Get("client_id").(string),
+	}
+	if profile, ok := d.GetOk("user_profile"); ok {
+		req.userProfile = profile.(map[string]interface{})
+	}
+	if profile, ok := d.GetOk("app_user_profile"); ok {
+		req.appUserProfile = profile.(map[string]interface{})
+	}
+	for _, g := range d.Get("groups").([]interface{}) {
+		group := g.(map[string]interface{})
+		req.groups = append(req.groups, el.Group{ID: group["id"].(string), Name: group["name"].(string)})
+	}
+	idTokenClaims, accessTokenClaims, err := previewAuthServerClaims(claims, req)
+	if err != nil {
+		return diag.Errorf("failed to evaluate auth server claims: %v", err)
+	}
+	idTokenMap, err := flattenPreviewClaims(idTokenClaims)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	accessTokenMap, err := flattenPreviewClaims(accessTokenClaims)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	sort.Strings(req.scopes)
+	d.SetId(fmt.Sprintf("%s.%d", authServerID, crc32.ChecksumIEEE([]byte(req.grantType+strings.Join(req.scopes, " ")))))
+	_ = d.Set("id_token_claims", idTokenMap)
+	_ = d.Set("access_token_claims", accessTokenMap)
+	return nil
+}
+
+// claimsPreviewRequest is the synthetic token request the claims are
+// evaluated for.
+type claimsPreviewRequest struct {
+	scopes         []string
+	grantType      string
+	clientID       string
+	userProfile    map[string]interface{}
+	appUserProfile map[string]interface{}
+	groups         []el.Group
+}
+
+func (r *claimsPreviewRequest) hasUser() bool {
+	return r.grantType != "client_credentials"
+}
+
+func (r *claimsPreviewRequest) env() *el.Env {
+	variables := map[string]interface{}{
+		"access": map[string]interface{}{"scope": convertStringSliceToInterfaceSlice(r.scopes)},
+		"app":    map[string]interface{}{"clientId": r.clientID},
+	}
+	if r.hasUser() {
+		variables["user"] = nonNilMap(r.userProfile)
+		variables["appuser"] = nonNilMap(r.appUserProfile)
+	}
+	env := &el.Env{Variables: variables}
+	if r.hasUser() {
+		env.Groups = r.groups
+	}
+	return env
+}
+
+func nonNilMap(m map[string]interface{}) map[string]interface{} {
+	if m == nil {
+		return map[string]interface{}{}
+	}
+	return m
+}
+
+// previewAuthServerClaims evaluates the custom claims of an authorization
+// server as Okta would when minting tokens for the request. Claims evaluating
+// to null or to no groups are omitted, like Okta does.
+func previewAuthServerClaims(claims []*sdk.OAuth2Claim, req *claimsPreviewRequest) (idToken, accessToken map[string]interface{}, err error) {
+	idToken = map[string]interface{}{}
+	accessToken = map[string]interface{}{}
+	env := req.env()
+	var errs []error
+	for _, claim := range claims {
+		if claim == nil || (claim.Status != "" && claim.Status != statusActive) || !claimScopesMatch(claim, req.scopes) {
+			continue
+		}
+		var tokenClaims map[string]interface{}
+		switch claim.ClaimType {
+		case "IDENTITY":
+			// ID tokens are only minted for users with the openid scope, claims
+			// not always included are left to the userinfo endpoint
+			if !req.hasUser() || !contains(req.scopes, "openid") ||
+				(claim.AlwaysIncludeInToken != nil && !*claim.AlwaysIncludeInToken) {
+				continue
+			}
+			tokenClaims = idToken
+		case "RESOURCE":
+			tokenClaims = accessToken
+		default:
+			continue
+		}
+		value, err := evalClaimValue(claim, env)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("claim '%s': %w", claim.Name, err))
+			continue
+		}
+		if value == nil {
+			continue
+		}
+		if groups, ok := value.([]interface{}); ok && claim.ValueType == "GROUPS" && len(groups) == 0 {
+			continue
+		}
+		tokenClaims[claim.Name] = value
+	}
+	return idToken, accessToken, errors.Join(errs...)
+}
+
+// claimScopesMatch tells whether a claim restricted to some scopes applies to
+// a token with the scopes.
+func claimScopesMatch(claim *sdk.OAuth2Claim, scopes []string) bool {
+	if claim.Conditions == nil || len(claim.Conditions.Scopes) == 0 {
+		return true
+	}
+	for _, scope := range claim.Conditions.Scopes {
+		if contains(scopes, scope) {
+			return true
+		}
+	}
+	return false
+}
+
+func evalClaimValue(claim *sdk.OAuth2Claim, env *el.Env) (interface{}, error) {
+	switch claim.ValueType {
+	case "", "EXPRESSION":
+		return el.Eval(claim.Value, env)
+	case "GROUPS":
+		return filterClaimGroups(claim.GroupFilterType, claim.Value, env.Groups)
+	}
+	return nil, fmt.Errorf("unsupported value type '%s'", claim.ValueType)
+}
+
+// filterClaimGroups returns the names of the groups matching the filter of a
+// groups claim, at most 100 like Okta.
+func filterClaimGroups(filterType, filter string, groups []el.Group) (interface{}, error) {
+	var match func(string) bool
+	switch filterType {
+	case "STARTS_WITH":
+		match = func(name string) bool { return strings.HasPrefix(name, filter) }
+	case "EQUALS":
+		match = func(name string) bool { return name == filter }
+	case "CONTAINS":
+		match = func(name string) bool { return strings.Contains(name, filter) }
+	case "REGEX":
+		re, err := regexp.Compile("^(?:" + filter + ")$")
+		if err != nil {
+			return nil, fmt.Errorf("invalid group filter regular expression: %v", err)
+		}
+		match = re.MatchString
+	default:
+		return nil, fmt.Errorf("unsupported group filter type '%s'", filterType)
+	}
+	names := []interface{}{}
+	for _, g := range groups {
+		if len(names) == 100 {
+			break
+		}
+		if match(g.Name) {
+			names = append(names, g.Name)
+		}
+	}
+	return names, nil
+}
+
+// flattenPreviewClaims converts claims to a map of strings, values other than
+// strings are JSON encoded.
+func flattenPreviewClaims(claims map[string]interface{}) (map[string]interface{}, error) {
+	flattened := make(map[string]interface{}, len(claims))
+	for name, value := range claims {
+		if s, ok := value.(string); ok {
+			flattened[name] = s
+			continue
+		}
+		b, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode value of claim '%s': %v", name, err)
+		}
+		flattened[name] = string(b)
+	}
+	return flattened, nil
+}
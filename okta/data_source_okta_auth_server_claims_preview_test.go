@@ -0,0 +1,91 @@
+package okta
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/okta/terraform-provider-okta/okta/internal/el"
+	"github.com/okta/terraform-provider-okta/sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccDataSourceOktaAuthServerClaimsPreview_read(t *testing.T) {
+	mgr := newFixtureManager(authServerClaimsPreview, t.Name())
+	config := mgr.GetFixtures("datasource.tf", t)
+	resourceName := "data.okta_auth_server_claims_preview.test"
+
+	oktaResourceTest(t, resource.TestCase{
+		PreCheck:          testAccPreCheck(t),
+		ErrorCheck:        testAccErrorChecks(t),
+		ProviderFactories: testAccProvidersFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "access_token_claims.department", "SALES"),
+					resource.TestCheckResourceAttr(resourceName, "id_token_claims.email_domain", "example.com"),
+					resource.TestCheckResourceAttr(resourceName, "id_token_claims.groups", `["App-Admins"]`),
+				),
+			},
+		},
+	})
+}
+
+func TestPreviewAuthServerClaims(t *testing.T) {
+	claims := []*sdk.OAuth2Claim{
+		{Name: "department", ClaimType: "RESOURCE", ValueType: "EXPRESSION", Value: "String.toUpperCase(user.department)", Status: statusActive},
+		{Name: "scoped", ClaimType: "RESOURCE", ValueType: "EXPRESSION", Value: `"yes"`, Status: statusActive, Conditions: &sdk.OAuth2ClaimConditions{Scopes: []string{"api:write"}}},
+		{Name: "inactive", ClaimType: "RESOURCE", ValueType: "EXPRESSION", Value: `"yes"`, Status: statusInactive},
+		{Name: "is_admin", ClaimType: "RESOURCE", ValueType: "EXPRESSION", Value: `isMemberOfGroupName("App-Admins")`, Status: statusActive},
+		{Name: "client", ClaimType: "RESOURCE", ValueType: "EXPRESSION", Value: "app.clientId", Status: statusActive},
+		{Name: "nickname", ClaimType: "IDENTITY", ValueType: "EXPRESSION", Value: "user.nickName", Status: statusActive},
+		{Name: "email_domain", ClaimType: "IDENTITY", ValueType: "EXPRESSION", Value: `String.substringAfter(user.email, "@")`, Status: statusActive},
+		{Name: "userinfo_only", ClaimType: "IDENTITY", ValueType: "EXPRESSION", Value: `"x"`, Status: statusActive, AlwaysIncludeInToken: boolPtr(false)},
+		{Name: "groups", ClaimType: "IDENTITY", ValueType: "GROUPS", GroupFilterType: "REGEX", Value: "App-.*", Status: statusActive},
+		{Name: "no_groups", ClaimType: "IDENTITY", ValueType: "GROUPS", GroupFilterType: "EQUALS", Value: "Nobody", Status: statusActive},
+	}
+	req := &claimsPreviewRequest{
+		scopes:      []string{"openid", "api:read"},
+		grantType:   "authorization_code",
+		clientID:    "0oa1",
+		userProfile: map[string]interface{}{"email": "john.doe@example.com", "department": "sales"},
+		groups:      []el.Group{{Name: "Everyone"}, {Name: "App-Admins"}, {Name: "App-Users"}},
+	}
+	idToken, accessToken, err := previewAuthServerClaims(claims, req)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"department": "SALES",
+		"is_admin":   true,
+		"client":     "0oa1",
+	}, accessToken)
+	assert.Equal(t, map[string]interface{}{
+		"email_domain": "example.com",
+		"groups":       []interface{}{"App-Admins", "App-Users"},
+	}, idToken)
+
+	flattened, err := flattenPreviewClaims(idToken)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"email_domain": "example.com", "groups": `["App-Admins","App-Users"]`}, flattened)
+
+	// without openid there is no ID token
+	req.scopes = []string{"api:read", "api:write"}
+	idToken, accessToken, err = previewAuthServerClaims(claims, req)
+	require.NoError(t, err)
+	assert.Empty(t, idToken)
+	assert.Equal(t, "yes", accessToken["scoped"])
+}
+
+func TestPreviewAuthServerClaimsErrors(t *testing.T) {
+	claims := []*sdk.OAuth2Claim{
+		{Name: "department", ClaimType: "RESOURCE", Value: "user.department", Status: statusActive},
+		{Name: "broken", ClaimType: "RESOURCE", Value: "String.toUpperCase(", Status: statusActive},
+		{Name: "regex", ClaimType: "RESOURCE", ValueType: "GROUPS", GroupFilterType: "REGEX", Value: "(", Status: statusActive},
+	}
+	// no user with client credentials
+	_, _, err := previewAuthServerClaims(claims, &claimsPreviewRequest{scopes: []string{"api:read"}, grantType: "client_credentials"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "claim 'department': unknown variable 'user'")
+	assert.Contains(t, err.Error(), "claim 'broken': unexpected end of expression")
+	assert.Contains(t, err.Error(), "claim 'regex': invalid group filter regular expression")
+}
@@ -0,0 +1,36 @@
+package okta
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/okta/terraform-provider-okta/okta/internal/eventtypes"
+)
+
+func dataSourceEventHookEventTypes() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceEventHookEventTypesRead,
+		Schema: map[string]*schema.Schema{
+			"prefix": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only list the event types starting with this prefix, e.g. 'user.lifecycle.'",
+			},
+			"event_types": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Sorted event types which can be delivered to event hooks, a partial list",
+			},
+		},
+	}
+}
+
+func dataSourceEventHookEventTypesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	prefix := d.Get("prefix").(string)
+	d.SetId(fmt.Sprintf("event_hook_event_types-%s", prefix))
+	_ = d.Set("event_types", convertStringSliceToInterfaceSlice(eventtypes.WithPrefix(prefix)))
+	return nil
+}
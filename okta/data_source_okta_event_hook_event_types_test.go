@@ -0,0 +1,29 @@
+package okta
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceOktaEventHookEventTypes_read(t *testing.T) {
+	mgr := newFixtureManager(eventHookEventTypes, t.Name())
+	config := mgr.GetFixtures("datasource.tf", t)
+
+	oktaResourceTest(t, resource.TestCase{
+		PreCheck:          testAccPreCheck(t),
+		ErrorCheck:        testAccErrorChecks(t),
+		ProviderFactories: testAccProvidersFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.okta_event_hook_event_types.all", "event_types.#"),
+					resource.TestCheckTypeSetElemAttr("data.okta_event_hook_event_types.all", "event_types.*", "group.user_membership.add"),
+					resource.TestCheckResourceAttr("data.okta_event_hook_event_types.user_lifecycle", "event_types.#", "8"),
+					resource.TestCheckResourceAttr("data.okta_event_hook_event_types.user_lifecycle", "event_types.0", "user.lifecycle.activate"),
+				),
+			},
+		},
+	})
+}
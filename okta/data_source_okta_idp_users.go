@@ -0,0 +1,108 @@
+package okta
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/okta/terraform-provider-okta/sdk"
+)
+
+func dataSourceIdpUsers() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceIdpUsersRead,
+		Schema: map[string]*schema.Schema{
+			"idp_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "ID of the IdP",
+			},
+			"users": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Okta users linked to the IdP",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ID of the Okta user",
+						},
+						"external_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ID of the user at the IdP",
+						},
+						"created": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"last_updated": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"profile": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "JSON of the profile of the user at the IdP",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceIdpUsersRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	idpID := d.Get("idp_id").(string)
+	users, err := listIdpUsers(ctx, m, idpID)
+	if err != nil {
+		return diag.Errorf("failed to list identity provider users: %v", err)
+	}
+	arr, err := flattenIdpUsers(users)
+	if err != nil {
+		return diag.Errorf("failed to set identity provider users: %v", err)
+	}
+	d.SetId(idpID)
+	_ = d.Set("users", arr)
+	return nil
+}
+
+func listIdpUsers(ctx context.Context, m interface{}, idpID string) ([]*sdk.IdentityProviderApplicationUser, error) {
+	users, resp, err := getOktaClientFromMetadata(m).IdentityProvider.ListIdentityProviderApplicationUsers(ctx, idpID)
+	if err != nil {
+		return nil, err
+	}
+	for resp.HasNextPage() {
+		var nextUsers []*sdk.IdentityProviderApplicationUser
+		resp, err = resp.Next(ctx, &nextUsers)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, nextUsers...)
+	}
+	return users, nil
+}
+
+func flattenIdpUsers(users []*sdk.IdentityProviderApplicationUser) ([]interface{}, error) {
+	arr := make([]interface{}, len(users))
+	for i, user := range users {
+		var profile string
+		if user.Profile != nil {
+			b, err := json.Marshal(user.Profile)
+			if err != nil {
+				return nil, err
+			}
+			profile = string(b)
+		}
+		arr[i] = map[string]interface{}{
+			"id":           user.Id,
+			"external_id":  user.ExternalId,
+			"created":      user.Created,
+			"last_updated": user.LastUpdated,
+			"profile":      profile,
+		}
+	}
+	return arr, nil
+}
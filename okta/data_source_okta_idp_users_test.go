@@ -0,0 +1,55 @@
+package okta
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/okta/terraform-provider-okta/sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccDataSourceOktaIdpUsers_read(t *testing.T) {
+	mgr := newFixtureManager(idpUsers, t.Name())
+	config := mgr.GetFixtures("datasource.tf", t)
+
+	oktaResourceTest(t, resource.TestCase{
+		PreCheck:          testAccPreCheck(t),
+		ErrorCheck:        testAccErrorChecks(t),
+		ProviderFactories: testAccProvidersFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.okta_idp_users.test", "users.#", "1"),
+					resource.TestCheckResourceAttrPair("data.okta_idp_users.test", "users.0.id", "okta_user.test", "id"),
+					resource.TestCheckResourceAttr("data.okta_idp_users.test", "users.0.external_id", "118296312345678901234"),
+				),
+			},
+		},
+	})
+}
+
+func TestFlattenIdpUsers(t *testing.T) {
+	users, err := flattenIdpUsers([]*sdk.IdentityProviderApplicationUser{
+		{Id: "00u1", ExternalId: "1182963", Created: "2023-06-01T12:00:00.000Z", Profile: map[string]interface{}{"email": "john@example.com"}},
+		{Id: "00u2", ExternalId: "1182964"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{
+			"id":           "00u1",
+			"external_id":  "1182963",
+			"created":      "2023-06-01T12:00:00.000Z",
+			"last_updated": "",
+			"profile":      `{"email":"john@example.com"}`,
+		},
+		map[string]interface{}{
+			"id":           "00u2",
+			"external_id":  "1182964",
+			"created":      "",
+			"last_updated": "",
+			"profile":      "",
+		},
+	}, users)
+}
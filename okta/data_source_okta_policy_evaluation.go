@@ -0,0 +1,247 @@
+package okta
+
+import (
+	"context"
+	"fmt"
+	"hash/crc32"
+	"net/netip"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/okta/terraform-provider-okta/sdk"
+)
+
+func dataSourcePolicyEvaluation() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePolicyEvaluationRead,
+		Schema: map[string]*schema.Schema{
+			"policy_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "ID of the policy to evaluate",
+			},
+			"user_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "ID of the user signing in, their groups are used unless `group_ids` is set",
+			},
+			"group_ids": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "IDs of the groups of the user signing in",
+			},
+			"network_zone_ids": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "IDs of the network zones the sign-in comes from",
+			},
+			"ip": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "IP address the sign-in comes from, it is resolved to the network zones used by the rules",
+			},
+			"platform_type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Platform of the device signing in: DESKTOP, MOBILE or OTHER",
+			},
+			"os_type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Operating system of the device signing in, e.g. WINDOWS, MACOS, IOS or ANDROID",
+			},
+			"device_registered": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Whether the device signing in is registered",
+			},
+			"device_managed": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Whether the device signing in is managed",
+			},
+			"risk_level": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Risk level of the sign-in: LOW, MEDIUM or HIGH",
+			},
+			"policy_applies": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the policy applies to the sign-in",
+			},
+			"rule_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the matching rule",
+			},
+			"rule_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Name of the matching rule",
+			},
+			"rule_priority": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Priority of the matching rule",
+			},
+			"actions": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "JSON of the actions of the matching rule",
+			},
+			"rules": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Rules of the policy in the order of their evaluation",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"priority": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"matches": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"reason": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Why the rule doesn't match",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourcePolicyEvaluationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	policyID := d.Get("policy_id").(string)
+	d.SetId(policyID)
+	policy, err := getPolicy(ctx, d, m)
+	if err != nil {
+		return diag.Errorf("failed to get policy: %v", err)
+	}
+	if policy == nil {
+		return diag.Errorf("policy '%s' does not exist", policyID)
+	}
+	rawRules, _, err := getAPISupplementFromMetadata(m).ListPolicyRulesJSON(ctx, policyID)
+	if err != nil {
+		return diag.Errorf("failed to list policy rules: %v", err)
+	}
+	rules := make([]*evaluatedPolicyRule, len(rawRules))
+	for i := range rawRules {
+		rules[i], err = parseEvaluatedPolicyRule(rawRules[i])
+		if err != nil {
+			return diag.Errorf("failed to parse policy rule: %v", err)
+		}
+	}
+	sort.SliceStable(rules, func(i, j int) bool {
+		return rules[i].Priority < rules[j].Priority
+	})
+
+	evalCtx, err := buildPolicyEvaluationContext(ctx, d, m, rules)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	applies, _ := evalCtx.policyApplies(&policy.Policy)
+	_ = d.Set("policy_applies", applies)
+	var matched *evaluatedPolicyRule
+	arr := make([]map[string]interface{}, len(rules))
+	for i, rule := range rules {
+		ok, reason := false, "a rule with higher priority matches"
+		switch {
+		case !applies:
+			reason = "policy does not apply"
+		case matched == nil:
+			ok, reason = evalCtx.matchRule(rule)
+			if ok {
+				matched = rule
+			}
+		}
+		arr[i] = map[string]interface{}{
+			"id":       rule.ID,
+			"name":     rule.Name,
+			"priority": rule.Priority,
+			"matches":  ok,
+			"reason":   reason,
+		}
+	}
+	if err = setNonPrimitives(d, map[string]interface{}{"rules": arr}); err != nil {
+		return diag.Errorf("failed to set policy rules: %v", err)
+	}
+	if matched != nil {
+		_ = d.Set("rule_id", matched.ID)
+		_ = d.Set("rule_name", matched.Name)
+		_ = d.Set("rule_priority", matched.Priority)
+		_ = d.Set("actions", normalizeDataJSON(string(matched.Actions)))
+	} else {
+		_ = d.Set("rule_id", "")
+		_ = d.Set("rule_name", "")
+		_ = d.Set("rule_priority", 0)
+		_ = d.Set("actions", "")
+	}
+	id := fmt.Sprintf("%s|%s|%s|%s|%s|%s|%s|%t|%t|%s", policyID, evalCtx.UserID, strings.Join(evalCtx.GroupIDs, ","),
+		strings.Join(evalCtx.ZoneIDs, ","), d.Get("ip").(string), evalCtx.PlatformType, evalCtx.OSType,
+		evalCtx.DeviceRegistered, evalCtx.DeviceManaged, evalCtx.RiskLevel)
+	d.SetId(fmt.Sprintf("%d", crc32.ChecksumIEEE([]byte(id))))
+	return nil
+}
+
+func buildPolicyEvaluationContext(ctx context.Context, d *schema.ResourceData, m interface{}, rules []*evaluatedPolicyRule) (*policyEvaluationContext, error) {
+	evalCtx := &policyEvaluationContext{
+		UserID:           d.Get("user_id").(string),
+		GroupIDs:         convertInterfaceToStringSetNullable(d.Get("group_ids")),
+		ZoneIDs:          convertInterfaceToStringSetNullable(d.Get("network_zone_ids")),
+		PlatformType:     d.Get("platform_type").(string),
+		OSType:           d.Get("os_type").(string),
+		DeviceRegistered: d.Get("device_registered").(bool),
+		DeviceManaged:    d.Get("device_managed").(bool),
+		RiskLevel:        d.Get("risk_level").(string),
+	}
+	client := getOktaClientFromMetadata(m)
+	if _, ok := d.GetOk("group_ids"); !ok && evalCtx.UserID != "" {
+		groupIDs, err := getGroupsForUser(ctx, evalCtx.UserID, client)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list groups of user '%s': %v", evalCtx.UserID, err)
+		}
+		evalCtx.GroupIDs = groupIDs
+	}
+	if v, ok := d.GetOk("ip"); ok {
+		ip, err := netip.ParseAddr(v.(string))
+		if err != nil {
+			return nil, fmt.Errorf("invalid IP address '%s': %v", v.(string), err)
+		}
+		var zones []*sdk.NetworkZone
+		for _, id := range ruleZoneIDs(rules) {
+			zone, resp, err := client.NetworkZone.GetNetworkZone(ctx, id)
+			if err := suppressErrorOn404(resp, err); err != nil {
+				return nil, fmt.Errorf("failed to get network zone '%s': %v", id, err)
+			}
+			if zone != nil {
+				zones = append(zones, zone)
+			}
+		}
+		for _, id := range zonesContainingIP(zones, ip) {
+			if !contains(evalCtx.ZoneIDs, id) {
+				evalCtx.ZoneIDs = append(evalCtx.ZoneIDs, id)
+			}
+		}
+	}
+	sort.Strings(evalCtx.GroupIDs)
+	sort.Strings(evalCtx.ZoneIDs)
+	return evalCtx, nil
+}
@@ -0,0 +1,30 @@
+package okta
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccOktaDataSourcePolicyEvaluation_read(t *testing.T) {
+	mgr := newFixtureManager(policyEvaluation, t.Name())
+	config := mgr.GetFixtures("datasource.tf", t)
+
+	oktaResourceTest(t, resource.TestCase{
+		PreCheck:          testAccPreCheck(t),
+		ErrorCheck:        testAccErrorChecks(t),
+		ProviderFactories: testAccProvidersFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.okta_policy_evaluation.member", "policy_applies", "true"),
+					resource.TestCheckResourceAttrPair("data.okta_policy_evaluation.member", "rule_id", "okta_policy_rule_signon.mobile", "id"),
+					resource.TestCheckResourceAttr("data.okta_policy_evaluation.member", "rule_priority", "1"),
+					resource.TestCheckResourceAttr("data.okta_policy_evaluation.other", "policy_applies", "false"),
+					resource.TestCheckResourceAttr("data.okta_policy_evaluation.other", "rule_id", ""),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,41 @@
+package okta
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourcePolicyExport() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePolicyExportRead,
+		Schema: map[string]*schema.Schema{
+			"policy_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "ID of the policy to export",
+			},
+			"json": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Canonical JSON of the policy and its rules, ordered by priority, as expected by okta_policy_restore",
+			},
+		},
+	}
+}
+
+func dataSourcePolicyExportRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	policyID := d.Get("policy_id").(string)
+	snapshot, err := exportPolicy(ctx, m, policyID)
+	if err != nil {
+		return diag.Errorf("failed to export policy '%s': %v", policyID, err)
+	}
+	value, err := snapshot.String()
+	if err != nil {
+		return diag.Errorf("failed to export policy '%s': %v", policyID, err)
+	}
+	d.SetId(policyID)
+	_ = d.Set("json", value)
+	return nil
+}
@@ -0,0 +1,29 @@
+package okta
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccOktaDataSourcePolicyExport_read(t *testing.T) {
+	mgr := newFixtureManager(policyExport, t.Name())
+	config := mgr.GetFixtures("datasource.tf", t)
+
+	oktaResourceTest(t, resource.TestCase{
+		PreCheck:          testAccPreCheck(t),
+		ErrorCheck:        testAccErrorChecks(t),
+		ProviderFactories: testAccProvidersFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.okta_policy_export.test", "id", "okta_policy_signon.test", "id"),
+					resource.TestMatchResourceAttr("data.okta_policy_export.test", "json", regexp.MustCompile(`"name":"`+buildResourceName(mgr.Seed)+`"`)),
+					resource.TestMatchResourceAttr("data.okta_policy_export.test", "json", regexp.MustCompile(`"access":"DENY"`)),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,504 @@
+package el
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// typ is the static type of an expression, tAny when it can't be known
+// before evaluation, such as the attributes of a profile.
+type typ int
+
+const (
+	tAny typ = iota
+	tNull
+	tBool
+	tNumber
+	tString
+	tArray
+	tObject
+)
+
+func (t typ) String() string {
+	return [...]string{"any", "null", "boolean", "number", "string", "array", "object"}[t]
+}
+
+// signature is the types of the parameters and of the result of a function,
+// the last parameter of a variadic function is repeated.
+type signature struct {
+	params []typ
+	result typ
+}
+
+var signatures = map[string]signature{
+	"String.append":          {[]typ{tString, tString}, tString},
+	"String.endsWith":        {[]typ{tString, tString}, tBool},
+	"String.join":            {[]typ{tString, tString}, tString},
+	"String.len":             {[]typ{tString}, tNumber},
+	"String.removeSpaces":    {[]typ{tString}, tString},
+	"String.replace":         {[]typ{tString, tString, tString}, tString},
+	"String.replaceFirst":    {[]typ{tString, tString, tString}, tString},
+	"String.startsWith":      {[]typ{tString, tString}, tBool},
+	"String.stringContains":  {[]typ{tString, tString}, tBool},
+	"String.stringSwitch":    {[]typ{tString, tAny, tAny}, tAny},
+	"String.substring":       {[]typ{tString, tNumber, tNumber}, tString},
+	"String.substringAfter":  {[]typ{tString, tString}, tString},
+	"String.substringBefore": {[]typ{tString, tString}, tString},
+	"String.toLowerCase":     {[]typ{tString}, tString},
+	"String.toUpperCase":     {[]typ{tString}, tString},
+
+	"Arrays.add":         {[]typ{tArray, tAny}, tArray},
+	"Arrays.remove":      {[]typ{tArray, tAny}, tArray},
+	"Arrays.clear":       {[]typ{tArray}, tArray},
+	"Arrays.get":         {[]typ{tArray, tNumber}, tAny},
+	"Arrays.flatten":     {[]typ{tAny}, tArray},
+	"Arrays.contains":    {[]typ{tArray, tAny}, tBool},
+	"Arrays.size":        {[]typ{tArray}, tNumber},
+	"Arrays.isEmpty":     {[]typ{tArray}, tBool},
+	"Arrays.toCsvString": {[]typ{tArray}, tString},
+
+	"Convert.toInt": {[]typ{tAny}, tNumber},
+	"Convert.toNum": {[]typ{tAny}, tNumber},
+
+	"Groups.startsWith": {[]typ{tString, tString, tNumber}, tArray},
+	"Groups.endsWith":   {[]typ{tString, tString, tNumber}, tArray},
+	"Groups.contains":   {[]typ{tString, tString, tNumber}, tArray},
+
+	"getFilteredGroups":             {[]typ{tArray, tString, tNumber}, tArray},
+	"isMemberOfGroup":               {[]typ{tString}, tBool},
+	"isMemberOfAnyGroup":            {[]typ{tString}, tBool},
+	"isMemberOfGroupName":           {[]typ{tString}, tBool},
+	"isMemberOfGroupNameStartsWith": {[]typ{tString}, tBool},
+	"isMemberOfGroupNameContains":   {[]typ{tString}, tBool},
+	"isMemberOfGroupNameRegex":      {[]typ{tString}, tBool},
+
+	"Time.now":                  {[]typ{tString, tString}, tString},
+	"Time.fromWindowsToIso8601": {[]typ{tAny}, tString},
+	"Time.fromUnixToIso8601":    {[]typ{tAny}, tString},
+	"Time.fromStringToIso8601":  {[]typ{tString, tString}, tString},
+	"Time.fromIso8601ToWindows": {[]typ{tString}, tString},
+	"Time.fromIso8601ToUnix":    {[]typ{tString}, tString},
+	"Time.fromIso8601ToString":  {[]typ{tString, tString}, tString},
+
+	"Iso3166Convert.toAlpha2":  {[]typ{tString}, tString},
+	"Iso3166Convert.toAlpha3":  {[]typ{tString}, tString},
+	"Iso3166Convert.toNumeric": {[]typ{tString}, tString},
+	"Iso3166Convert.toName":    {[]typ{tString}, tString},
+
+	"hasDirectoryUser":    {nil, tBool},
+	"hasWorkdayUser":      {nil, tBool},
+	"findDirectoryUser":   {nil, tObject},
+	"findWorkdayUser":     {nil, tObject},
+	"getManagerUser":      {[]typ{tString}, tObject},
+	"getManagerAppUser":   {[]typ{tString, tString}, tObject},
+	"getAssistantUser":    {[]typ{tString}, tObject},
+	"getAssistantAppUser": {[]typ{tString, tString}, tObject},
+}
+
+// methodSignatures are the signatures of the methods of strings, their first
+// parameter is the receiver.
+var methodSignatures = map[string]signature{
+	"contains":        {[]typ{tString, tString}, tBool},
+	"endsWith":        {[]typ{tString, tString}, tBool},
+	"length":          {[]typ{tString}, tNumber},
+	"startsWith":      {[]typ{tString, tString}, tBool},
+	"substring":       {[]typ{tString, tNumber, tNumber}, tString},
+	"substringAfter":  {[]typ{tString, tString}, tString},
+	"substringBefore": {[]typ{tString, tString}, tString},
+	"toLowerCase":     {[]typ{tString}, tString},
+	"toUpperCase":     {[]typ{tString}, tString},
+	"trim":            {[]typ{tString}, tString},
+}
+
+// objectMethods are the methods of the user objects, such as
+// user.getInternalProperty("id"), which the checker accepts with any
+// arguments.
+var objectMethods = map[string]bool{
+	"getGroups":           true,
+	"getInternalProperty": true,
+	"getLinkedObject":     true,
+	"isMemberOf":          true,
+}
+
+// Check parses an expression and checks it statically: the functions it
+// calls and their number of arguments, and the types of the arguments and of
+// the operands where they are known. The top level names of the expression
+// must be in variables, unless variables is nil. Calls of functions and
+// methods the checker doesn't know are returned as warnings rather than
+// errors, Okta may support them.
+func Check(src string, variables []string) ([]*Error, error) {
+	expr, err := Parse(src)
+	if err != nil {
+		return nil, err
+	}
+	return expr.Check(variables)
+}
+
+// Check checks the parsed expression, see Check.
+func (e *Expression) Check(variables []string) ([]*Error, error) {
+	c := &checker{}
+	if variables != nil {
+		c.variables = map[string]bool{}
+		for _, v := range variables {
+			c.variables[v] = true
+		}
+	}
+	_, err := c.check(e.root)
+	if err != nil {
+		return nil, err
+	}
+	return c.warnings, nil
+}
+
+type checker struct {
+	variables map[string]bool
+	warnings  []*Error
+}
+
+func (c *checker) warnf(pos int, format string, args ...interface{}) {
+	c.warnings = append(c.warnings, errorf(pos, format, args...))
+}
+
+func (c *checker) isVariable(name string) bool {
+	return c.variables == nil || c.variables[name]
+}
+
+func (c *checker) isNamespace(name string) bool {
+	return namespaces[name] && (c.variables == nil || !c.variables[name])
+}
+
+func (c *checker) check(n node) (typ, error) {
+	switch n := n.(type) {
+	case *literalNode:
+		return typeOf(n.value), nil
+	case *identNode:
+		if c.isNamespace(n.name) {
+			return tAny, errorf(n.pos, "'%s' is a namespace of functions, not a value", n.name)
+		}
+		if !c.isVariable(n.name) {
+			return tAny, errorf(n.pos, "unknown variable '%s'", n.name)
+		}
+		return tObject, nil
+	case *memberNode:
+		x, err := c.check(n.x)
+		if err != nil {
+			return tAny, err
+		}
+		if x != tAny && x != tObject && x != tNull {
+			return tAny, errorf(n.pos, "cannot get '%s' of %s", n.name, x)
+		}
+		return tAny, nil
+	case *indexNode:
+		x, err := c.check(n.x)
+		if err != nil {
+			return tAny, err
+		}
+		index, err := c.check(n.index)
+		if err != nil {
+			return tAny, err
+		}
+		switch x {
+		case tArray:
+			if !compatible(index, tNumber) {
+				return tAny, errorf(n.index.position(), "expected an integer index, got %s", index)
+			}
+		case tObject:
+			if !compatible(index, tString) {
+				return tAny, errorf(n.index.position(), "expected a string key, got %s", index)
+			}
+		case tAny, tNull:
+		default:
+			return tAny, errorf(n.pos, "cannot index %s", x)
+		}
+		return tAny, nil
+	case *callNode:
+		return c.checkCall(n)
+	case *unaryNode:
+		x, err := c.check(n.x)
+		if err != nil {
+			return tAny, err
+		}
+		if n.op == "!" {
+			if !compatible(x, tBool) {
+				return tAny, errorf(n.x.position(), "expected a boolean, got %s", x)
+			}
+			return tBool, nil
+		}
+		if !compatible(x, tNumber) {
+			return tAny, errorf(n.pos, "expected a number after '%s', got %s", n.op, x)
+		}
+		return tNumber, nil
+	case *binaryNode:
+		return c.checkBinary(n)
+	case *conditionalNode:
+		cond, err := c.check(n.cond)
+		if err != nil {
+			return tAny, err
+		}
+		if !compatible(cond, tBool) {
+			return tAny, errorf(n.cond.position(), "expected a boolean, got %s", cond)
+		}
+		then, err := c.check(n.then)
+		if err != nil {
+			return tAny, err
+		}
+		els, err := c.check(n.els)
+		if err != nil {
+			return tAny, err
+		}
+		if then == els {
+			return then, nil
+		}
+		return tAny, nil
+	case *elvisNode:
+		if _, err := c.check(n.x); err != nil {
+			return tAny, err
+		}
+		if _, err := c.check(n.y); err != nil {
+			return tAny, err
+		}
+		return tAny, nil
+	case *projectionNode:
+		x, err := c.check(n.x)
+		if err != nil {
+			return tAny, err
+		}
+		if x != tAny && x != tArray && x != tNull {
+			return tAny, errorf(n.pos, "cannot project %s", x)
+		}
+		// the names of the expression are the attributes of the elements
+		elem := &checker{}
+		_, err = elem.check(n.expr)
+		c.warnings = append(c.warnings, elem.warnings...)
+		return tArray, err
+	case *selectionNode:
+		x, err := c.check(n.x)
+		if err != nil {
+			return tAny, err
+		}
+		if x != tAny && x != tArray && x != tObject && x != tNull {
+			return tAny, errorf(n.pos, "cannot select from %s", x)
+		}
+		elem := &checker{}
+		cond, err := elem.check(n.expr)
+		c.warnings = append(c.warnings, elem.warnings...)
+		if err != nil {
+			return tAny, err
+		}
+		if !compatible(cond, tBool) {
+			return tAny, errorf(n.expr.position(), "expected a boolean, got %s", cond)
+		}
+		if n.op == "?" && x != tAny {
+			return x, nil
+		}
+		return tAny, nil
+	case *contextVariableNode:
+		switch n.name {
+		case "root":
+			return tObject, nil
+		case "this":
+			return tAny, nil
+		}
+		c.warnf(n.pos, "unknown context variable '#%s'", n.name)
+		return tAny, nil
+	case *typeNode:
+		return tAny, errorf(n.pos, "type references are only supported after 'instanceof'")
+	case *listNode:
+		for _, elem := range n.elems {
+			if _, err := c.check(elem); err != nil {
+				return tAny, err
+			}
+		}
+		return tArray, nil
+	case *mapNode:
+		for _, value := range n.values {
+			if _, err := c.check(value); err != nil {
+				return tAny, err
+			}
+		}
+		return tObject, nil
+	}
+	return tAny, errorf(n.position(), "unsupported expression")
+}
+
+func (c *checker) checkCall(n *callNode) (typ, error) {
+	args := make([]typ, 0, len(n.args)+1)
+	name := n.name
+	var sig signature
+	var fn *function
+	if ident, ok := n.receiver.(*identNode); n.receiver == nil || ok && c.isNamespace(ident.name) {
+		if n.receiver != nil {
+			name = ident.name + "." + n.name
+		}
+		fn, sig = functions[name], signatures[name]
+	} else {
+		r, err := c.check(n.receiver)
+		if err != nil {
+			return tAny, err
+		}
+		switch {
+		case (r == tString || r == tAny) && methods[name] != nil:
+			fn, sig = methods[name], methodSignatures[name]
+			args = append(args, tString)
+		case (r == tObject || r == tAny) && objectMethods[name]:
+			return tAny, c.checkArgs(n.args)
+		case r == tAny || r == tObject || r == tString:
+			c.warnf(n.pos, "unknown method '%s'", name)
+			return tAny, c.checkArgs(n.args)
+		default:
+			return tAny, errorf(n.pos, "cannot call '%s' on %s", name, r)
+		}
+	}
+	if fn == nil {
+		c.warnf(n.pos, "unknown function '%s'", name)
+		return tAny, c.checkArgs(n.args)
+	}
+	for _, arg := range n.args {
+		t, err := c.check(arg)
+		if err != nil {
+			return tAny, err
+		}
+		args = append(args, t)
+	}
+	if err := fn.checkArgCount(name, len(args)); err != nil {
+		return tAny, errorf(n.pos, "%v", err)
+	}
+	for i, t := range args {
+		if len(sig.params) == 0 {
+			break
+		}
+		param := sig.params[len(sig.params)-1]
+		if i < len(sig.params) {
+			param = sig.params[i]
+		}
+		if !compatible(t, param) {
+			pos := n.pos
+			if j := i - (len(args) - len(n.args)); j >= 0 {
+				pos = n.args[j].position()
+			}
+			return tAny, errorf(pos, "%s: argument %d must be %s, got %s", name, i+1, article(param), t)
+		}
+	}
+	return sig.result, nil
+}
+
+// checkArgs checks the arguments of a call whose signature isn't known.
+func (c *checker) checkArgs(args []node) error {
+	for _, arg := range args {
+		if _, err := c.check(arg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *checker) checkBinary(n *binaryNode) (typ, error) {
+	x, err := c.check(n.x)
+	if err != nil {
+		return tAny, err
+	}
+	if n.op == "instanceof" {
+		return c.checkInstanceOf(n)
+	}
+	y, err := c.check(n.y)
+	if err != nil {
+		return tAny, err
+	}
+	mismatch := errorf(n.pos, "cannot apply '%s' to %s and %s", n.op, x, y)
+	switch n.op {
+	case "&&", "||":
+		if !compatible(x, tBool) {
+			return tAny, errorf(n.x.position(), "expected a boolean, got %s", x)
+		}
+		if !compatible(y, tBool) {
+			return tAny, errorf(n.y.position(), "expected a boolean, got %s", y)
+		}
+		return tBool, nil
+	case "==", "!=":
+		return tBool, nil
+	case "+":
+		if x == tString || y == tString {
+			return tString, nil
+		}
+		if x == tAny || y == tAny {
+			// either may be a string at runtime
+			return tAny, nil
+		}
+		if x == tNumber && y == tNumber {
+			return tNumber, nil
+		}
+		return tAny, mismatch
+	case "<", "<=", ">", ">=":
+		if compatible(x, tNumber) && compatible(y, tNumber) || compatible(x, tString) && compatible(y, tString) {
+			return tBool, nil
+		}
+		return tAny, mismatch
+	case "matches":
+		if !compatible(x, tString) || !compatible(y, tString) {
+			return tAny, mismatch
+		}
+		if lit, ok := n.y.(*literalNode); ok {
+			if _, err := regexp.Compile(lit.value.(string)); err != nil {
+				// Okta evaluates Java regular expressions, whose syntax
+				// differs from Go's
+				c.warnf(n.y.position(), "regular expression not checked: %v", err)
+			}
+		}
+		return tBool, nil
+	case "between":
+		if !compatible(y, tArray) {
+			return tAny, errorf(n.y.position(), "expected a list of 2 bounds after 'between', got %s", y)
+		}
+		if list, ok := n.y.(*listNode); ok && len(list.elems) != 2 {
+			return tAny, errorf(n.y.position(), "expected a list of 2 bounds after 'between', got %d elements", len(list.elems))
+		}
+		return tBool, nil
+	}
+	if !compatible(x, tNumber) || !compatible(y, tNumber) {
+		return tAny, mismatch
+	}
+	return tNumber, nil
+}
+
+func (c *checker) checkInstanceOf(n *binaryNode) (typ, error) {
+	ref, ok := n.y.(*typeNode)
+	if !ok {
+		return tAny, errorf(n.y.position(), "expected a type, such as T(String), after 'instanceof'")
+	}
+	if _, ok := javaType(ref.name); !ok {
+		c.warnf(ref.pos, "unknown type '%s'", ref.name)
+	}
+	return tBool, nil
+}
+
+// compatible tells whether a value of type t may be used where a value of
+// type expected is, null being accepted everywhere as it is at runtime.
+func compatible(t, expected typ) bool {
+	return t == expected || t == tAny || expected == tAny || t == tNull
+}
+
+func typeOf(v interface{}) typ {
+	switch v.(type) {
+	case nil:
+		return tNull
+	case bool:
+		return tBool
+	case float64:
+		return tNumber
+	case string:
+		return tString
+	case []interface{}:
+		return tArray
+	case map[string]interface{}:
+		return tObject
+	}
+	return tAny
+}
+
+func article(t typ) string {
+	switch t {
+	case tArray, tObject, tAny:
+		return fmt.Sprintf("an %s", t)
+	}
+	return fmt.Sprintf("a %s", t)
+}
@@ -0,0 +1,145 @@
+package el
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheck(t *testing.T) {
+	valid := []string{
+		`user.department == "Sales"`,
+		`String.stringContains(user.email, "@example.com") AND isMemberOfGroupName("Everyone")`,
+		`user.login.toLowerCase()`,
+		`login.identifier.substringAfter('@')`,
+		`user.getInternalProperty("id")`,
+		`user.isMemberOf({'group.id': {'00g1', '00g2'}})`,
+		`String.substring(user.firstName, 0, 1) + user.lastName`,
+		`Arrays.contains(access.scope, "api:read") ? "yes" : null`,
+		`Convert.toInt(user.costCenter) > 10`,
+		`user.level + 1`,
+		`user.middleName ?: "n/a"`,
+		`Time.fromIso8601ToUnix(Time.now())`,
+		`Iso3166Convert.toAlpha2(user.countryCode)`,
+		`hasWorkdayUser() ? findWorkdayUser().status : "none"`,
+		`getManagerUser("active_directory").firstName`,
+		`user.groups[0]`,
+		`{"a", "b"}[1]`,
+		`String.toUpperCase(null)`,
+		`isMemberOfAnyGroup("00g1", "00g2", "00g3")`,
+		`getFilteredGroups({"00gabc123", "00gdef456"}, "group.name", 40)`,
+		`user.getGroups({'group.type': {'OKTA_GROUP'}}).![name]`,
+		`Arrays.contains(user.getGroups({'group.type': {'OKTA_GROUP'}}).![profile.name], "Sales")`,
+		`user.email matches ".*@x.com"`,
+		`user.email matches '[a-z]+\\.[a-z]+@x\\.com'`,
+		`user?.firstName`,
+		`user?.login?.toLowerCase()`,
+		`user.getGroups().?[name == 'A']`,
+		`user.getGroups().^[name != 'Everyone'].id`,
+		`user.getGroups().$[#this.name != 'Everyone']`,
+		`user.firstName instanceof T(String)`,
+		`user.level instanceof T(java.lang.Integer)`,
+		`user.level between {1, 5}`,
+		`1.5e3 > 1`,
+		`2E-3 < 1`,
+		`#root.user.firstName`,
+	}
+	for _, expr := range valid {
+		warnings, err := Check(expr, nil)
+		assert.NoError(t, err, expr)
+		assert.Empty(t, warnings, expr)
+	}
+}
+
+func TestCheckWarnings(t *testing.T) {
+	tests := []struct {
+		expr    string
+		warning string
+	}{
+		{`String.toUppercase(user.firstName)`, "unknown function 'String.toUppercase' at position 8"},
+		{`isMemberOfGroupNames("Everyone")`, "unknown function 'isMemberOfGroupNames'"},
+		{`user.firstName.foo()`, "unknown method 'foo'"},
+		{`user.foo("x")`, "unknown method 'foo'"},
+		{`"a".foo()`, "unknown method 'foo'"},
+		{`user.getGroups().![String.foo(name)]`, "unknown function 'String.foo'"},
+		{`user.getGroups().?[String.foo(name)]`, "unknown function 'String.foo'"},
+		{`#foo.firstName`, "unknown context variable '#foo'"},
+		{`user.level instanceof T(Date)`, "unknown type 'Date'"},
+		{`user.email matches '(?=.*@x.com).*'`, "regular expression not checked"},
+	}
+	for _, test := range tests {
+		t.Run(test.expr, func(t *testing.T) {
+			warnings, err := Check(test.expr, []string{"user"})
+			require.NoError(t, err)
+			require.Len(t, warnings, 1)
+			assert.Contains(t, warnings[0].Error(), test.warning)
+		})
+	}
+}
+
+func TestCheckErrors(t *testing.T) {
+	tests := []struct {
+		expr      string
+		variables []string
+		err       string
+	}{
+		{`user.firstName +`, nil, "unexpected end of expression"},
+		{`String.substringAfter(user.email)`, nil, "String.substringAfter expects 2 arguments, got 1"},
+		{`String.len(1)`, nil, "String.len: argument 1 must be a string, got number at position 12"},
+		{`Arrays.size("a")`, nil, "Arrays.size: argument 1 must be an array, got string"},
+		{`isMemberOfAnyGroup("00g1", 2)`, nil, "isMemberOfAnyGroup: argument 2 must be a string, got number"},
+		{`"a".substring("b")`, nil, "substring: argument 2 must be a number, got string"},
+		{`(1).toLowerCase()`, nil, "cannot call 'toLowerCase' on number"},
+		{`"a".length`, nil, "cannot get 'length' of string"},
+		{`true[0]`, nil, "cannot index boolean"},
+		{`{"a"}["b"]`, nil, "expected an integer index, got string"},
+		{`"a" && true`, nil, "expected a boolean, got string at position 1"},
+		{`!user.firstName.length()`, nil, "expected a boolean, got number"},
+		{`-"a"`, nil, "expected a number after '-', got string"},
+		{`"a" - 1`, nil, "cannot apply '-' to string and number"},
+		{`true + 1`, nil, "cannot apply '+' to boolean and number"},
+		{`"a" < 1`, nil, "cannot apply '<' to string and number"},
+		{`"yes" ? 1 : 2`, nil, "expected a boolean, got string"},
+		{`String`, nil, "'String' is a namespace of functions, not a value"},
+		{`usr.department == "Sales"`, []string{"user"}, "unknown variable 'usr' at position 1"},
+		{`"a".![name]`, nil, "cannot project string"},
+		{`getFilteredGroups("00g1", "group.name", 40)`, nil, "getFilteredGroups: argument 1 must be an array, got string"},
+		{`user.getGroups().!name`, nil, "expected '[', got identifier 'name'"},
+		{`"a".?[true]`, nil, "cannot select from string"},
+		{`user.getGroups().?[1]`, nil, "expected a boolean, got number"},
+		{`user.getGroups().?name`, nil, "expected '[', got identifier 'name'"},
+		{`user.#root`, nil, "expected a name after '.', got identifier '#root'"},
+		{`1 matches "1"`, nil, "cannot apply 'matches' to number and string"},
+		{`user.level between 1`, nil, "expected a list of 2 bounds after 'between', got number"},
+		{`user.level between {1, 2, 3}`, nil, "expected a list of 2 bounds after 'between', got 3 elements"},
+		{`user.level instanceof "String"`, nil, "expected a type, such as T(String), after 'instanceof'"},
+		{`T(String)`, nil, "type references are only supported after 'instanceof'"},
+		{`T(java.)`, nil, "expected a type name"},
+		{`1e`, nil, "unexpected identifier 'e'"},
+	}
+	for _, test := range tests {
+		t.Run(test.expr, func(t *testing.T) {
+			_, err := Check(test.expr, test.variables)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), test.err)
+		})
+	}
+}
+
+func TestSignatures(t *testing.T) {
+	for name, fn := range functions {
+		sig, ok := signatures[name]
+		if assert.True(t, ok, "missing signature of %s", name) && fn.maxArgs >= 0 {
+			assert.Equal(t, fn.maxArgs, len(sig.params), "parameters of %s", name)
+		}
+	}
+	for name, fn := range methods {
+		sig, ok := methodSignatures[name]
+		if assert.True(t, ok, "missing signature of method %s", name) {
+			assert.Equal(t, fn.maxArgs, len(sig.params), "parameters of method %s", name)
+		}
+	}
+	assert.Equal(t, len(functions), len(signatures))
+	assert.Equal(t, len(methods), len(methodSignatures))
+}
@@ -0,0 +1,70 @@
+// Package el parses, checks and evaluates Okta Expression Language
+// expressions, the SpEL based language of claims, group rules, profile
+// mappings and policy conditions. Evaluation is local: the variables of an
+// expression, such as the user profile, are supplied by the caller with an
+// Env.
+package el
+
+import (
+	"fmt"
+)
+
+// Error is a syntax or evaluation error of an expression.
+type Error struct {
+	// Pos is the offset of the error in the expression, in runes.
+	Pos int
+	Msg string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s at position %d", e.Msg, e.Pos+1)
+}
+
+func errorf(pos int, format string, args ...interface{}) *Error {
+	return &Error{Pos: pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+// Expression is a parsed expression.
+type Expression struct {
+	src  string
+	root node
+}
+
+// Parse parses an expression.
+func Parse(src string) (*Expression, error) {
+	tokens, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	root, err := p.parseExpression()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokenEOF {
+		return nil, errorf(t.pos, "unexpected %s", t)
+	}
+	return &Expression{src: src, root: root}, nil
+}
+
+func (e *Expression) String() string {
+	return e.src
+}
+
+// Eval evaluates the expression. Its value is nil, a bool, a float64, a
+// string, a []interface{} or a map[string]interface{}.
+func (e *Expression) Eval(env *Env) (interface{}, error) {
+	if env == nil {
+		env = &Env{}
+	}
+	return (&evaluator{env: env}).eval(e.root)
+}
+
+// Eval parses and evaluates an expression.
+func Eval(src string, env *Env) (interface{}, error) {
+	expr, err := Parse(src)
+	if err != nil {
+		return nil, err
+	}
+	return expr.Eval(env)
+}
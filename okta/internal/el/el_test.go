@@ -0,0 +1,217 @@
+package el
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testEnv() *Env {
+	return &Env{
+		Variables: map[string]interface{}{
+			"user": map[string]interface{}{
+				"login":      "John.Doe@example.com",
+				"firstName":  "John",
+				"lastName":   "Doe",
+				"department": "Sales EMEA",
+				"costCenter": "42",
+				"level":      3,
+			},
+			"access": map[string]interface{}{
+				"scope": []string{"openid", "profile", "api:read"},
+			},
+			"groups": []interface{}{
+				map[string]interface{}{"id": "00g1", "name": "Everyone"},
+				map[string]interface{}{"id": "00g2", "name": "App-Admins"},
+			},
+		},
+		Groups: []Group{
+			{ID: "00g1", Name: "Everyone"},
+			{ID: "00g2", Name: "App-Admins"},
+			{ID: "00g3", Name: "App-Users"},
+		},
+		Now: func() time.Time { return time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC) },
+	}
+}
+
+func TestEval(t *testing.T) {
+	tests := []struct {
+		expr     string
+		expected interface{}
+	}{
+		{`"a" + 'b'`, "ab"},
+		{`'it''s'`, "it's"},
+		{`"say \"hi\""`, `say "hi"`},
+		{`1 + 2 * 3`, float64(7)},
+		{`(1 + 2) * 3`, float64(9)},
+		{`-user.level + 1`, float64(-2)},
+		{`7 % 4`, float64(3)},
+		{`user.firstName + " " + user.lastName`, "John Doe"},
+		{`user.middleName`, nil},
+		{`user.middleName + "x"`, "nullx"},
+		{`user.middleName ?: user.firstName`, "John"},
+		{`user.level > 2 ? "senior" : "junior"`, "senior"},
+		{`user.level gt 2 AND user.firstName eq "John"`, true},
+		{`not (user.level == 3) or false`, false},
+		{`user.level != 3 || user.lastName == "Doe"`, true},
+		{`"abc" < "abd"`, true},
+		{`access.scope[1]`, "profile"},
+		{`user["firstName"]`, "John"},
+		{`{"a", "b"}`, []interface{}{"a", "b"}},
+		{`{}`, []interface{}{}},
+		{`{"group.profile.name": "Everyone", id: 1}`, map[string]interface{}{"group.profile.name": "Everyone", "id": float64(1)}},
+		{`{:}`, map[string]interface{}{}},
+		{`String.toUpperCase(user.firstName)`, "JOHN"},
+		{`String.toLowerCase(user.login)`, "john.doe@example.com"},
+		{`String.substringAfter(user.login, "@")`, "example.com"},
+		{`String.substringBefore(user.login, "@")`, "John.Doe"},
+		{`String.substringBefore(user.login, "#")`, "John.Doe@example.com"},
+		{`String.substring(user.firstName, 0, 2)`, "Jo"},
+		{`String.append(user.firstName, "!")`, "John!"},
+		{`String.join(",", "a", "b", "c")`, "a,b,c"},
+		{`String.len(user.lastName)`, float64(3)},
+		{`String.removeSpaces(user.department)`, "SalesEMEA"},
+		{`String.replace("This is a test", "is", "at")`, "That at a test"},
+		{`String.replaceFirst("This is a test", "is", "at")`, "That is a test"},
+		{`String.stringContains(user.department, "EMEA")`, true},
+		{`String.startsWith(user.firstName, "Jo")`, true},
+		{`String.endsWith(user.login, "@example.org")`, false},
+		{`String.stringSwitch(user.department, "other", "Marketing", "mkt", "Sales", "sales")`, "sales"},
+		{`String.stringSwitch(user.department, "other", "Marketing", "mkt")`, "other"},
+		{`user.login.toLowerCase()`, "john.doe@example.com"},
+		{`user.login.substring(5)`, "Doe@example.com"},
+		{`user.login.substringAfter("@")`, "example.com"},
+		{`user.login.substringBefore("@")`, "John.Doe"},
+		{`user.firstName.startsWith("Jo") and user.lastName.length() == 3`, true},
+		{`Arrays.contains(access.scope, "openid")`, true},
+		{`Arrays.size(access.scope)`, float64(3)},
+		{`Arrays.isEmpty({})`, true},
+		{`Arrays.add(access.scope, "email")`, []interface{}{"openid", "profile", "api:read", "email"}},
+		{`Arrays.remove(access.scope, "profile")`, []interface{}{"openid", "api:read"}},
+		{`Arrays.clear(access.scope)`, []interface{}{}},
+		{`Arrays.get(access.scope, 2)`, "api:read"},
+		{`Arrays.get(access.scope, 5)`, nil},
+		{`Arrays.flatten("a", {"b", {"c"}})`, []interface{}{"a", "b", "c"}},
+		{`Arrays.toCsvString(access.scope)`, "openid,profile,api:read"},
+		{`Convert.toInt(user.costCenter) + 1`, float64(43)},
+		{`Convert.toInt(2.5)`, float64(2)},
+		{`Convert.toNum("1.5")`, 1.5},
+		{`isMemberOfGroup("00g2")`, true},
+		{`isMemberOfAnyGroup("00g9", "00g3")`, true},
+		{`isMemberOfGroupName("Everyone")`, true},
+		{`isMemberOfGroupName("Nobody")`, false},
+		{`isMemberOfGroupNameStartsWith("App-")`, true},
+		{`isMemberOfGroupNameContains("Admin")`, true},
+		{`isMemberOfGroupNameRegex("App-.*")`, true},
+		{`isMemberOfGroupNameRegex("App")`, false},
+		{`Groups.startsWith("OKTA", "App-", 10)`, []interface{}{"App-Admins", "App-Users"}},
+		{`Groups.contains("OKTA", "-", 1)`, []interface{}{"App-Admins"}},
+		{`Groups.endsWith("OKTA", "one", 100)`, []interface{}{"Everyone"}},
+		{`Time.now()`, "2023-06-01T12:00:00.000Z"},
+		{`getFilteredGroups({"00g2", "00g3", "00g9"}, "group.name", 40)`, []interface{}{"App-Admins", "App-Users"}},
+		{`getFilteredGroups({"00g1", "00g2", "00g3"}, "group.id", 1)`, []interface{}{"00g1"}},
+		{`groups.![name]`, []interface{}{"Everyone", "App-Admins"}},
+		{`groups.![name.toUpperCase() + user.lastName]`, []interface{}{"EVERYONEDoe", "APP-ADMINSDoe"}},
+		{`user.middleName.![name]`, nil},
+		{`user.login matches ".*@example\\.com"`, true},
+		{`user.login matches "example"`, false},
+		{`user.middleName matches ".*"`, false},
+		{`user.middleName?.toLowerCase()`, nil},
+		{`user?.firstName`, "John"},
+		{`user.login?.toLowerCase()`, "john.doe@example.com"},
+		{`groups.?[name.startsWith("App-")].![id]`, []interface{}{"00g2"}},
+		{`groups.?[name == "Nobody"]`, []interface{}{}},
+		{`groups.^[true].name`, "Everyone"},
+		{`groups.$[true].name`, "App-Admins"},
+		{`groups.^[false]`, nil},
+		{`groups.?[#this.id == "00g1"].![#this.name]`, []interface{}{"Everyone"}},
+		{`user.?[value == "Doe"]`, map[string]interface{}{"lastName": "Doe"}},
+		{`user.^[key.startsWith("l")]`, map[string]interface{}{"lastName": "Doe"}},
+		{`user.$[key.startsWith("l")]`, map[string]interface{}{"login": "John.Doe@example.com"}},
+		{`user.middleName.?[true]`, nil},
+		{`user.firstName instanceof T(String)`, true},
+		{`user.level instanceof T(java.lang.Integer)`, true},
+		{`1.5 instanceof T(Integer)`, false},
+		{`user.level instanceof T(Number)`, true},
+		{`user.level instanceof T(String)`, false},
+		{`groups instanceof T(List)`, true},
+		{`user instanceof T(Map)`, true},
+		{`user.middleName instanceof T(Object)`, false},
+		{`user.level between {1, 3}`, true},
+		{`user.level between {4, 5}`, false},
+		{`user.firstName between {"A", "K"}`, true},
+		{`1.5e3`, float64(1500)},
+		{`2E-1 + 1`, 1.2},
+		{`#root.user.firstName`, "John"},
+		{`#this.user.lastName`, "Doe"},
+	}
+	for _, test := range tests {
+		t.Run(test.expr, func(t *testing.T) {
+			v, err := Eval(test.expr, testEnv())
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, v)
+		})
+	}
+}
+
+func TestEvalErrors(t *testing.T) {
+	tests := []struct {
+		expr string
+		err  string
+	}{
+		{`"abc`, "unterminated string at position 1"},
+		{`user.firstName +`, "unexpected end of expression at position 17"},
+		{`user.firstName user.lastName`, "unexpected identifier 'user' at position 16"},
+		{`user.firstName # 1`, "unexpected character '#' at position 16"},
+		{`String.toUpperCase(user.firstName`, "expected ',' or ')', got end of expression"},
+		{`user.`, "expected a name after '.'"},
+		{`{"a": 1, "b"}`, "expected ':'"},
+		{`{1: "a"}`, "expected a string or a name as map key at position 2"},
+		{`a ? b`, "expected ':'"},
+		{`group.name`, "unknown variable 'group' at position 1"},
+		{`String.foo(user.firstName)`, "unknown function 'String.foo' at position 8"},
+		{`isMemberOfGroupName()`, "isMemberOfGroupName expects 1 arguments, got 0"},
+		{`String.substring("abc", 1)`, "String.substring expects 3 arguments, got 2"},
+		{`String.toUpperCase(user.level)`, "String.toUpperCase: argument 1 must be a string, got number"},
+		{`String.substring("abc", 1, 5)`, "range [1, 5) out of bounds"},
+		{`user.firstName && true`, "expected a boolean, got string"},
+		{`user.firstName - 1`, "cannot apply '-' to string and number"},
+		{`1 / 0`, "division by zero"},
+		{`access.scope[3]`, "index 3 out of bounds of array of size 3"},
+		{`user.level.foo`, "cannot get 'foo' of number"},
+		{`user.middleName.toLowerCase()`, "cannot call 'toLowerCase' on null"},
+		{`isMemberOfGroupNameRegex("(")`, "invalid regular expression"},
+		{`Groups.startsWith("OKTA", "App", 101)`, "limit must be between 1 and 100"},
+		{`getFilteredGroups({"00g1"}, "group.", 40)`, "invalid group expression"},
+		{`user.firstName.![name]`, "cannot project string"},
+		{`user.firstName.?[true]`, "cannot select from string"},
+		{`user.login matches "("`, "invalid regular expression"},
+		{`user.level between {"a", "b"}`, "cannot apply 'between' to number and array"},
+		{`user.level instanceof T(Date)`, "unknown type 'Date'"},
+		{`#foo`, "unknown variable '#foo'"},
+	}
+	for _, test := range tests {
+		t.Run(test.expr, func(t *testing.T) {
+			_, err := Eval(test.expr, testEnv())
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), test.err)
+			var elErr *Error
+			assert.ErrorAs(t, err, &elErr)
+		})
+	}
+}
+
+func TestTextualOperatorAsAttribute(t *testing.T) {
+	v, err := Eval(`user.or`, &Env{Variables: map[string]interface{}{"user": map[string]interface{}{"or": "x"}}})
+	require.NoError(t, err)
+	assert.Equal(t, "x", v)
+}
+
+func TestVariableShadowsNamespace(t *testing.T) {
+	env := &Env{Variables: map[string]interface{}{"String": map[string]interface{}{"x": "y"}}}
+	_, err := Eval(`String.toUpperCase("a")`, env)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot call 'toUpperCase' on object")
+}
@@ -0,0 +1,674 @@
+package el
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Env holds what an expression is evaluated against.
+type Env struct {
+	// Variables are the top level names of the expressions, such as user,
+	// appuser or access. Profiles are maps, members missing from them are
+	// null.
+	Variables map[string]interface{}
+	// Groups are the groups of the user, for the group membership functions.
+	Groups []Group
+	// Now returns the current time for the Time functions, time.Now when
+	// nil.
+	Now func() time.Time
+}
+
+// Group is a group of the user.
+type Group struct {
+	ID   string
+	Name string
+}
+
+func (env *Env) now() time.Time {
+	if env.Now != nil {
+		return env.Now()
+	}
+	return time.Now()
+}
+
+type evaluator struct {
+	env *Env
+	// this is the element of the projection or selection being evaluated,
+	// #this, when inElement is true
+	this      interface{}
+	inElement bool
+}
+
+func (e *evaluator) eval(n node) (interface{}, error) {
+	switch n := n.(type) {
+	case *literalNode:
+		return n.value, nil
+	case *identNode:
+		v, ok := e.env.Variables[n.name]
+		if !ok {
+			return nil, errorf(n.pos, "unknown variable '%s'", n.name)
+		}
+		return normalize(v), nil
+	case *memberNode:
+		x, err := e.eval(n.x)
+		if err != nil {
+			return nil, err
+		}
+		return member(x, n.name, n.pos)
+	case *indexNode:
+		return e.evalIndex(n)
+	case *callNode:
+		return e.evalCall(n)
+	case *unaryNode:
+		return e.evalUnary(n)
+	case *binaryNode:
+		return e.evalBinary(n)
+	case *conditionalNode:
+		cond, err := e.evalBool(n.cond)
+		if err != nil {
+			return nil, err
+		}
+		if cond {
+			return e.eval(n.then)
+		}
+		return e.eval(n.els)
+	case *elvisNode:
+		x, err := e.eval(n.x)
+		if err != nil {
+			return nil, err
+		}
+		if x != nil && x != "" {
+			return x, nil
+		}
+		return e.eval(n.y)
+	case *projectionNode:
+		return e.evalProjection(n)
+	case *selectionNode:
+		return e.evalSelection(n)
+	case *contextVariableNode:
+		return e.evalContextVariable(n)
+	case *typeNode:
+		return nil, errorf(n.pos, "type references are only supported after 'instanceof'")
+	case *listNode:
+		list := make([]interface{}, len(n.elems))
+		for i, elem := range n.elems {
+			v, err := e.eval(elem)
+			if err != nil {
+				return nil, err
+			}
+			list[i] = v
+		}
+		return list, nil
+	case *mapNode:
+		m := make(map[string]interface{}, len(n.keys))
+		for i, key := range n.keys {
+			v, err := e.eval(n.values[i])
+			if err != nil {
+				return nil, err
+			}
+			m[key] = v
+		}
+		return m, nil
+	}
+	return nil, errorf(n.position(), "unsupported expression")
+}
+
+func member(x interface{}, name string, pos int) (interface{}, error) {
+	switch x := x.(type) {
+	case nil:
+		return nil, nil
+	case map[string]interface{}:
+		return normalize(x[name]), nil
+	}
+	return nil, errorf(pos, "cannot get '%s' of %s", name, typeName(x))
+}
+
+func (e *evaluator) evalIndex(n *indexNode) (interface{}, error) {
+	x, err := e.eval(n.x)
+	if err != nil {
+		return nil, err
+	}
+	index, err := e.eval(n.index)
+	if err != nil {
+		return nil, err
+	}
+	switch x := x.(type) {
+	case nil:
+		return nil, nil
+	case []interface{}:
+		i, ok := index.(float64)
+		if !ok || i != math.Trunc(i) {
+			return nil, errorf(n.index.position(), "expected an integer index, got %s", typeName(index))
+		}
+		if i < 0 || int(i) >= len(x) {
+			return nil, errorf(n.index.position(), "index %d out of bounds of array of size %d", int(i), len(x))
+		}
+		return x[int(i)], nil
+	case map[string]interface{}:
+		key, ok := index.(string)
+		if !ok {
+			return nil, errorf(n.index.position(), "expected a string key, got %s", typeName(index))
+		}
+		return normalize(x[key]), nil
+	}
+	return nil, errorf(n.pos, "cannot index %s", typeName(x))
+}
+
+// evalProjection evaluates the expression of x.![expr] for every element of
+// x. The names in the expression are the attributes of the element, or the
+// variables when the element has no such attribute.
+func (e *evaluator) evalProjection(n *projectionNode) (interface{}, error) {
+	x, err := e.eval(n.x)
+	if err != nil {
+		return nil, err
+	}
+	var elems []interface{}
+	switch x := x.(type) {
+	case nil:
+		return nil, nil
+	case []interface{}:
+		elems = x
+	default:
+		return nil, errorf(n.pos, "cannot project %s", typeName(x))
+	}
+	values := make([]interface{}, len(elems))
+	for i, elem := range elems {
+		v, err := e.element(elem).eval(n.expr)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = v
+	}
+	return values, nil
+}
+
+// element returns the evaluator of the expression of a projection or a
+// selection for an element, see evalProjection.
+func (e *evaluator) element(elem interface{}) *evaluator {
+	variables := make(map[string]interface{}, len(e.env.Variables))
+	for k, v := range e.env.Variables {
+		variables[k] = v
+	}
+	if attributes, ok := elem.(map[string]interface{}); ok {
+		for k, v := range attributes {
+			variables[k] = v
+		}
+	}
+	return &evaluator{
+		env:       &Env{Variables: variables, Groups: e.env.Groups, Now: e.env.Now},
+		this:      elem,
+		inElement: true,
+	}
+}
+
+// evalSelection evaluates x.?[expr], x.^[expr] and x.$[expr]. The elements
+// of a map are its entries, the result of selecting from a map is a map.
+func (e *evaluator) evalSelection(n *selectionNode) (interface{}, error) {
+	x, err := e.eval(n.x)
+	if err != nil {
+		return nil, err
+	}
+	selected := func(elem interface{}) (bool, error) {
+		return e.element(elem).evalBool(n.expr)
+	}
+	switch x := x.(type) {
+	case nil:
+		return nil, nil
+	case []interface{}:
+		values := []interface{}{}
+		for _, elem := range x {
+			ok, err := selected(elem)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				values = append(values, elem)
+			}
+		}
+		switch {
+		case n.op == "?":
+			return values, nil
+		case len(values) == 0:
+			return nil, nil
+		case n.op == "^":
+			return values[0], nil
+		}
+		return values[len(values)-1], nil
+	case map[string]interface{}:
+		keys := make([]string, 0, len(x))
+		for k := range x {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		entries := map[string]interface{}{}
+		var last string
+		for _, k := range keys {
+			ok, err := selected(map[string]interface{}{"key": k, "value": normalize(x[k])})
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				continue
+			}
+			if n.op == "^" {
+				return map[string]interface{}{k: normalize(x[k])}, nil
+			}
+			entries[k], last = normalize(x[k]), k
+		}
+		if n.op == "$" {
+			if len(entries) == 0 {
+				return nil, nil
+			}
+			return map[string]interface{}{last: entries[last]}, nil
+		}
+		if n.op == "^" {
+			return nil, nil
+		}
+		return entries, nil
+	}
+	return nil, errorf(n.pos, "cannot select from %s", typeName(x))
+}
+
+// evalContextVariable evaluates #root, the variables of the expression as an
+// object, #this, and the other context variables, which are looked up in the
+// variables.
+func (e *evaluator) evalContextVariable(n *contextVariableNode) (interface{}, error) {
+	switch {
+	case n.name == "this" && e.inElement:
+		return normalize(e.this), nil
+	case n.name == "root" || n.name == "this":
+		root := make(map[string]interface{}, len(e.env.Variables))
+		for k, v := range e.env.Variables {
+			root[k] = v
+		}
+		return root, nil
+	}
+	v, ok := e.env.Variables[n.name]
+	if !ok {
+		return nil, errorf(n.pos, "unknown variable '#%s'", n.name)
+	}
+	return normalize(v), nil
+}
+
+func (e *evaluator) evalCall(n *callNode) (interface{}, error) {
+	name, receiver, err := e.callee(n)
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		// safe call on null
+		return nil, nil
+	}
+	args := make([]interface{}, len(n.args))
+	for i, arg := range n.args {
+		v, err := e.eval(arg)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = v
+	}
+	var fn *function
+	if receiver != nil {
+		fn = methods[name]
+		args = append([]interface{}{receiver}, args...)
+	} else {
+		fn = functions[name]
+	}
+	if fn == nil {
+		return nil, errorf(n.pos, "unknown function '%s'", name)
+	}
+	if err := fn.checkArgCount(name, len(args)); err != nil {
+		return nil, errorf(n.pos, "%v", err)
+	}
+	v, err := fn.call(e.env, args)
+	if err != nil {
+		return nil, errorf(n.pos, "%s: %v", name, err)
+	}
+	return v, nil
+}
+
+// callee returns the name of the function called, qualified by its namespace,
+// or the name of the method and its receiver. The name is empty for a safe
+// call on null.
+func (e *evaluator) callee(n *callNode) (string, interface{}, error) {
+	if n.receiver == nil {
+		return n.name, nil, nil
+	}
+	if ident, ok := n.receiver.(*identNode); ok {
+		if _, isVariable := e.env.Variables[ident.name]; !isVariable && namespaces[ident.name] {
+			return ident.name + "." + n.name, nil, nil
+		}
+	}
+	receiver, err := e.eval(n.receiver)
+	if err != nil {
+		return "", nil, err
+	}
+	if receiver == nil {
+		if n.safe {
+			return "", nil, nil
+		}
+		return "", nil, errorf(n.pos, "cannot call '%s' on null", n.name)
+	}
+	if _, ok := receiver.(string); !ok {
+		return "", nil, errorf(n.pos, "cannot call '%s' on %s", n.name, typeName(receiver))
+	}
+	return n.name, receiver, nil
+}
+
+func (e *evaluator) evalBool(n node) (bool, error) {
+	v, err := e.eval(n)
+	if err != nil {
+		return false, err
+	}
+	b, ok := v.(bool)
+	if !ok {
+		return false, errorf(n.position(), "expected a boolean, got %s", typeName(v))
+	}
+	return b, nil
+}
+
+func (e *evaluator) evalUnary(n *unaryNode) (interface{}, error) {
+	if n.op == "!" {
+		b, err := e.evalBool(n.x)
+		if err != nil {
+			return nil, err
+		}
+		return !b, nil
+	}
+	x, err := e.eval(n.x)
+	if err != nil {
+		return nil, err
+	}
+	f, ok := x.(float64)
+	if !ok {
+		return nil, errorf(n.pos, "expected a number after '%s', got %s", n.op, typeName(x))
+	}
+	if n.op == "-" {
+		return -f, nil
+	}
+	return f, nil
+}
+
+func (e *evaluator) evalBinary(n *binaryNode) (interface{}, error) {
+	switch n.op {
+	case "&&", "||":
+		x, err := e.evalBool(n.x)
+		if err != nil {
+			return nil, err
+		}
+		if (n.op == "&&" && !x) || (n.op == "||" && x) {
+			return x, nil
+		}
+		return e.evalBool(n.y)
+	case "instanceof":
+		return e.evalInstanceOf(n)
+	}
+	x, err := e.eval(n.x)
+	if err != nil {
+		return nil, err
+	}
+	y, err := e.eval(n.y)
+	if err != nil {
+		return nil, err
+	}
+	switch n.op {
+	case "matches":
+		return matches(n, x, y)
+	case "between":
+		return between(n, x, y)
+	case "==":
+		return equal(x, y), nil
+	case "!=":
+		return !equal(x, y), nil
+	case "+":
+		_, xs := x.(string)
+		_, ys := y.(string)
+		if xs || ys {
+			return toString(x) + toString(y), nil
+		}
+	case "<", "<=", ">", ">=":
+		if xs, ok := x.(string); ok {
+			if ys, ok := y.(string); ok {
+				return compare(n.op, strings.Compare(xs, ys)), nil
+			}
+		}
+	}
+	xf, xok := x.(float64)
+	yf, yok := y.(float64)
+	if !xok || !yok {
+		return nil, errorf(n.pos, "cannot apply '%s' to %s and %s", n.op, typeName(x), typeName(y))
+	}
+	switch n.op {
+	case "+":
+		return xf + yf, nil
+	case "-":
+		return xf - yf, nil
+	case "*":
+		return xf * yf, nil
+	case "/":
+		if yf == 0 {
+			return nil, errorf(n.pos, "division by zero")
+		}
+		return xf / yf, nil
+	case "%":
+		if yf == 0 {
+			return nil, errorf(n.pos, "division by zero")
+		}
+		return math.Mod(xf, yf), nil
+	}
+	c := 0
+	if xf < yf {
+		c = -1
+	} else if xf > yf {
+		c = 1
+	}
+	return compare(n.op, c), nil
+}
+
+// matches evaluates x matches regex, which is true when the regular
+// expression matches all of x as in Java.
+func matches(n *binaryNode, x, y interface{}) (interface{}, error) {
+	pattern, ok := y.(string)
+	if !ok {
+		return nil, errorf(n.y.position(), "expected a regular expression, got %s", typeName(y))
+	}
+	re, err := regexp.Compile("^(?:" + pattern + ")$")
+	if err != nil {
+		return nil, errorf(n.y.position(), "invalid regular expression: %v", err)
+	}
+	switch x := x.(type) {
+	case nil:
+		return false, nil
+	case string:
+		return re.MatchString(x), nil
+	}
+	return nil, errorf(n.pos, "cannot apply 'matches' to %s", typeName(x))
+}
+
+// between evaluates x between {min, max}, bounds included.
+func between(n *binaryNode, x, y interface{}) (interface{}, error) {
+	bounds, ok := y.([]interface{})
+	if !ok || len(bounds) != 2 {
+		return nil, errorf(n.y.position(), "expected a list of 2 bounds after 'between', got %s", typeName(y))
+	}
+	if xs, ok := x.(string); ok {
+		min, minOk := bounds[0].(string)
+		max, maxOk := bounds[1].(string)
+		if minOk && maxOk {
+			return min <= xs && xs <= max, nil
+		}
+	}
+	xf, xok := x.(float64)
+	min, minOk := bounds[0].(float64)
+	max, maxOk := bounds[1].(float64)
+	if !xok || !minOk || !maxOk {
+		return nil, errorf(n.pos, "cannot apply 'between' to %s and %s", typeName(x), typeName(y))
+	}
+	return min <= xf && xf <= max, nil
+}
+
+// javaTypes maps the names of the Java types of instanceof, with or without
+// their package, to the type names of the evaluator.
+var javaTypes = map[string]string{
+	"String":     "string",
+	"Boolean":    "boolean",
+	"Number":     "number",
+	"Integer":    "number",
+	"Long":       "number",
+	"Double":     "number",
+	"Float":      "number",
+	"Short":      "number",
+	"Byte":       "number",
+	"BigDecimal": "number",
+	"BigInteger": "number",
+	"List":       "array",
+	"ArrayList":  "array",
+	"Collection": "array",
+	"Map":        "object",
+	"HashMap":    "object",
+	"Object":     "",
+}
+
+// javaIntegralTypes are the Java types of instanceof whose instances are the
+// whole numbers.
+var javaIntegralTypes = map[string]bool{
+	"Integer":    true,
+	"Long":       true,
+	"Short":      true,
+	"Byte":       true,
+	"BigInteger": true,
+}
+
+// javaType returns the type name of the evaluator of a Java type name, an
+// empty string for Object, which every value but null is an instance of.
+func javaType(name string) (string, bool) {
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		name = name[i+1:]
+	}
+	t, ok := javaTypes[name]
+	return t, ok
+}
+
+func (e *evaluator) evalInstanceOf(n *binaryNode) (interface{}, error) {
+	ref, ok := n.y.(*typeNode)
+	if !ok {
+		return nil, errorf(n.y.position(), "expected a type, such as T(String), after 'instanceof'")
+	}
+	t, ok := javaType(ref.name)
+	if !ok {
+		return nil, errorf(ref.pos, "unknown type '%s'", ref.name)
+	}
+	x, err := e.eval(n.x)
+	if err != nil {
+		return nil, err
+	}
+	if x == nil {
+		return false, nil
+	}
+	if f, ok := x.(float64); ok && javaIntegralTypes[ref.name[strings.LastIndex(ref.name, ".")+1:]] {
+		return f == math.Trunc(f), nil
+	}
+	return t == "" || typeName(x) == t, nil
+}
+
+func compare(op string, c int) bool {
+	switch op {
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	case ">":
+		return c > 0
+	}
+	return c >= 0
+}
+
+func equal(x, y interface{}) bool {
+	return reflect.DeepEqual(normalize(x), normalize(y))
+}
+
+// normalize converts the values supplied by the caller to the types of the
+// evaluator.
+func normalize(v interface{}) interface{} {
+	switch v := v.(type) {
+	case int:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case []string:
+		list := make([]interface{}, len(v))
+		for i, s := range v {
+			list[i] = s
+		}
+		return list
+	case []interface{}:
+		list := make([]interface{}, len(v))
+		for i, elem := range v {
+			list[i] = normalize(elem)
+		}
+		return list
+	case map[string]string:
+		m := make(map[string]interface{}, len(v))
+		for k, s := range v {
+			m[k] = s
+		}
+		return m
+	}
+	return v
+}
+
+// toString formats a value as Java would when concatenating it.
+func toString(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return "null"
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case []interface{}:
+		elems := make([]string, len(v))
+		for i, elem := range v {
+			elems[i] = toString(elem)
+		}
+		return "[" + strings.Join(elems, ", ") + "]"
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		entries := make([]string, len(keys))
+		for i, k := range keys {
+			entries[i] = k + "=" + toString(v[k])
+		}
+		return "{" + strings.Join(entries, ", ") + "}"
+	}
+	return fmt.Sprint(v)
+}
+
+func typeName(v interface{}) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "boolean"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return fmt.Sprintf("%T", v)
+}
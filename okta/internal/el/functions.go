@@ -0,0 +1,622 @@
+package el
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// function is a function of the standard library or a method of strings,
+// methods get their receiver as first argument.
+type function struct {
+	minArgs int
+	// maxArgs is negative for variadic functions.
+	maxArgs int
+	call    func(env *Env, args []interface{}) (interface{}, error)
+}
+
+func (f *function) checkArgCount(name string, n int) error {
+	switch {
+	case f.maxArgs < 0 && n < f.minArgs:
+		return fmt.Errorf("%s expects at least %d arguments, got %d", name, f.minArgs, n)
+	case f.maxArgs >= 0 && (n < f.minArgs || n > f.maxArgs) && f.minArgs == f.maxArgs:
+		return fmt.Errorf("%s expects %d arguments, got %d", name, f.minArgs, n)
+	case f.maxArgs >= 0 && (n < f.minArgs || n > f.maxArgs):
+		return fmt.Errorf("%s expects %d to %d arguments, got %d", name, f.minArgs, f.maxArgs, n)
+	}
+	return nil
+}
+
+// namespaces are the prefixes of the functions of the standard library.
+var namespaces = map[string]bool{
+	"Arrays":         true,
+	"Convert":        true,
+	"Groups":         true,
+	"Iso3166Convert": true,
+	"String":         true,
+	"Time":           true,
+}
+
+// maxGroups is the maximal number of groups the Groups functions return.
+const maxGroups = 100
+
+var functions = map[string]*function{
+	"String.append": {2, 2, func(_ *Env, args []interface{}) (interface{}, error) {
+		return stringFunc2(args, func(s, suffix string) interface{} { return s + suffix })
+	}},
+	"String.endsWith": {2, 2, func(_ *Env, args []interface{}) (interface{}, error) {
+		return stringFunc2(args, func(s, suffix string) interface{} { return strings.HasSuffix(s, suffix) })
+	}},
+	"String.join": {1, -1, func(_ *Env, args []interface{}) (interface{}, error) {
+		separator, err := stringArg(args, 0)
+		if err != nil {
+			return nil, err
+		}
+		elems := make([]string, 0, len(args)-1)
+		for i := 1; i < len(args); i++ {
+			s, err := stringArg(args, i)
+			if err != nil {
+				return nil, err
+			}
+			elems = append(elems, s)
+		}
+		return strings.Join(elems, separator), nil
+	}},
+	"String.len": {1, 1, func(_ *Env, args []interface{}) (interface{}, error) {
+		s, err := stringArg(args, 0)
+		if err != nil {
+			return nil, err
+		}
+		return float64(utf8.RuneCountInString(s)), nil
+	}},
+	"String.removeSpaces": {1, 1, func(_ *Env, args []interface{}) (interface{}, error) {
+		s, err := stringArg(args, 0)
+		if err != nil {
+			return nil, err
+		}
+		return strings.ReplaceAll(s, " ", ""), nil
+	}},
+	"String.replace": {3, 3, func(_ *Env, args []interface{}) (interface{}, error) {
+		return replace(args, -1)
+	}},
+	"String.replaceFirst": {3, 3, func(_ *Env, args []interface{}) (interface{}, error) {
+		return replace(args, 1)
+	}},
+	"String.startsWith": {2, 2, func(_ *Env, args []interface{}) (interface{}, error) {
+		return stringFunc2(args, func(s, prefix string) interface{} { return strings.HasPrefix(s, prefix) })
+	}},
+	"String.stringContains": {2, 2, func(_ *Env, args []interface{}) (interface{}, error) {
+		return stringFunc2(args, func(s, search string) interface{} { return strings.Contains(s, search) })
+	}},
+	"String.stringSwitch": {2, -1, func(_ *Env, args []interface{}) (interface{}, error) {
+		if len(args)%2 != 0 {
+			return nil, fmt.Errorf("expects keys and values in pairs")
+		}
+		input, err := stringArg(args, 0)
+		if err != nil {
+			return nil, err
+		}
+		for i := 2; i < len(args); i += 2 {
+			key, err := stringArg(args, i)
+			if err != nil {
+				return nil, err
+			}
+			if strings.Contains(input, key) {
+				return args[i+1], nil
+			}
+		}
+		return args[1], nil
+	}},
+	"String.substring": {3, 3, func(_ *Env, args []interface{}) (interface{}, error) {
+		s, err := stringArg(args, 0)
+		if err != nil {
+			return nil, err
+		}
+		return substring(s, args[1:])
+	}},
+	"String.substringAfter": {2, 2, func(_ *Env, args []interface{}) (interface{}, error) {
+		return stringFunc2(args, func(s, search string) interface{} {
+			if i := strings.Index(s, search); i >= 0 {
+				return s[i+len(search):]
+			}
+			return ""
+		})
+	}},
+	"String.substringBefore": {2, 2, func(_ *Env, args []interface{}) (interface{}, error) {
+		return stringFunc2(args, func(s, search string) interface{} {
+			if i := strings.Index(s, search); i >= 0 {
+				return s[:i]
+			}
+			return s
+		})
+	}},
+	"String.toLowerCase": {1, 1, func(_ *Env, args []interface{}) (interface{}, error) {
+		s, err := stringArg(args, 0)
+		if err != nil {
+			return nil, err
+		}
+		return strings.ToLower(s), nil
+	}},
+	"String.toUpperCase": {1, 1, func(_ *Env, args []interface{}) (interface{}, error) {
+		s, err := stringArg(args, 0)
+		if err != nil {
+			return nil, err
+		}
+		return strings.ToUpper(s), nil
+	}},
+
+	"Arrays.add": {2, 2, func(_ *Env, args []interface{}) (interface{}, error) {
+		list, err := arrayArg(args, 0)
+		if err != nil {
+			return nil, err
+		}
+		return append(append([]interface{}{}, list...), args[1]), nil
+	}},
+	"Arrays.remove": {2, 2, func(_ *Env, args []interface{}) (interface{}, error) {
+		list, err := arrayArg(args, 0)
+		if err != nil {
+			return nil, err
+		}
+		result := []interface{}{}
+		removed := false
+		for _, elem := range list {
+			if !removed && equal(elem, args[1]) {
+				removed = true
+				continue
+			}
+			result = append(result, elem)
+		}
+		return result, nil
+	}},
+	"Arrays.clear": {1, 1, func(_ *Env, args []interface{}) (interface{}, error) {
+		if _, err := arrayArg(args, 0); err != nil {
+			return nil, err
+		}
+		return []interface{}{}, nil
+	}},
+	"Arrays.get": {2, 2, func(_ *Env, args []interface{}) (interface{}, error) {
+		list, err := arrayArg(args, 0)
+		if err != nil {
+			return nil, err
+		}
+		i, err := intArg(args, 1)
+		if err != nil {
+			return nil, err
+		}
+		if i < 0 || i >= len(list) {
+			return nil, nil
+		}
+		return list[i], nil
+	}},
+	"Arrays.flatten": {0, -1, func(_ *Env, args []interface{}) (interface{}, error) {
+		return flatten([]interface{}{}, args), nil
+	}},
+	"Arrays.contains": {2, 2, func(_ *Env, args []interface{}) (interface{}, error) {
+		list, err := arrayArg(args, 0)
+		if err != nil {
+			return nil, err
+		}
+		for _, elem := range list {
+			if equal(elem, args[1]) {
+				return true, nil
+			}
+		}
+		return false, nil
+	}},
+	"Arrays.size": {1, 1, func(_ *Env, args []interface{}) (interface{}, error) {
+		list, err := arrayArg(args, 0)
+		if err != nil {
+			return nil, err
+		}
+		return float64(len(list)), nil
+	}},
+	"Arrays.isEmpty": {1, 1, func(_ *Env, args []interface{}) (interface{}, error) {
+		list, err := arrayArg(args, 0)
+		if err != nil {
+			return nil, err
+		}
+		return len(list) == 0, nil
+	}},
+	"Arrays.toCsvString": {1, 1, func(_ *Env, args []interface{}) (interface{}, error) {
+		list, err := arrayArg(args, 0)
+		if err != nil {
+			return nil, err
+		}
+		elems := make([]string, len(list))
+		for i, elem := range list {
+			elems[i] = toString(elem)
+		}
+		return strings.Join(elems, ","), nil
+	}},
+
+	"Convert.toInt": {1, 1, func(_ *Env, args []interface{}) (interface{}, error) {
+		f, err := toNumber(args[0])
+		if err != nil {
+			return nil, err
+		}
+		return math.RoundToEven(f), nil
+	}},
+	"Convert.toNum": {1, 1, func(_ *Env, args []interface{}) (interface{}, error) {
+		return toNumber(args[0])
+	}},
+
+	"Groups.startsWith": {3, 3, func(env *Env, args []interface{}) (interface{}, error) {
+		return filterGroups(env, args, strings.HasPrefix)
+	}},
+	"Groups.endsWith": {3, 3, func(env *Env, args []interface{}) (interface{}, error) {
+		return filterGroups(env, args, strings.HasSuffix)
+	}},
+	"Groups.contains": {3, 3, func(env *Env, args []interface{}) (interface{}, error) {
+		return filterGroups(env, args, strings.Contains)
+	}},
+
+	"isMemberOfGroup": {1, 1, func(env *Env, args []interface{}) (interface{}, error) {
+		return isMemberOf(env, args, func(g Group, s string) bool { return g.ID == s })
+	}},
+	"isMemberOfAnyGroup": {1, -1, func(env *Env, args []interface{}) (interface{}, error) {
+		for i := range args {
+			member, err := isMemberOf(env, args[i:i+1], func(g Group, s string) bool { return g.ID == s })
+			if err != nil || member == true {
+				return member, err
+			}
+		}
+		return false, nil
+	}},
+	"isMemberOfGroupName": {1, 1, func(env *Env, args []interface{}) (interface{}, error) {
+		return isMemberOf(env, args, func(g Group, s string) bool { return g.Name == s })
+	}},
+	"isMemberOfGroupNameStartsWith": {1, 1, func(env *Env, args []interface{}) (interface{}, error) {
+		return isMemberOf(env, args, func(g Group, s string) bool { return strings.HasPrefix(g.Name, s) })
+	}},
+	"isMemberOfGroupNameContains": {1, 1, func(env *Env, args []interface{}) (interface{}, error) {
+		return isMemberOf(env, args, func(g Group, s string) bool { return strings.Contains(g.Name, s) })
+	}},
+	"isMemberOfGroupNameRegex": {1, 1, func(env *Env, args []interface{}) (interface{}, error) {
+		pattern, err := stringArg(args, 0)
+		if err != nil {
+			return nil, err
+		}
+		re, err := regexp.Compile("^(?:" + pattern + ")$")
+		if err != nil {
+			return nil, fmt.Errorf("invalid regular expression: %v", err)
+		}
+		return isMemberOf(env, args, func(g Group, _ string) bool { return re.MatchString(g.Name) })
+	}},
+
+	"Time.now": {0, 2, func(env *Env, args []interface{}) (interface{}, error) {
+		now := env.now().UTC()
+		if len(args) > 0 {
+			zone, err := stringArg(args, 0)
+			if err != nil {
+				return nil, err
+			}
+			location, err := time.LoadLocation(zone)
+			if err != nil {
+				return nil, fmt.Errorf("unknown time zone %q", zone)
+			}
+			now = now.In(location)
+		}
+		if len(args) > 1 {
+			return nil, errUnsupported
+		}
+		return now.Format(iso8601), nil
+	}},
+	"Time.fromWindowsToIso8601": {1, 1, func(_ *Env, args []interface{}) (interface{}, error) {
+		ticks, err := int64Arg(args, 0)
+		if err != nil {
+			return nil, err
+		}
+		return windowsEpoch.Add(time.Duration(ticks) * 100).UTC().Format(iso8601), nil
+	}},
+	"Time.fromUnixToIso8601": {1, 1, func(_ *Env, args []interface{}) (interface{}, error) {
+		seconds, err := int64Arg(args, 0)
+		if err != nil {
+			return nil, err
+		}
+		return time.Unix(seconds, 0).UTC().Format(iso8601), nil
+	}},
+	"Time.fromStringToIso8601": {2, 2, unsupported},
+	"Time.fromIso8601ToWindows": {1, 1, func(_ *Env, args []interface{}) (interface{}, error) {
+		t, err := timeArg(args, 0)
+		if err != nil {
+			return nil, err
+		}
+		return strconv.FormatInt(int64(t.Sub(windowsEpoch)/100), 10), nil
+	}},
+	"Time.fromIso8601ToUnix": {1, 1, func(_ *Env, args []interface{}) (interface{}, error) {
+		t, err := timeArg(args, 0)
+		if err != nil {
+			return nil, err
+		}
+		return strconv.FormatInt(t.Unix(), 10), nil
+	}},
+	"Time.fromIso8601ToString": {2, 2, unsupported},
+
+	"Iso3166Convert.toAlpha2":  {1, 1, unsupported},
+	"Iso3166Convert.toAlpha3":  {1, 1, unsupported},
+	"Iso3166Convert.toNumeric": {1, 1, unsupported},
+	"Iso3166Convert.toName":    {1, 1, unsupported},
+
+	"hasDirectoryUser":    {0, 0, unsupported},
+	"hasWorkdayUser":      {0, 0, unsupported},
+	"findDirectoryUser":   {0, 0, unsupported},
+	"findWorkdayUser":     {0, 0, unsupported},
+	"getManagerUser":      {1, 1, unsupported},
+	"getManagerAppUser":   {2, 2, unsupported},
+	"getAssistantUser":    {1, 1, unsupported},
+	"getAssistantAppUser": {2, 2, unsupported},
+}
+
+// errUnsupported is returned by the functions which depend on data Env
+// doesn't model, such as the directories of the org.
+var errUnsupported = errors.New("not supported by the local evaluator")
+
+func unsupported(*Env, []interface{}) (interface{}, error) {
+	return nil, errUnsupported
+}
+
+const iso8601 = "2006-01-02T15:04:05.000Z07:00"
+
+// windowsEpoch is the origin of Windows file times, counted in 100ns.
+var windowsEpoch = time.Date(1601, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// methods are the methods of strings, as in user.login.toLowerCase().
+var methods = map[string]*function{
+	"contains": {2, 2, func(_ *Env, args []interface{}) (interface{}, error) {
+		return stringFunc2(args, func(s, t string) interface{} { return strings.Contains(s, t) })
+	}},
+	"endsWith": {2, 2, func(_ *Env, args []interface{}) (interface{}, error) {
+		return stringFunc2(args, func(s, t string) interface{} { return strings.HasSuffix(s, t) })
+	}},
+	"length": {1, 1, func(env *Env, args []interface{}) (interface{}, error) {
+		return functions["String.len"].call(env, args)
+	}},
+	"startsWith": {2, 2, func(_ *Env, args []interface{}) (interface{}, error) {
+		return stringFunc2(args, func(s, t string) interface{} { return strings.HasPrefix(s, t) })
+	}},
+	"substring": {2, 3, func(_ *Env, args []interface{}) (interface{}, error) {
+		return substring(args[0].(string), args[1:])
+	}},
+	"substringAfter": {2, 2, func(env *Env, args []interface{}) (interface{}, error) {
+		return functions["String.substringAfter"].call(env, args)
+	}},
+	"substringBefore": {2, 2, func(env *Env, args []interface{}) (interface{}, error) {
+		return functions["String.substringBefore"].call(env, args)
+	}},
+	"toLowerCase": {1, 1, func(env *Env, args []interface{}) (interface{}, error) {
+		return functions["String.toLowerCase"].call(env, args)
+	}},
+	"toUpperCase": {1, 1, func(env *Env, args []interface{}) (interface{}, error) {
+		return functions["String.toUpperCase"].call(env, args)
+	}},
+	"trim": {1, 1, func(_ *Env, args []interface{}) (interface{}, error) {
+		return strings.TrimSpace(args[0].(string)), nil
+	}},
+}
+
+func stringArg(args []interface{}, i int) (string, error) {
+	s, ok := args[i].(string)
+	if !ok {
+		return "", fmt.Errorf("argument %d must be a string, got %s", i+1, typeName(args[i]))
+	}
+	return s, nil
+}
+
+// int64Arg returns an integer given as a number or a string.
+func int64Arg(args []interface{}, i int) (int64, error) {
+	f, err := toNumber(args[i])
+	if err != nil || f != math.Trunc(f) {
+		return 0, fmt.Errorf("argument %d must be an integer, got %s", i+1, toString(args[i]))
+	}
+	return int64(f), nil
+}
+
+func timeArg(args []interface{}, i int) (time.Time, error) {
+	s, err := stringArg(args, i)
+	if err != nil {
+		return time.Time{}, err
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("argument %d must be an ISO 8601 time, got %q", i+1, s)
+	}
+	return t, nil
+}
+
+func intArg(args []interface{}, i int) (int, error) {
+	f, ok := args[i].(float64)
+	if !ok || f != math.Trunc(f) {
+		return 0, fmt.Errorf("argument %d must be an integer, got %s", i+1, typeName(args[i]))
+	}
+	return int(f), nil
+}
+
+func arrayArg(args []interface{}, i int) ([]interface{}, error) {
+	list, ok := args[i].([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("argument %d must be an array, got %s", i+1, typeName(args[i]))
+	}
+	return list, nil
+}
+
+func stringFunc2(args []interface{}, fn func(string, string) interface{}) (interface{}, error) {
+	s, err := stringArg(args, 0)
+	if err != nil {
+		return nil, err
+	}
+	t, err := stringArg(args, 1)
+	if err != nil {
+		return nil, err
+	}
+	return fn(s, t), nil
+}
+
+func replace(args []interface{}, n int) (interface{}, error) {
+	s, err := stringArg(args, 0)
+	if err != nil {
+		return nil, err
+	}
+	pattern, err := stringArg(args, 1)
+	if err != nil {
+		return nil, err
+	}
+	replacement, err := stringArg(args, 2)
+	if err != nil {
+		return nil, err
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid regular expression: %v", err)
+	}
+	if n < 0 {
+		return re.ReplaceAllString(s, replacement), nil
+	}
+	if loc := re.FindStringSubmatchIndex(s); loc != nil {
+		var dst []byte
+		dst = re.ExpandString(dst, replacement, s, loc)
+		return s[:loc[0]] + string(dst) + s[loc[1]:], nil
+	}
+	return s, nil
+}
+
+// substring returns the runes of s from the begin index, included, to the end
+// index, excluded, or to the end of s.
+func substring(s string, bounds []interface{}) (interface{}, error) {
+	runes := []rune(s)
+	begin, err := intArg(bounds, 0)
+	if err != nil {
+		return nil, err
+	}
+	end := len(runes)
+	if len(bounds) > 1 {
+		if end, err = intArg(bounds, 1); err != nil {
+			return nil, err
+		}
+	}
+	if begin < 0 || end > len(runes) || begin > end {
+		return nil, fmt.Errorf("range [%d, %d) out of bounds of string of length %d", begin, end, len(runes))
+	}
+	return string(runes[begin:end]), nil
+}
+
+func flatten(dst, values []interface{}) []interface{} {
+	for _, v := range values {
+		if list, ok := v.([]interface{}); ok {
+			dst = flatten(dst, list)
+			continue
+		}
+		dst = append(dst, v)
+	}
+	return dst
+}
+
+func toNumber(v interface{}) (float64, error) {
+	switch v := v.(type) {
+	case float64:
+		return v, nil
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err != nil {
+			return 0, fmt.Errorf("cannot convert %q to a number", v)
+		}
+		return f, nil
+	}
+	return 0, fmt.Errorf("cannot convert %s to a number", typeName(v))
+}
+
+func isMemberOf(env *Env, args []interface{}, match func(Group, string) bool) (interface{}, error) {
+	s, err := stringArg(args, 0)
+	if err != nil {
+		return nil, err
+	}
+	for _, g := range env.Groups {
+		if match(g, s) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// filterGroups returns the names of the groups of the user matching the
+// pattern. The first argument is the source of the groups, which isn't
+// modeled by Env and is ignored.
+func filterGroups(env *Env, args []interface{}, match func(string, string) bool) (interface{}, error) {
+	if _, err := stringArg(args, 0); err != nil {
+		return nil, err
+	}
+	pattern, err := stringArg(args, 1)
+	if err != nil {
+		return nil, err
+	}
+	limit, err := intArg(args, 2)
+	if err != nil {
+		return nil, err
+	}
+	if limit < 1 || limit > maxGroups {
+		return nil, fmt.Errorf("limit must be between 1 and %d, got %d", maxGroups, limit)
+	}
+	names := []interface{}{}
+	for _, g := range env.Groups {
+		if len(names) == limit {
+			break
+		}
+		if match(g.Name, pattern) {
+			names = append(names, g.Name)
+		}
+	}
+	return names, nil
+}
+
+// getFilteredGroups evaluates expressions, it is registered by init to break
+// the initialization cycle with the evaluator.
+func init() {
+	functions["getFilteredGroups"] = &function{3, 3, filteredGroups}
+}
+
+// filteredGroups returns the values of the group expression, e.g.
+// "group.name", for the groups of the user whose IDs are in the allowlist.
+func filteredGroups(env *Env, args []interface{}) (interface{}, error) {
+	allowlist, err := arrayArg(args, 0)
+	if err != nil {
+		return nil, err
+	}
+	src, err := stringArg(args, 1)
+	if err != nil {
+		return nil, err
+	}
+	limit, err := intArg(args, 2)
+	if err != nil {
+		return nil, err
+	}
+	if limit < 1 || limit > maxGroups {
+		return nil, fmt.Errorf("limit must be between 1 and %d, got %d", maxGroups, limit)
+	}
+	expr, err := Parse(src)
+	if err != nil {
+		return nil, fmt.Errorf("invalid group expression %q: %v", src, err)
+	}
+	values := []interface{}{}
+	for _, g := range env.Groups {
+		if len(values) == limit {
+			break
+		}
+		allowed := false
+		for _, id := range allowlist {
+			allowed = allowed || id == g.ID
+		}
+		if !allowed {
+			continue
+		}
+		v, err := expr.Eval(&Env{Variables: map[string]interface{}{"group": map[string]interface{}{"id": g.ID, "name": g.Name}}, Now: env.Now})
+		if err != nil {
+			return nil, fmt.Errorf("invalid group expression %q: %v", src, err)
+		}
+		values = append(values, v)
+	}
+	return values, nil
+}
@@ -0,0 +1,188 @@
+package el
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenString
+	tokenNumber
+	tokenOperator
+)
+
+type token struct {
+	kind tokenKind
+	// text is the identifier or the operator, textual operators are
+	// normalized to their symbolic form.
+	text  string
+	value interface{}
+	pos   int
+}
+
+// textualOperators are the SpEL operators spelled as words, matched case
+// insensitively.
+var textualOperators = map[string]string{
+	"and":        "&&",
+	"or":         "||",
+	"not":        "!",
+	"eq":         "==",
+	"ne":         "!=",
+	"lt":         "<",
+	"gt":         ">",
+	"le":         "<=",
+	"ge":         ">=",
+	"matches":    "matches",
+	"between":    "between",
+	"instanceof": "instanceof",
+}
+
+var twoCharOperators = []string{"==", "!=", "<=", ">=", "&&", "||", "?:", "?."}
+
+const oneCharOperators = ".,()[]{}:?+-*/%!<>^"
+
+// lex splits an expression into tokens, the last one being tokenEOF.
+func lex(src string) ([]token, error) {
+	var tokens []token
+	runes := []rune(src)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case isIdentStart(r):
+			start := i
+			for i < len(runes) && isIdentPart(runes[i]) {
+				i++
+			}
+			word := string(runes[start:i])
+			afterDot := len(tokens) > 0 && tokens[len(tokens)-1].kind == tokenOperator &&
+				(tokens[len(tokens)-1].text == "." || tokens[len(tokens)-1].text == "?.")
+			if op, ok := textualOperators[strings.ToLower(word)]; ok && !afterDot {
+				tokens = append(tokens, token{kind: tokenOperator, text: op, pos: start})
+			} else {
+				tokens = append(tokens, token{kind: tokenIdent, text: word, pos: start})
+			}
+		case unicode.IsDigit(r):
+			start := i
+			for i < len(runes) && unicode.IsDigit(runes[i]) {
+				i++
+			}
+			if i+1 < len(runes) && runes[i] == '.' && unicode.IsDigit(runes[i+1]) {
+				i++
+				for i < len(runes) && unicode.IsDigit(runes[i]) {
+					i++
+				}
+			}
+			if exp := exponentLength(runes[i:]); exp > 0 {
+				i += exp
+			}
+			n, err := strconv.ParseFloat(string(runes[start:i]), 64)
+			if err != nil {
+				return nil, errorf(start, "invalid number %s", string(runes[start:i]))
+			}
+			tokens = append(tokens, token{kind: tokenNumber, value: n, pos: start})
+		case r == '#' && i+1 < len(runes) && isIdentStart(runes[i+1]):
+			// a context variable, such as #root or #this
+			start := i
+			i++
+			for i < len(runes) && isIdentPart(runes[i]) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: string(runes[start:i]), pos: start})
+		case r == '"' || r == '\'':
+			s, next, err := lexString(runes, i)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, token{kind: tokenString, value: s, pos: i})
+			i = next
+		default:
+			matched := false
+			for _, op := range twoCharOperators {
+				if i+1 < len(runes) && string(runes[i:i+2]) == op {
+					tokens = append(tokens, token{kind: tokenOperator, text: op, pos: i})
+					i += 2
+					matched = true
+					break
+				}
+			}
+			if matched {
+				continue
+			}
+			if strings.ContainsRune(oneCharOperators, r) {
+				tokens = append(tokens, token{kind: tokenOperator, text: string(r), pos: i})
+				i++
+				continue
+			}
+			return nil, errorf(i, "unexpected character '%c'", r)
+		}
+	}
+	return append(tokens, token{kind: tokenEOF, pos: len(runes)}), nil
+}
+
+// lexString reads the string literal starting at the quote at start and
+// returns its value and the position after it. The quote is escaped either
+// with a backslash or by doubling it.
+func lexString(runes []rune, start int) (string, int, error) {
+	quote := runes[start]
+	var sb strings.Builder
+	for i := start + 1; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r == quote && i+1 < len(runes) && runes[i+1] == quote:
+			sb.WriteRune(quote)
+			i++
+		case r == quote:
+			return sb.String(), i + 1, nil
+		case r == '\\' && i+1 < len(runes):
+			i++
+			switch runes[i] {
+			case 'n':
+				sb.WriteRune('\n')
+			case 't':
+				sb.WriteRune('\t')
+			case 'r':
+				sb.WriteRune('\r')
+			default:
+				sb.WriteRune(runes[i])
+			}
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	return "", 0, errorf(start, "unterminated string")
+}
+
+// exponentLength returns the length of the exponent of a number, such as e3
+// in 1.5e3, at the start of runes, 0 if there is none.
+func exponentLength(runes []rune) int {
+	if len(runes) == 0 || runes[0] != 'e' && runes[0] != 'E' {
+		return 0
+	}
+	i := 1
+	if i < len(runes) && (runes[i] == '+' || runes[i] == '-') {
+		i++
+	}
+	start := i
+	for i < len(runes) && unicode.IsDigit(runes[i]) {
+		i++
+	}
+	if i == start {
+		return 0
+	}
+	return i
+}
+
+func isIdentStart(r rune) bool {
+	return r == '_' || r == '$' || unicode.IsLetter(r)
+}
+
+func isIdentPart(r rune) bool {
+	return isIdentStart(r) || unicode.IsDigit(r)
+}
@@ -0,0 +1,510 @@
+package el
+
+import (
+	"fmt"
+	"strings"
+)
+
+// node is a node of the syntax tree of an expression.
+type node interface {
+	position() int
+}
+
+type literalNode struct {
+	pos   int
+	value interface{}
+}
+
+// identNode is a variable, such as user, or the namespace of a function,
+// such as String.
+type identNode struct {
+	pos  int
+	name string
+}
+
+type memberNode struct {
+	pos  int
+	x    node
+	name string
+}
+
+type indexNode struct {
+	pos   int
+	x     node
+	index node
+}
+
+// callNode is a function call, receiver is nil for global functions such as
+// isMemberOfGroupName, an identNode for the functions of a namespace such as
+// String.toUpperCase, or a value for methods such as user.login.toLowerCase.
+// A safe call, x?.method(), is null when its receiver is null. Members and
+// projections of null are null already, so x?.name needs no such flag.
+type callNode struct {
+	pos      int
+	receiver node
+	name     string
+	args     []node
+	safe     bool
+}
+
+type unaryNode struct {
+	pos int
+	op  string
+	x   node
+}
+
+type binaryNode struct {
+	pos int
+	op  string
+	x   node
+	y   node
+}
+
+type conditionalNode struct {
+	pos  int
+	cond node
+	then node
+	els  node
+}
+
+// elvisNode is x ?: y, which is x unless x is null or empty.
+type elvisNode struct {
+	pos int
+	x   node
+	y   node
+}
+
+// projectionNode is x.![expr], the list of the values of expr for every
+// element of x.
+type projectionNode struct {
+	pos  int
+	x    node
+	expr node
+}
+
+// selectionNode is x.?[expr], the elements of x for which expr is true, or
+// x.^[expr] and x.$[expr], the first and the last of them. The elements of a
+// map are its entries, with the key and value attributes.
+type selectionNode struct {
+	pos  int
+	op   string
+	x    node
+	expr node
+}
+
+// contextVariableNode is a variable of the evaluation context, #root for the
+// variables of the expression and #this for the element of a projection or a
+// selection, or the variable itself outside of them.
+type contextVariableNode struct {
+	pos  int
+	name string
+}
+
+// typeNode is a type reference, T(String), the right operand of instanceof.
+type typeNode struct {
+	pos  int
+	name string
+}
+
+type listNode struct {
+	pos   int
+	elems []node
+}
+
+type mapNode struct {
+	pos    int
+	keys   []string
+	values []node
+}
+
+func (n *literalNode) position() int         { return n.pos }
+func (n *identNode) position() int           { return n.pos }
+func (n *memberNode) position() int          { return n.pos }
+func (n *indexNode) position() int           { return n.pos }
+func (n *callNode) position() int            { return n.pos }
+func (n *unaryNode) position() int           { return n.pos }
+func (n *binaryNode) position() int          { return n.pos }
+func (n *conditionalNode) position() int     { return n.pos }
+func (n *elvisNode) position() int           { return n.pos }
+func (n *projectionNode) position() int      { return n.pos }
+func (n *selectionNode) position() int       { return n.pos }
+func (n *contextVariableNode) position() int { return n.pos }
+func (n *typeNode) position() int            { return n.pos }
+func (n *listNode) position() int            { return n.pos }
+func (n *mapNode) position() int             { return n.pos }
+
+func (t token) String() string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of expression"
+	case tokenString:
+		return fmt.Sprintf("string %q", t.value)
+	case tokenNumber:
+		return fmt.Sprintf("number %v", t.value)
+	case tokenIdent:
+		return fmt.Sprintf("identifier '%s'", t.text)
+	}
+	return fmt.Sprintf("'%s'", t.text)
+}
+
+// binaryPrecedence lists the binary operators from the lowest precedence to
+// the highest.
+var binaryPrecedence = [][]string{
+	{"||"},
+	{"&&"},
+	{"==", "!="},
+	{"<", "<=", ">", ">=", "matches", "between", "instanceof"},
+	{"+", "-"},
+	{"*", "/", "%"},
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) isOperator(ops ...string) bool {
+	t := p.peek()
+	if t.kind != tokenOperator {
+		return false
+	}
+	for _, op := range ops {
+		if t.text == op {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *parser) expect(op string) (token, error) {
+	if !p.isOperator(op) {
+		t := p.peek()
+		return t, errorf(t.pos, "expected '%s', got %s", op, t)
+	}
+	return p.next(), nil
+}
+
+func (p *parser) parseExpression() (node, error) {
+	x, err := p.parseBinary(0)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case p.isOperator("?:"):
+		t := p.next()
+		y, err := p.parseExpression()
+		if err != nil {
+			return nil, err
+		}
+		return &elvisNode{pos: t.pos, x: x, y: y}, nil
+	case p.isOperator("?"):
+		t := p.next()
+		then, err := p.parseExpression()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(":"); err != nil {
+			return nil, err
+		}
+		els, err := p.parseExpression()
+		if err != nil {
+			return nil, err
+		}
+		return &conditionalNode{pos: t.pos, cond: x, then: then, els: els}, nil
+	}
+	return x, nil
+}
+
+func (p *parser) parseBinary(level int) (node, error) {
+	if level == len(binaryPrecedence) {
+		return p.parseUnary()
+	}
+	x, err := p.parseBinary(level + 1)
+	if err != nil {
+		return nil, err
+	}
+	for p.isOperator(binaryPrecedence[level]...) {
+		t := p.next()
+		y, err := p.parseBinary(level + 1)
+		if err != nil {
+			return nil, err
+		}
+		x = &binaryNode{pos: t.pos, op: t.text, x: x, y: y}
+	}
+	return x, nil
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if p.isOperator("!", "-", "+") {
+		t := p.next()
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &unaryNode{pos: t.pos, op: t.text, x: x}, nil
+	}
+	return p.parsePostfix()
+}
+
+func (p *parser) parsePostfix() (node, error) {
+	x, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		switch {
+		case p.isOperator(".", "?."):
+			safe := p.next().text == "?."
+			if t := p.peek(); p.isOperator("!", "?", "^") || t.kind == tokenIdent && t.text == "$" && p.isNextOperator("[") {
+				p.next()
+				expr, err := p.parseBracketed()
+				if err != nil {
+					return nil, err
+				}
+				if t.text == "!" {
+					x = &projectionNode{pos: t.pos, x: x, expr: expr}
+				} else {
+					x = &selectionNode{pos: t.pos, op: t.text, x: x, expr: expr}
+				}
+				continue
+			}
+			t := p.next()
+			if t.kind != tokenIdent || strings.HasPrefix(t.text, "#") {
+				return nil, errorf(t.pos, "expected a name after '.', got %s", t)
+			}
+			if p.isOperator("(") {
+				args, err := p.parseArgs()
+				if err != nil {
+					return nil, err
+				}
+				x = &callNode{pos: t.pos, receiver: x, name: t.text, args: args, safe: safe}
+				continue
+			}
+			x = &memberNode{pos: t.pos, x: x, name: t.text}
+		case p.isOperator("["):
+			t := p.next()
+			index, err := p.parseExpression()
+			if err != nil {
+				return nil, err
+			}
+			if _, err := p.expect("]"); err != nil {
+				return nil, err
+			}
+			x = &indexNode{pos: t.pos, x: x, index: index}
+		default:
+			return x, nil
+		}
+	}
+}
+
+// isNextOperator tells whether the token after the next one is one of ops.
+func (p *parser) isNextOperator(ops ...string) bool {
+	if p.pos+1 >= len(p.tokens) {
+		return false
+	}
+	t := p.tokens[p.pos+1]
+	for _, op := range ops {
+		if t.kind == tokenOperator && t.text == op {
+			return true
+		}
+	}
+	return false
+}
+
+// parseBracketed parses the [expr] of a projection or a selection.
+func (p *parser) parseBracketed() (node, error) {
+	if _, err := p.expect("["); err != nil {
+		return nil, err
+	}
+	expr, err := p.parseExpression()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := p.expect("]"); err != nil {
+		return nil, err
+	}
+	return expr, nil
+}
+
+func (p *parser) parseArgs() ([]node, error) {
+	if _, err := p.expect("("); err != nil {
+		return nil, err
+	}
+	args := []node{}
+	if p.isOperator(")") {
+		p.next()
+		return args, nil
+	}
+	for {
+		arg, err := p.parseExpression()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+		if p.isOperator(")") {
+			p.next()
+			return args, nil
+		}
+		if !p.isOperator(",") {
+			t := p.peek()
+			return nil, errorf(t.pos, "expected ',' or ')', got %s", t)
+		}
+		p.next()
+	}
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	t := p.next()
+	switch t.kind {
+	case tokenString, tokenNumber:
+		return &literalNode{pos: t.pos, value: t.value}, nil
+	case tokenIdent:
+		switch strings.ToLower(t.text) {
+		case "true":
+			return &literalNode{pos: t.pos, value: true}, nil
+		case "false":
+			return &literalNode{pos: t.pos, value: false}, nil
+		case "null":
+			return &literalNode{pos: t.pos, value: nil}, nil
+		}
+		if strings.HasPrefix(t.text, "#") {
+			return &contextVariableNode{pos: t.pos, name: t.text[1:]}, nil
+		}
+		if t.text == "T" && p.isOperator("(") {
+			return p.parseType(t)
+		}
+		if p.isOperator("(") {
+			args, err := p.parseArgs()
+			if err != nil {
+				return nil, err
+			}
+			return &callNode{pos: t.pos, name: t.text, args: args}, nil
+		}
+		return &identNode{pos: t.pos, name: t.text}, nil
+	case tokenOperator:
+		switch t.text {
+		case "(":
+			x, err := p.parseExpression()
+			if err != nil {
+				return nil, err
+			}
+			if _, err := p.expect(")"); err != nil {
+				return nil, err
+			}
+			return x, nil
+		case "{":
+			return p.parseInline(t)
+		}
+	}
+	return nil, errorf(t.pos, "unexpected %s", t)
+}
+
+// parseType parses the dotted name of a type reference, T(java.lang.String),
+// after the T.
+func (p *parser) parseType(t token) (node, error) {
+	p.next()
+	var parts []string
+	for {
+		name := p.next()
+		if name.kind != tokenIdent {
+			return nil, errorf(name.pos, "expected a type name, got %s", name)
+		}
+		parts = append(parts, name.text)
+		if !p.isOperator(".") {
+			break
+		}
+		p.next()
+	}
+	if _, err := p.expect(")"); err != nil {
+		return nil, err
+	}
+	return &typeNode{pos: t.pos, name: strings.Join(parts, ".")}, nil
+}
+
+// parseInline parses an inline list, {"a", "b"}, or an inline map,
+// {"key": "value"}, after its opening brace.
+func (p *parser) parseInline(open token) (node, error) {
+	if p.isOperator("}") {
+		p.next()
+		return &listNode{pos: open.pos, elems: []node{}}, nil
+	}
+	if p.isOperator(":") {
+		p.next()
+		if _, err := p.expect("}"); err != nil {
+			return nil, err
+		}
+		return &mapNode{pos: open.pos}, nil
+	}
+	first, err := p.parseExpression()
+	if err != nil {
+		return nil, err
+	}
+	if !p.isOperator(":") {
+		list := &listNode{pos: open.pos, elems: []node{first}}
+		for p.isOperator(",") {
+			p.next()
+			elem, err := p.parseExpression()
+			if err != nil {
+				return nil, err
+			}
+			list.elems = append(list.elems, elem)
+		}
+		if _, err := p.expect("}"); err != nil {
+			return nil, err
+		}
+		return list, nil
+	}
+	m := &mapNode{pos: open.pos}
+	key := first
+	for {
+		name, err := mapKey(key)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(":"); err != nil {
+			return nil, err
+		}
+		value, err := p.parseExpression()
+		if err != nil {
+			return nil, err
+		}
+		m.keys = append(m.keys, name)
+		m.values = append(m.values, value)
+		if !p.isOperator(",") {
+			break
+		}
+		p.next()
+		if key, err = p.parseBinary(0); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := p.expect("}"); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// mapKey returns the key of an inline map entry, a string or a name.
+func mapKey(n node) (string, error) {
+	switch k := n.(type) {
+	case *literalNode:
+		if s, ok := k.value.(string); ok {
+			return s, nil
+		}
+	case *identNode:
+		return k.name, nil
+	}
+	return "", errorf(n.position(), "expected a string or a name as map key")
+}
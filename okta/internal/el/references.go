@@ -0,0 +1,93 @@
+package el
+
+// Reference is an attribute of a variable used by an expression, such as
+// firstName in user.firstName.
+type Reference struct {
+	// Pos is the offset of the reference in the expression, in runes.
+	Pos       int
+	Variable  string
+	Attribute string
+}
+
+func (r Reference) String() string {
+	return r.Variable + "." + r.Attribute
+}
+
+// References returns the attributes of the given variables that the
+// expression uses, in order of appearance. Method calls on a variable, such as
+// user.getInternalProperty("id"), are not attribute references.
+func (e *Expression) References(variables ...string) []Reference {
+	var refs []Reference
+	var walk func(n node)
+	walk = func(n node) {
+		switch n := n.(type) {
+		case *memberNode:
+			if v, pos, ok := variable(n.x, variables); ok {
+				refs = append(refs, Reference{Pos: pos, Variable: v, Attribute: n.name})
+				return
+			}
+			walk(n.x)
+		case *indexNode:
+			walk(n.x)
+			walk(n.index)
+		case *callNode:
+			if n.receiver != nil {
+				walk(n.receiver)
+			}
+			for _, arg := range n.args {
+				walk(arg)
+			}
+		case *unaryNode:
+			walk(n.x)
+		case *binaryNode:
+			walk(n.x)
+			walk(n.y)
+		case *conditionalNode:
+			walk(n.cond)
+			walk(n.then)
+			walk(n.els)
+		case *elvisNode:
+			walk(n.x)
+			walk(n.y)
+		case *projectionNode:
+			// the names of the expression are the attributes of the elements
+			walk(n.x)
+		case *selectionNode:
+			walk(n.x)
+		case *listNode:
+			for _, elem := range n.elems {
+				walk(elem)
+			}
+		case *mapNode:
+			for _, value := range n.values {
+				walk(value)
+			}
+		}
+	}
+	walk(e.root)
+	return refs
+}
+
+// variable tells whether n is one of variables, user or #root.user.
+func variable(n node, variables []string) (string, int, bool) {
+	var name string
+	var pos int
+	switch n := n.(type) {
+	case *identNode:
+		name, pos = n.name, n.pos
+	case *memberNode:
+		root, ok := n.x.(*contextVariableNode)
+		if !ok || root.name != "root" {
+			return "", 0, false
+		}
+		name, pos = n.name, root.pos
+	default:
+		return "", 0, false
+	}
+	for _, v := range variables {
+		if name == v {
+			return v, pos, true
+		}
+	}
+	return "", 0, false
+}
@@ -0,0 +1,23 @@
+package el
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReferences(t *testing.T) {
+	expr, err := Parse(`String.join(" ", user.firstName, appuser.lastName) + user.getInternalProperty("id") + source.user.email + (user.nickName ?: {user.login}[0])`)
+	require.NoError(t, err)
+	var refs []string
+	for _, ref := range expr.References("user", "appuser") {
+		refs = append(refs, ref.String())
+	}
+	assert.Equal(t, []string{"user.firstName", "appuser.lastName", "user.nickName", "user.login"}, refs)
+	assert.Empty(t, expr.References("app"))
+
+	expr, err = Parse(`#root.user.email + groups.?[name == user.login].![id]`)
+	require.NoError(t, err)
+	assert.Equal(t, []Reference{{Pos: 0, Variable: "user", Attribute: "email"}}, expr.References("user"))
+}
@@ -0,0 +1,116 @@
+/*
+* Copyright 2022 - Present Okta, Inc.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*      http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package eventtypes
+
+// eventHookEventTypes is a partial list of the event hook eligible event
+// types, maintained by hand and kept sorted. It isn't generated from the Okta
+// developer documentation yet, to replace it with the full catalog:
+// cd scripts/generate_event_types
+// go run main.go --catalog /path/to/okta-developer-docs/packages/@okta/vuepress-site/data/event-types.json --revision <commit>
+var eventHookEventTypes = []string{
+	"app.oauth2.as.consent.grant",
+	"app.oauth2.as.consent.revoke",
+	"application.lifecycle.activate",
+	"application.lifecycle.create",
+	"application.lifecycle.deactivate",
+	"application.lifecycle.delete",
+	"application.lifecycle.update",
+	"application.user_membership.add",
+	"application.user_membership.change_username",
+	"application.user_membership.remove",
+	"device.enrollment.create",
+	"device.lifecycle.activate",
+	"device.lifecycle.deactivate",
+	"device.lifecycle.delete",
+	"device.lifecycle.suspend",
+	"device.lifecycle.unsuspend",
+	"device.user.add",
+	"device.user.remove",
+	"event_hook.activated",
+	"event_hook.created",
+	"event_hook.deactivated",
+	"event_hook.deleted",
+	"event_hook.updated",
+	"event_hook.verified",
+	"group.application_assignment.add",
+	"group.application_assignment.remove",
+	"group.application_assignment.update",
+	"group.lifecycle.create",
+	"group.lifecycle.delete",
+	"group.privilege.grant",
+	"group.privilege.revoke",
+	"group.profile.update",
+	"group.user_membership.add",
+	"group.user_membership.remove",
+	"inline_hook.activated",
+	"inline_hook.created",
+	"inline_hook.deactivated",
+	"inline_hook.deleted",
+	"inline_hook.updated",
+	"policy.lifecycle.activate",
+	"policy.lifecycle.create",
+	"policy.lifecycle.deactivate",
+	"policy.lifecycle.delete",
+	"policy.lifecycle.update",
+	"policy.rule.activate",
+	"policy.rule.add",
+	"policy.rule.deactivate",
+	"policy.rule.delete",
+	"policy.rule.update",
+	"security.threat.detected",
+	"system.api_token.create",
+	"system.api_token.revoke",
+	"system.org.rate_limit.violation",
+	"system.org.rate_limit.warning",
+	"user.account.lock",
+	"user.account.privilege.grant",
+	"user.account.privilege.revoke",
+	"user.account.report_suspicious_activity_by_enduser",
+	"user.account.reset_password",
+	"user.account.unlock",
+	"user.account.unlock_by_admin",
+	"user.account.update_password",
+	"user.account.update_profile",
+	"user.authentication.sso",
+	"user.import.password",
+	"user.lifecycle.activate",
+	"user.lifecycle.create",
+	"user.lifecycle.deactivate",
+	"user.lifecycle.delete.completed",
+	"user.lifecycle.delete.initiated",
+	"user.lifecycle.reactivate",
+	"user.lifecycle.suspend",
+	"user.lifecycle.unsuspend",
+	"user.mfa.factor.activate",
+	"user.mfa.factor.deactivate",
+	"user.mfa.factor.reset_all",
+	"user.mfa.factor.suspend",
+	"user.mfa.factor.unsuspend",
+	"user.mfa.okta_verify.deny_push",
+	"user.risk.change",
+	"user.session.access_admin_app",
+	"user.session.clear",
+	"user.session.end",
+	"user.session.impersonation.grant",
+	"user.session.impersonation.initiate",
+	"user.session.start",
+	"zone.activate",
+	"zone.create",
+	"zone.deactivate",
+	"zone.delete",
+	"zone.update",
+}
@@ -0,0 +1,79 @@
+// Package eventtypes is the catalog of the event types which can be delivered
+// to event hooks. The catalog is a partial list maintained by hand until it is
+// generated from the event types reference of the Okta developer
+// documentation, see scripts/generate_event_types.
+package eventtypes
+
+import (
+	"sort"
+	"strings"
+)
+
+// maxSuggestionDistance is the maximal edit distance between an unknown event
+// type and the event type suggested for it.
+const maxSuggestionDistance = 3
+
+// All returns the event types of the catalog, sorted.
+func All() []string {
+	return WithPrefix("")
+}
+
+// WithPrefix returns the event types of the catalog starting with prefix,
+// sorted.
+func WithPrefix(prefix string) []string {
+	i := sort.SearchStrings(eventHookEventTypes, prefix)
+	j := i
+	for j < len(eventHookEventTypes) && strings.HasPrefix(eventHookEventTypes[j], prefix) {
+		j++
+	}
+	return append([]string{}, eventHookEventTypes[i:j]...)
+}
+
+// IsEligible tells whether the event type is in the catalog.
+func IsEligible(eventType string) bool {
+	i := sort.SearchStrings(eventHookEventTypes, eventType)
+	return i < len(eventHookEventTypes) && eventHookEventTypes[i] == eventType
+}
+
+// Suggest returns the event type of the catalog closest to an unknown event
+// type, or an empty string if none is close enough to be a likely typo.
+func Suggest(eventType string) string {
+	suggestion, best := "", maxSuggestionDistance+1
+	for _, candidate := range eventHookEventTypes {
+		if d := distance(eventType, candidate); d < best {
+			suggestion, best = candidate, d
+		}
+	}
+	return suggestion
+}
+
+// distance returns the Levenshtein distance between a and b.
+func distance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min3(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
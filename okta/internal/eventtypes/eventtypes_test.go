@@ -0,0 +1,51 @@
+package eventtypes
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCatalogIsSorted(t *testing.T) {
+	assert.True(t, sort.StringsAreSorted(eventHookEventTypes))
+	assert.Equal(t, len(eventHookEventTypes), len(All()))
+}
+
+func TestIsEligible(t *testing.T) {
+	assert.True(t, IsEligible("user.lifecycle.create"))
+	assert.True(t, IsEligible("user.lifecycle.delete.initiated"))
+	assert.False(t, IsEligible("user.lifecycle.creat"))
+	assert.False(t, IsEligible("user.lifecycle"))
+	assert.False(t, IsEligible(""))
+}
+
+func TestWithPrefix(t *testing.T) {
+	assert.Equal(t, []string{
+		"event_hook.activated",
+		"event_hook.created",
+		"event_hook.deactivated",
+		"event_hook.deleted",
+		"event_hook.updated",
+		"event_hook.verified",
+	}, WithPrefix("event_hook."))
+	assert.Empty(t, WithPrefix("unknown."))
+
+	types := WithPrefix("zone.")
+	types[0] = "changed"
+	assert.Equal(t, "zone.activate", WithPrefix("zone.")[0])
+}
+
+func TestSuggest(t *testing.T) {
+	assert.Equal(t, "user.lifecycle.create", Suggest("user.lifecycle.creat"))
+	assert.Equal(t, "group.user_membership.add", Suggest("group.user_membership.ad"))
+	assert.Equal(t, "user.session.start", Suggest("user.sesion.start"))
+	assert.Equal(t, "", Suggest("something.else.entirely"))
+}
+
+func TestDistance(t *testing.T) {
+	assert.Equal(t, 0, distance("zone.create", "zone.create"))
+	assert.Equal(t, 1, distance("zone.create", "zone.creat"))
+	assert.Equal(t, 3, distance("kitten", "sitting"))
+	assert.Equal(t, 4, distance("", "abcd"))
+}
@@ -0,0 +1,209 @@
+package okta
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-cleanhttp"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// imageAttributes names the attributes describing one image of a resource:
+// the local file path, the base64 encoded content as an alternative to the
+// file, and the hash of the image currently served by Okta.
+type imageAttributes struct {
+	path    string
+	content string
+	hash    string
+}
+
+var (
+	logoAttributes                 = imageAttributes{path: "logo", content: "logo_content", hash: "logo_hash"}
+	themeFaviconAttributes         = imageAttributes{path: "favicon", content: "favicon_content", hash: "favicon_hash"}
+	themeBackgroundImageAttributes = imageAttributes{path: "background_image", content: "background_image_content", hash: "background_image_hash"}
+	maxImageSize                   = 1 << 20 // should be less than 1 MB in size.
+	imageDownloadTimeout           = 10 * time.Second
+)
+
+func (a imageAttributes) hasChange(d *schema.ResourceData) bool {
+	return d.HasChanges(a.path, a.content)
+}
+
+// rollback restores the prior values after a failed upload.
+func (a imageAttributes) rollback(d *schema.ResourceData) {
+	o, _ := d.GetChange(a.path)
+	_ = d.Set(a.path, o)
+	o, _ = d.GetChange(a.content)
+	_ = d.Set(a.content, o)
+}
+
+// open returns the configured image. The file is nil when neither the path nor
+// the content are set and has to be closed with the returned func otherwise.
+func (a imageAttributes) open(d *schema.ResourceData) (*os.File, func(), error) {
+	if p, ok := d.GetOk(a.path); ok {
+		fo, err := os.Open(p.(string))
+		if err != nil {
+			return nil, nil, err
+		}
+		return fo, func() { fo.Close() }, nil
+	}
+	c, ok := d.GetOk(a.content)
+	if !ok {
+		return nil, nil, nil
+	}
+	content, err := base64.StdEncoding.DecodeString(c.(string))
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid '%s': %v", a.content, err)
+	}
+	fo, err := os.CreateTemp("", "okta-image-*"+imageExtension(content))
+	if err != nil {
+		return nil, nil, err
+	}
+	cleanup := func() {
+		fo.Close()
+		os.Remove(fo.Name())
+	}
+	if _, err = fo.Write(content); err == nil {
+		_, err = fo.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	return fo, cleanup, nil
+}
+
+// syncHash records the hash of the image served by Okta at url and compares
+// it with the hash of the configured image, which the state of the configured
+// attribute holds. When they differ the image was changed outside of
+// Terraform and the configured attribute is cleared so the next plan uploads
+// the configured image again. Nothing is compared while the configured image
+// is being uploaded.
+func (a imageAttributes) syncHash(ctx context.Context, d *schema.ResourceData, m interface{}, url string) {
+	configured := a.path
+	if d.Get(a.path).(string) == "" {
+		configured = a.content
+	}
+	if d.Get(configured).(string) == "" || url == "" {
+		_ = d.Set(a.hash, "")
+		return
+	}
+	current, err := fetchRemoteFileHash(ctx, url)
+	if err != nil {
+		logger(m).Warn("failed to download image, skipping drift detection", "url", url, "error", err)
+		return
+	}
+	if !a.hasChange(d) && d.Get(configured).(string) != current {
+		logger(m).Info("image was changed outside of terraform", "attribute", configured)
+		_ = d.Set(configured, "")
+	}
+	_ = d.Set(a.hash, current)
+}
+
+func syncAppLogo(ctx context.Context, d *schema.ResourceData, m interface{}, links interface{}) {
+	logoURL := linksValue(links, "logo", "href")
+	_ = d.Set("logo_url", logoURL)
+	logoAttributes.syncHash(ctx, d, m, logoURL)
+}
+
+func handleAppLogo(ctx context.Context, d *schema.ResourceData, m interface{}, appID string, links interface{}) error {
+	fo, cleanup, err := logoAttributes.open(d)
+	if err != nil || fo == nil {
+		return err
+	}
+	defer cleanup()
+	_, err = getOktaClientFromMetadata(m).Application.UploadApplicationLogo(ctx, appID, fo.Name())
+	return err
+}
+
+// base64ContentStateFunc stores the hash of the decoded content, similar to
+// localFileStateFunc for local files.
+func base64ContentStateFunc(val interface{}) string {
+	content, err := base64.StdEncoding.DecodeString(val.(string))
+	if err != nil || len(content) == 0 {
+		return ""
+	}
+	return computeContentHash(content)
+}
+
+func computeContentHash(content []byte) string {
+	h := sha256.Sum256(content)
+	return hex.EncodeToString(h[:])
+}
+
+// fetchRemoteFileHash downloads the image at url, the images Okta serves are
+// public. The download is bounded by imageDownloadTimeout as it happens on
+// every refresh.
+func fetchRemoteFileHash(ctx context.Context, url string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	client := cleanhttp.DefaultClient()
+	client.Timeout = imageDownloadTimeout
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status: %s", resp.Status)
+	}
+	h := sha256.New()
+	if _, err := io.Copy(h, resp.Body); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// imageExtension guesses the file extension of the image, Okta relies on it
+// to recognize the uploaded file type.
+func imageExtension(content []byte) string {
+	contentType := http.DetectContentType(content)
+	if strings.HasPrefix(contentType, "text/") && strings.Contains(string(content), "<svg") {
+		return ".svg"
+	}
+	if i := strings.Index(contentType, ";"); i >= 0 {
+		contentType = contentType[:i]
+	}
+	switch contentType {
+	case "image/png":
+		return ".png"
+	case "image/jpeg":
+		return ".jpg"
+	case "image/gif":
+		return ".gif"
+	}
+	if exts, _ := mime.ExtensionsByType(contentType); len(exts) > 0 {
+		return exts[0]
+	}
+	return ""
+}
+
+func imageContentIsValid() schema.SchemaValidateDiagFunc {
+	return func(i interface{}, k cty.Path) diag.Diagnostics {
+		v, ok := i.(string)
+		if !ok {
+			return diag.Errorf("expected type of %v to be string", k)
+		}
+		content, err := base64.StdEncoding.DecodeString(v)
+		if err != nil {
+			return diag.Errorf("expected %v to be base64 encoded: %v", k, err)
+		}
+		if len(content) > maxImageSize {
+			return diag.Errorf("%v should be less than 1 MB in size", k)
+		}
+		return nil
+	}
+}
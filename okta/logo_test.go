@@ -0,0 +1,72 @@
+package okta
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBase64ContentStateFunc(t *testing.T) {
+	content := []byte("\x89PNG\r\n\x1a\nlogo")
+	encoded := base64.StdEncoding.EncodeToString(content)
+	assert.Equal(t, computeContentHash(content), base64ContentStateFunc(encoded))
+	assert.Equal(t, "", base64ContentStateFunc(""))
+	assert.Equal(t, "", base64ContentStateFunc("not base64!"))
+}
+
+func TestImageExtension(t *testing.T) {
+	assert.Equal(t, ".png", imageExtension([]byte("\x89PNG\r\n\x1a\n")))
+	assert.Equal(t, ".gif", imageExtension([]byte("GIF89a")))
+	assert.Equal(t, ".jpg", imageExtension([]byte("\xff\xd8\xff\xe0")))
+	assert.Equal(t, ".svg", imageExtension([]byte(`<?xml version="1.0"?><svg xmlns="http://www.w3.org/2000/svg"></svg>`)))
+}
+
+func TestFetchRemoteFileHash(t *testing.T) {
+	content := []byte("\x89PNG\r\n\x1a\nlogo")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/logo.png" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(content)
+	}))
+	defer server.Close()
+
+	hash, err := fetchRemoteFileHash(context.Background(), server.URL+"/logo.png")
+	require.NoError(t, err)
+	assert.Equal(t, computeContentHash(content), hash)
+
+	_, err = fetchRemoteFileHash(context.Background(), server.URL+"/missing.png")
+	assert.Error(t, err)
+}
+
+func TestImageAttributesSyncHash(t *testing.T) {
+	served := []byte("\x89PNG\r\n\x1a\nserved")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(served)
+	}))
+	defer server.Close()
+	m := &Config{logger: hclog.NewNullLogger()}
+	desired := computeContentHash([]byte("\x89PNG\r\n\x1a\ndesired"))
+
+	d := resourceAppSaml().Data(&terraform.InstanceState{ID: "app", Attributes: map[string]string{"logo_content": desired}})
+	logoAttributes.syncHash(context.Background(), d, m, server.URL)
+	assert.Equal(t, "", d.Get("logo_content"), "changed image must be uploaded again")
+	assert.Equal(t, computeContentHash(served), d.Get("logo_hash"))
+
+	d = resourceAppSaml().Data(&terraform.InstanceState{ID: "app", Attributes: map[string]string{"logo_content": computeContentHash(served)}})
+	logoAttributes.syncHash(context.Background(), d, m, server.URL)
+	assert.Equal(t, computeContentHash(served), d.Get("logo_content"))
+	assert.Equal(t, computeContentHash(served), d.Get("logo_hash"))
+
+	d = resourceAppSaml().Data(&terraform.InstanceState{ID: "app", Attributes: map[string]string{}})
+	logoAttributes.syncHash(context.Background(), d, m, server.URL)
+	assert.Equal(t, "", d.Get("logo_hash"), "nothing is downloaded without a configured image")
+}
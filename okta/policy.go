@@ -3,7 +3,10 @@ package okta
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"time"
 
+	"github.com/cenkalti/backoff/v4"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/okta/terraform-provider-okta/sdk"
 	"github.com/okta/terraform-provider-okta/sdk/query"
@@ -138,7 +141,32 @@ func createPolicy(ctx context.Context, d *schema.ResourceData, m interface{}, te
 	if err := ensureNotDefaultPolicy(d); err != nil {
 		return err
 	}
-	policy, _, err := getAPISupplementFromMetadata(m).CreatePolicy(ctx, template)
+	var policy *sdk.SdkPolicy
+	bOff := backoff.NewExponentialBackOff()
+	bOff.MaxElapsedTime = d.Timeout(schema.TimeoutCreate)
+	err := backoff.Retry(func() error {
+		created, resp, err := getAPISupplementFromMetadata(m).CreatePolicy(ctx, template)
+		if err == nil {
+			policy = created
+			return nil
+		}
+		if !isAmbiguousCreateFailure(resp, err) {
+			return backoff.Permanent(err)
+		}
+		// the policy might have been created even though the request failed,
+		// the API rejects names in use so a policy with the name is that one
+		policyID, lookupErr := findPolicyIDByNameAndType(ctx, m, template.Name, template.Type)
+		if lookupErr != nil || policyID == "" {
+			return err
+		}
+		adopted, _, getErr := getAPISupplementFromMetadata(m).GetPolicy(ctx, policyID)
+		if getErr != nil {
+			return err
+		}
+		logger(m).Warn("adopting policy created by a failed request", "id", policyID, "name", template.Name, "error", err)
+		policy = adopted
+		return nil
+	}, backoff.WithContext(bOff, ctx))
 	if err != nil {
 		return err
 	}
@@ -154,6 +182,23 @@ func createPolicy(ctx context.Context, d *schema.ResourceData, m interface{}, te
 	return policyActivate(ctx, d, m)
 }
 
+// policyCreateTimeouts bounds the retries of the creation of policies and
+// rules, see createPolicy and createRule.
+func policyCreateTimeouts() *schema.ResourceTimeout {
+	return &schema.ResourceTimeout{
+		Create: schema.DefaultTimeout(20 * time.Minute),
+	}
+}
+
+// isAmbiguousCreateFailure tells whether a failed create request might still
+// have created the object: no response was received or the server failed.
+func isAmbiguousCreateFailure(resp *sdk.Response, err error) bool {
+	if err == nil {
+		return false
+	}
+	return resp == nil || resp.Response == nil || resp.StatusCode >= http.StatusInternalServerError
+}
+
 func ensureNotDefaultPolicy(d *schema.ResourceData) error {
 	return ensureNotDefault(d, "Policy")
 }
@@ -288,6 +333,27 @@ func findSystemPolicyByType(ctx context.Context, m interface{}, _type string) (*
 	return nil, fmt.Errorf("default system %q policy not found", _type)
 }
 
+// findPolicyIDByNameAndType returns the ID of the policy with the given name
+// and type, or an empty string when there's none.
+func findPolicyIDByNameAndType(ctx context.Context, m interface{}, name, policyType string) (string, error) {
+	policies, resp, err := getOktaClientFromMetadata(m).Policy.ListPolicies(ctx, &query.Params{Type: policyType})
+	for {
+		if err != nil {
+			return "", fmt.Errorf("failed to list policies: %v", err)
+		}
+		for _, _policy := range policies {
+			policy := _policy.(*sdk.Policy)
+			if policy.Name == name {
+				return policy.Id, nil
+			}
+		}
+		if !resp.HasNextPage() {
+			return "", nil
+		}
+		resp, err = resp.Next(ctx, &policies)
+	}
+}
+
 func findPolicyByNameAndType(ctx context.Context, m interface{}, name, policyType string) (*sdk.Policy, error) {
 	policies, resp, err := getOktaClientFromMetadata(m).Policy.ListPolicies(ctx, &query.Params{Type: policyType})
 	if err != nil {
@@ -0,0 +1,297 @@
+package okta
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/netip"
+	"sort"
+	"strings"
+
+	"github.com/okta/terraform-provider-okta/sdk"
+)
+
+// policyEvaluationContext is the synthetic sign-in the rules of a policy are
+// evaluated against. Empty values don't match conditions requiring them.
+type policyEvaluationContext struct {
+	UserID           string
+	GroupIDs         []string
+	ZoneIDs          []string
+	PlatformType     string
+	OSType           string
+	DeviceRegistered bool
+	DeviceManaged    bool
+	RiskLevel        string
+}
+
+// evaluatedPolicyRule holds the parts of a policy rule of any type needed to
+// evaluate it locally.
+type evaluatedPolicyRule struct {
+	ID         string
+	Name       string
+	Status     string
+	Priority   int64
+	System     bool
+	Conditions *sdk.PolicyRuleConditions
+	// RawConditions holds all conditions, including the ones without a field
+	// in sdk.PolicyRuleConditions
+	RawConditions map[string]interface{}
+	// the device condition of access policy rules differs from the generic one
+	Device  *sdk.DeviceAccessPolicyRuleCondition
+	Actions json.RawMessage
+}
+
+func parseEvaluatedPolicyRule(raw json.RawMessage) (*evaluatedPolicyRule, error) {
+	var rule sdk.SdkPolicyRule
+	if err := json.Unmarshal(raw, &rule); err != nil {
+		return nil, err
+	}
+	var extra struct {
+		Actions    json.RawMessage `json:"actions"`
+		Conditions struct {
+			Device *sdk.DeviceAccessPolicyRuleCondition `json:"device"`
+		} `json:"conditions"`
+	}
+	if err := json.Unmarshal(raw, &extra); err != nil {
+		return nil, err
+	}
+	var rawConditions struct {
+		Conditions map[string]interface{} `json:"conditions"`
+	}
+	if err := json.Unmarshal(raw, &rawConditions); err != nil {
+		return nil, err
+	}
+	return &evaluatedPolicyRule{
+		ID:            rule.Id,
+		Name:          rule.Name,
+		Status:        rule.Status,
+		Priority:      rule.Priority,
+		System:        rule.System != nil && *rule.System,
+		Conditions:    rule.Conditions,
+		RawConditions: rawConditions.Conditions,
+		Device:        extra.Conditions.Device,
+		Actions:       extra.Actions,
+	}, nil
+}
+
+// ruleZoneIDs returns the IDs of the network zones referenced by the rules.
+func ruleZoneIDs(rules []*evaluatedPolicyRule) []string {
+	var ids []string
+	for _, rule := range rules {
+		if rule.Conditions == nil || rule.Conditions.Network == nil {
+			continue
+		}
+		for _, id := range append(rule.Conditions.Network.Include, rule.Conditions.Network.Exclude...) {
+			if !contains(ids, id) {
+				ids = append(ids, id)
+			}
+		}
+	}
+	return ids
+}
+
+// zonesContainingIP returns the IDs of the zones whose gateways contain the IP.
+func zonesContainingIP(zones []*sdk.NetworkZone, ip netip.Addr) []string {
+	var ids []string
+	for _, zone := range zones {
+		for _, gateway := range zone.Gateways {
+			if gateway != nil && addressContainsIP(gateway.Value, ip) {
+				ids = append(ids, zone.Id)
+				break
+			}
+		}
+	}
+	return ids
+}
+
+// addressContainsIP handles the CIDR and RANGE gateway formats of network
+// zones, e.g. "1.2.3.0/24" or "1.2.3.4-1.2.3.10".
+func addressContainsIP(address string, ip netip.Addr) bool {
+	if strings.Contains(address, "/") {
+		prefix, err := netip.ParsePrefix(address)
+		return err == nil && prefix.Contains(ip)
+	}
+	if from, to, ok := strings.Cut(address, "-"); ok {
+		start, err := netip.ParseAddr(strings.TrimSpace(from))
+		if err != nil {
+			return false
+		}
+		end, err := netip.ParseAddr(strings.TrimSpace(to))
+		if err != nil {
+			return false
+		}
+		return start.Compare(ip) <= 0 && ip.Compare(end) <= 0
+	}
+	addr, err := netip.ParseAddr(address)
+	return err == nil && addr == ip
+}
+
+// policyApplies evaluates the people condition of the policy itself.
+func (c *policyEvaluationContext) policyApplies(policy *sdk.Policy) (bool, string) {
+	if policy.Status == statusInactive {
+		return false, "policy is inactive"
+	}
+	if policy.Conditions == nil {
+		return true, ""
+	}
+	return c.matchPeople(policy.Conditions.People)
+}
+
+// matchRule evaluates the conditions of the rule, the reason tells why the
+// rule doesn't match.
+func (c *policyEvaluationContext) matchRule(rule *evaluatedPolicyRule) (bool, string) {
+	if rule.Status == statusInactive {
+		return false, "rule is inactive"
+	}
+	if k := rule.unevaluatedCondition(); k != "" {
+		return false, fmt.Sprintf("the %s condition can't be evaluated locally", k)
+	}
+	matchers := []func(*evaluatedPolicyRule) (bool, string){
+		func(r *evaluatedPolicyRule) (bool, string) { return c.matchPeople(r.Conditions.People) },
+		func(r *evaluatedPolicyRule) (bool, string) { return c.matchNetwork(r.Conditions.Network) },
+		func(r *evaluatedPolicyRule) (bool, string) { return c.matchPlatform(r.Conditions.Platform) },
+		func(r *evaluatedPolicyRule) (bool, string) { return c.matchDevice(r.Device) },
+		func(r *evaluatedPolicyRule) (bool, string) { return c.matchRisk(r.Conditions) },
+	}
+	if rule.Conditions == nil {
+		rule.Conditions = &sdk.PolicyRuleConditions{}
+	}
+	for _, match := range matchers {
+		if ok, reason := match(rule); !ok {
+			return false, reason
+		}
+	}
+	return true, ""
+}
+
+// evaluatedPolicyRuleConditions are the conditions matchRule evaluates.
+var evaluatedPolicyRuleConditions = []string{"people", "network", "platform", "device", "riskScore", "risk"}
+
+// unevaluatedCondition returns the first condition of the rule, in
+// alphabetical order, that restricts the sign-ins it matches and can't be
+// evaluated locally, e.g. an Okta expression or the type of the user.
+func (r *evaluatedPolicyRule) unevaluatedCondition() string {
+	var keys []string
+	for k, v := range r.RawConditions {
+		if !contains(evaluatedPolicyRuleConditions, k) && !isNeutralCondition(v) {
+			keys = append(keys, k)
+		}
+	}
+	if len(keys) == 0 {
+		return ""
+	}
+	sort.Strings(keys)
+	return keys[0]
+}
+
+func (c *policyEvaluationContext) matchPeople(people *sdk.PolicyPeopleCondition) (bool, string) {
+	if people == nil {
+		return true, ""
+	}
+	if users := people.Users; users != nil {
+		if contains(users.Exclude, c.UserID) {
+			return false, "user is excluded"
+		}
+		if len(users.Include) > 0 && !contains(users.Include, c.UserID) {
+			return false, "user is not included"
+		}
+	}
+	if groups := people.Groups; groups != nil {
+		if containsOne(groups.Exclude, c.GroupIDs...) {
+			return false, "a group of the user is excluded"
+		}
+		if len(groups.Include) > 0 && !containsOne(groups.Include, c.GroupIDs...) {
+			return false, "no group of the user is included"
+		}
+	}
+	return true, ""
+}
+
+// matchNetwork treats a sign-in from any network zone as ON_NETWORK.
+func (c *policyEvaluationContext) matchNetwork(network *sdk.PolicyNetworkCondition) (bool, string) {
+	if network == nil {
+		return true, ""
+	}
+	switch network.Connection {
+	case "", "ANYWHERE":
+		return true, ""
+	case "ON_NETWORK":
+		if len(c.ZoneIDs) == 0 {
+			return false, "sign-in is off network"
+		}
+	case "OFF_NETWORK":
+		if len(c.ZoneIDs) > 0 {
+			return false, "sign-in is on network"
+		}
+	case "ZONE":
+		if containsOne(network.Exclude, c.ZoneIDs...) {
+			return false, "network zone is excluded"
+		}
+		if len(network.Include) > 0 && !containsOne(network.Include, c.ZoneIDs...) {
+			return false, "network zone is not included"
+		}
+	}
+	return true, ""
+}
+
+func (c *policyEvaluationContext) matchPlatform(platform *sdk.PlatformPolicyRuleCondition) (bool, string) {
+	if platform == nil {
+		return true, ""
+	}
+	for _, p := range platform.Exclude {
+		if c.isPlatform(p) {
+			return false, "platform is excluded"
+		}
+	}
+	if len(platform.Include) == 0 {
+		return true, ""
+	}
+	for _, p := range platform.Include {
+		if c.isPlatform(p) {
+			return true, ""
+		}
+	}
+	return false, "platform is not included"
+}
+
+func (c *policyEvaluationContext) isPlatform(p *sdk.PlatformConditionEvaluatorPlatform) bool {
+	if p == nil {
+		return false
+	}
+	if p.Type != "" && p.Type != "ANY" && !strings.EqualFold(p.Type, c.PlatformType) {
+		return false
+	}
+	if p.Os == nil || p.Os.Type == "" || p.Os.Type == "ANY" {
+		return true
+	}
+	return strings.EqualFold(p.Os.Type, c.OSType)
+}
+
+func (c *policyEvaluationContext) matchDevice(device *sdk.DeviceAccessPolicyRuleCondition) (bool, string) {
+	if device == nil {
+		return true, ""
+	}
+	if device.Registered != nil && *device.Registered && !c.DeviceRegistered {
+		return false, "device is not registered"
+	}
+	if device.Managed != nil && *device.Managed && !c.DeviceManaged {
+		return false, "device is not managed"
+	}
+	if device.Assurance != nil && len(device.Assurance.Include) > 0 {
+		return false, "device assurance can't be evaluated locally"
+	}
+	return true, ""
+}
+
+// matchRisk fails on behaviors, they depend on the sign-in history of the user
+// and can't be evaluated locally.
+func (c *policyEvaluationContext) matchRisk(conditions *sdk.PolicyRuleConditions) (bool, string) {
+	if score := conditions.RiskScore; score != nil && score.Level != "" && score.Level != "ANY" {
+		if !strings.EqualFold(score.Level, c.RiskLevel) {
+			return false, fmt.Sprintf("risk level is not %s", score.Level)
+		}
+	}
+	if risk := conditions.Risk; risk != nil && len(risk.Behaviors) > 0 {
+		return false, "behaviors can't be evaluated locally"
+	}
+	return true, ""
+}
@@ -0,0 +1,136 @@
+package okta
+
+import (
+	"net/http"
+	"net/netip"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/okta/terraform-provider-okta/sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseEvaluatedPolicyRule(t *testing.T) {
+	rule, err := parseEvaluatedPolicyRule([]byte(`{
+  "id": "rul1",
+  "name": "Managed devices",
+  "priority": 1,
+  "status": "ACTIVE",
+  "conditions": {
+    "people": {"groups": {"include": ["grp1"]}},
+    "device": {"registered": true, "managed": true},
+    "riskScore": {"level": "HIGH"}
+  },
+  "actions": {"appSignOn": {"access": "ALLOW"}}
+}`))
+	require.NoError(t, err)
+	assert.Equal(t, "rul1", rule.ID)
+	assert.Equal(t, []string{"grp1"}, rule.Conditions.People.Groups.Include)
+	assert.True(t, *rule.Device.Registered)
+	assert.True(t, *rule.Device.Managed)
+	assert.JSONEq(t, `{"appSignOn": {"access": "ALLOW"}}`, string(rule.Actions))
+}
+
+func TestPolicyEvaluationMatchRule(t *testing.T) {
+	rule := func(conditions *sdk.PolicyRuleConditions, device *sdk.DeviceAccessPolicyRuleCondition) *evaluatedPolicyRule {
+		return &evaluatedPolicyRule{Status: statusActive, Conditions: conditions, Device: device}
+	}
+	evalCtx := &policyEvaluationContext{
+		UserID:        "usr1",
+		GroupIDs:      []string{"grp1", "grp2"},
+		ZoneIDs:       []string{"zone1"},
+		PlatformType:  "DESKTOP",
+		OSType:        "MACOS",
+		DeviceManaged: true,
+		RiskLevel:     "LOW",
+	}
+	tests := []struct {
+		name    string
+		rule    *evaluatedPolicyRule
+		matches bool
+	}{
+		{"no conditions", rule(nil, nil), true},
+		{"inactive", &evaluatedPolicyRule{Status: statusInactive}, false},
+		{"group included", rule(&sdk.PolicyRuleConditions{People: &sdk.PolicyPeopleCondition{Groups: &sdk.GroupCondition{Include: []string{"grp2"}}}}, nil), true},
+		{"group not included", rule(&sdk.PolicyRuleConditions{People: &sdk.PolicyPeopleCondition{Groups: &sdk.GroupCondition{Include: []string{"grp3"}}}}, nil), false},
+		{"group excluded", rule(&sdk.PolicyRuleConditions{People: &sdk.PolicyPeopleCondition{Groups: &sdk.GroupCondition{Exclude: []string{"grp1"}}}}, nil), false},
+		{"user excluded", rule(&sdk.PolicyRuleConditions{People: &sdk.PolicyPeopleCondition{Users: &sdk.UserCondition{Exclude: []string{"usr1"}}}}, nil), false},
+		{"zone included", rule(&sdk.PolicyRuleConditions{Network: &sdk.PolicyNetworkCondition{Connection: "ZONE", Include: []string{"zone1"}}}, nil), true},
+		{"zone excluded", rule(&sdk.PolicyRuleConditions{Network: &sdk.PolicyNetworkCondition{Connection: "ZONE", Exclude: []string{"zone1"}}}, nil), false},
+		{"off network", rule(&sdk.PolicyRuleConditions{Network: &sdk.PolicyNetworkCondition{Connection: "OFF_NETWORK"}}, nil), false},
+		{"platform included", rule(&sdk.PolicyRuleConditions{Platform: &sdk.PlatformPolicyRuleCondition{Include: []*sdk.PlatformConditionEvaluatorPlatform{
+			{Type: "MOBILE", Os: &sdk.PlatformConditionEvaluatorPlatformOperatingSystem{Type: "IOS"}},
+			{Type: "DESKTOP", Os: &sdk.PlatformConditionEvaluatorPlatformOperatingSystem{Type: "MACOS"}},
+		}}}, nil), true},
+		{"platform not included", rule(&sdk.PolicyRuleConditions{Platform: &sdk.PlatformPolicyRuleCondition{Include: []*sdk.PlatformConditionEvaluatorPlatform{
+			{Type: "DESKTOP", Os: &sdk.PlatformConditionEvaluatorPlatformOperatingSystem{Type: "WINDOWS"}},
+		}}}, nil), false},
+		{"device managed", rule(nil, &sdk.DeviceAccessPolicyRuleCondition{Managed: boolPtr(true)}), true},
+		{"device registered", rule(nil, &sdk.DeviceAccessPolicyRuleCondition{Registered: boolPtr(true)}), false},
+		{"device assurance", rule(nil, &sdk.DeviceAccessPolicyRuleCondition{Assurance: &sdk.DeviceAssurancePolicyRuleCondition{Include: []string{"da1"}}}), false},
+		{"risk level", rule(&sdk.PolicyRuleConditions{RiskScore: &sdk.RiskScorePolicyRuleCondition{Level: "HIGH"}}, nil), false},
+		{"behaviors", rule(&sdk.PolicyRuleConditions{Risk: &sdk.RiskPolicyRuleCondition{Behaviors: []string{"bhv1"}}}, nil), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches, reason := evalCtx.matchRule(tt.rule)
+			assert.Equal(t, tt.matches, matches, reason)
+			assert.Equal(t, tt.matches, reason == "")
+		})
+	}
+}
+
+func TestPolicyEvaluationUnevaluatedConditions(t *testing.T) {
+	evalCtx := &policyEvaluationContext{UserID: "usr1"}
+	tests := []struct {
+		conditions string
+		reason     string
+	}{
+		{`{"people": {"users": {"include": ["usr1"]}}, "authContext": {"authType": "ANY"}}`, ""},
+		{`{"elCondition": {"condition": "user.profile.department == 'Sales'"}}`, "the elCondition condition can't be evaluated locally"},
+		{`{"userType": {"include": ["oty1"]}, "app": {"include": [{"type": "APP", "id": "app1"}]}}`, "the app condition can't be evaluated locally"},
+		{`{"identityProvider": {"provider": "SPECIFIC_IDP", "idpIds": ["idp1"]}}`, "the identityProvider condition can't be evaluated locally"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.conditions, func(t *testing.T) {
+			rule, err := parseEvaluatedPolicyRule([]byte(`{"id": "rul1", "status": "ACTIVE", "conditions": ` + tt.conditions + `}`))
+			require.NoError(t, err)
+			matches, reason := evalCtx.matchRule(rule)
+			assert.Equal(t, tt.reason == "", matches)
+			assert.Equal(t, tt.reason, reason)
+		})
+	}
+}
+
+func TestBuildPolicyEvaluationContextGroups(t *testing.T) {
+	ctx, m := newTestConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/api/v1/users/usr1/groups" && r.URL.Query().Get("after") == "grp2":
+			_, _ = w.Write([]byte(`[{"id": "grp3"}]`))
+		case r.URL.Path == "/api/v1/users/usr1/groups":
+			w.Header().Set("Link", nextPageLink(r, "grp2"))
+			_, _ = w.Write([]byte(`[{"id": "grp2"}, {"id": "grp1"}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	d := schema.TestResourceDataRaw(t, dataSourcePolicyEvaluation().Schema, map[string]interface{}{
+		"policy_id": "pol1",
+		"user_id":   "usr1",
+	})
+	evalCtx, err := buildPolicyEvaluationContext(ctx, d, m, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"grp1", "grp2", "grp3"}, evalCtx.GroupIDs)
+}
+
+func TestZonesContainingIP(t *testing.T) {
+	zones := []*sdk.NetworkZone{
+		{Id: "cidr", Gateways: []*sdk.NetworkZoneAddress{{Type: "CIDR", Value: "10.0.0.0/8"}}},
+		{Id: "range", Gateways: []*sdk.NetworkZoneAddress{{Type: "RANGE", Value: "192.168.1.10-192.168.1.20"}}},
+	}
+	assert.Equal(t, []string{"cidr"}, zonesContainingIP(zones, netip.MustParseAddr("10.1.2.3")))
+	assert.Equal(t, []string{"range"}, zonesContainingIP(zones, netip.MustParseAddr("192.168.1.15")))
+	assert.Empty(t, zonesContainingIP(zones, netip.MustParseAddr("192.168.1.21")))
+}
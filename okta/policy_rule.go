@@ -3,7 +3,6 @@ package okta
 import (
 	"context"
 	"fmt"
-	"net/http"
 	"strings"
 
 	"github.com/cenkalti/backoff/v4"
@@ -39,7 +38,7 @@ var (
 		"priority": {
 			Type:        schema.TypeInt,
 			Optional:    true,
-			Description: "Policy Rule Priority, this attribute can be set to a valid priority. To avoid endless diff situation we error if an invalid priority is provided. API defaults it to the last (lowest) if not there.",
+			Description: "Policy Rule Priority, this attribute can be set to a valid priority. To avoid endless diff situation we error if an invalid priority is provided. API defaults it to the last (lowest) if not there. Leave it unset when the rules of the policy are ordered with okta_policy_rule_order.",
 			// Suppress diff if config is empty.
 			DiffSuppressFunc: createValueDiffSuppression("0"),
 		},
@@ -90,11 +89,11 @@ var (
 )
 
 func buildBaseRuleSchema(target map[string]*schema.Schema) map[string]*schema.Schema {
-	return buildSchema(baseRuleSchema, target)
+	return buildSchema(baseRuleSchema, shadowingCheckSchema, target)
 }
 
 func buildRuleSchema(target map[string]*schema.Schema) map[string]*schema.Schema {
-	return buildSchema(baseRuleSchema, target, userExcludedSchema)
+	return buildSchema(baseRuleSchema, shadowingCheckSchema, target, userExcludedSchema)
 }
 
 func createRule(ctx context.Context, d *schema.ResourceData, m interface{}, template sdk.SdkPolicyRule, ruleType string) error {
@@ -107,18 +106,35 @@ func createRule(ctx context.Context, d *schema.ResourceData, m interface{}, temp
 	if policyID == "" {
 		return fmt.Errorf("'policy_id' field should be set")
 	}
+	// creating a rule shifts the priorities of the other rules of the policy
+	oktaMutexKV.Lock(policyID)
+	defer oktaMutexKV.Unlock(policyID)
 	var rule *sdk.SdkPolicyRule
+	bOff := backoff.NewExponentialBackOff()
+	bOff.MaxElapsedTime = d.Timeout(schema.TimeoutCreate)
 	err = backoff.Retry(func() error {
 		ruleObj, resp, err := getAPISupplementFromMetadata(m).CreatePolicyRule(ctx, policyID, template)
-		if resp.StatusCode == http.StatusInternalServerError {
-			return err
+		if err == nil {
+			rule = ruleObj
+			return nil
 		}
-		if err != nil {
+		if !isAmbiguousCreateFailure(resp, err) {
 			return backoff.Permanent(err)
 		}
-		rule = ruleObj
+		// the rule might have been created even though the request failed,
+		// the API rejects names in use so a rule with the name is that one
+		ruleID, lookupErr := findPolicyRuleIDByName(ctx, m, policyID, template.Name)
+		if lookupErr != nil || ruleID == "" {
+			return err
+		}
+		adopted, _, getErr := getAPISupplementFromMetadata(m).GetPolicyRule(ctx, policyID, ruleID)
+		if getErr != nil {
+			return err
+		}
+		logger(m).Warn("adopting policy rule created by a failed request", "policy_id", policyID, "id", ruleID, "name", template.Name, "error", err)
+		rule = adopted
 		return nil
-	}, backoff.NewExponentialBackOff())
+	}, backoff.WithContext(bOff, ctx))
 	if err != nil {
 		return fmt.Errorf("failed to create policy rule: %v", err)
 	}
@@ -134,6 +150,21 @@ func createRule(ctx context.Context, d *schema.ResourceData, m interface{}, temp
 	return validatePriority(template.Priority, rule.Priority)
 }
 
+// findPolicyRuleIDByName returns the ID of the rule of the policy with the
+// given name, or an empty string when there's none.
+func findPolicyRuleIDByName(ctx context.Context, m interface{}, policyID, name string) (string, error) {
+	positions, _, err := getAPISupplementFromMetadata(m).ListPolicyRulePositions(ctx, policyID)
+	if err != nil {
+		return "", fmt.Errorf("failed to list policy rules: %v", err)
+	}
+	for _, position := range positions {
+		if position.Name == name {
+			return position.Id, nil
+		}
+	}
+	return "", nil
+}
+
 func createPolicyRuleImporter() *schema.ResourceImporter {
 	return &schema.ResourceImporter{
 		StateContext: func(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
@@ -230,6 +261,8 @@ func updateRule(ctx context.Context, d *schema.ResourceData, m interface{}, temp
 	if policyID == "" {
 		return fmt.Errorf("'policy_id' field should be set")
 	}
+	oktaMutexKV.Lock(policyID)
+	defer oktaMutexKV.Unlock(policyID)
 	rule, _, err := getAPISupplementFromMetadata(m).UpdatePolicyRule(ctx, policyID, d.Id(), template)
 	if err != nil {
 		return err
@@ -287,6 +320,8 @@ func deleteRule(ctx context.Context, d *schema.ResourceData, m interface{}, chec
 		if policyID == "" {
 			return fmt.Errorf("'policy_id' field should be set")
 		}
+		oktaMutexKV.Lock(policyID)
+		defer oktaMutexKV.Unlock(policyID)
 		_, err = getOktaClientFromMetadata(m).Policy.DeletePolicyRule(ctx, policyID, d.Id())
 		if err != nil {
 			return err
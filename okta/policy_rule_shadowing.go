@@ -0,0 +1,376 @@
+package okta
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/okta/terraform-provider-okta/sdk"
+	"github.com/okta/terraform-provider-okta/sdk/query"
+)
+
+const (
+	shadowingCheckWarn  = "WARN"
+	shadowingCheckError = "ERROR"
+)
+
+var shadowingCheckSchema = map[string]*schema.Schema{
+	"shadowing_check": {
+		Type:             schema.TypeString,
+		Optional:         true,
+		ValidateDiagFunc: stringInSlice(shadowingCheckWarn, shadowingCheckError),
+		Description:      "Opt-in check whether the rule is shadowed by a rule with a higher priority or shadows a rule with a lower priority: WARN reports a warning after the rule is saved, ERROR fails the plan.",
+	},
+}
+
+// policyRuleScope holds the conditions of a rule that are compared to find
+// shadowed rules. Empty includes match everyone and everything.
+type policyRuleScope struct {
+	Name              string
+	UsersInclude      []string
+	UsersExclude      []string
+	GroupsInclude     []string
+	GroupsExclude     []string
+	NetworkConnection string
+	NetworkInclude    []string
+	NetworkExclude    []string
+	Platforms         []*sdk.PlatformConditionEvaluatorPlatform
+	// OtherConditions is set when the rule has conditions that aren't
+	// compared, such a rule can be shadowed but can't shadow another one.
+	OtherConditions bool
+}
+
+// policyRuleData is implemented by schema.ResourceData and schema.ResourceDiff.
+type policyRuleData interface {
+	Id() string
+	Get(key string) interface{}
+	GetOk(key string) (interface{}, bool)
+}
+
+// policyRuleShadowing holds the attributes of a rule resource holding
+// conditions that aren't compared, the rule isn't considered to shadow other
+// rules when any of them restricts the rule.
+type policyRuleShadowing []string
+
+// customizeDiff fails the plan when shadowing_check is ERROR and the planned
+// rule is shadowed by or shadows a sibling rule. Terraform doesn't support
+// warnings at plan time, the WARN check is done by warnings once the rule is
+// saved.
+func (s policyRuleShadowing) customizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Get("shadowing_check").(string) != shadowingCheckError {
+		return nil
+	}
+	for _, k := range append([]string{"policy_id", "priority", "status", "users_included", "users_excluded", "groups_included",
+		"groups_excluded", "network_connection", "network_includes", "network_excludes", "platform_include"}, s...) {
+		if !d.NewValueKnown(k) {
+			return nil
+		}
+	}
+	messages, err := s.find(ctx, d, m)
+	if err != nil || len(messages) == 0 {
+		return err
+	}
+	return errors.New(strings.Join(messages, "; "))
+}
+
+// warnings returns the shadowing findings as warnings when shadowing_check is
+// WARN, it is called by create and update once the rule is saved.
+func (s policyRuleShadowing) warnings(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if d.Get("shadowing_check").(string) != shadowingCheckWarn {
+		return nil
+	}
+	messages, err := s.find(ctx, d, m)
+	if err != nil {
+		logger(m).Warn("failed to check policy rule shadowing", "error", err)
+		return nil
+	}
+	var diags diag.Diagnostics
+	for _, msg := range messages {
+		diags = append(diags, diag.Diagnostic{Severity: diag.Warning, Summary: msg})
+	}
+	return diags
+}
+
+// find loads the sibling rules of the rule and reports the rules it is
+// shadowed by or shadows, comparing users, groups, network zones and
+// platforms.
+func (s policyRuleShadowing) find(ctx context.Context, d policyRuleData, m interface{}) ([]string, error) {
+	policyID := d.Get("policy_id").(string)
+	if policyID == "" || d.Get("status").(string) == statusInactive {
+		return nil, nil
+	}
+	rawRules, _, err := getAPISupplementFromMetadata(m).ListPolicyRulesJSON(ctx, policyID)
+	if err != nil {
+		logger(m).Warn("failed to list policy rules, skipping shadowing check", "policy_id", policyID, "error", err)
+		return nil, nil
+	}
+	planned := plannedPolicyRuleScope(d, s)
+	priority := int64(d.Get("priority").(int))
+	needsEveryone := len(planned.GroupsInclude) > 0
+	rules := make([]*evaluatedPolicyRule, 0, len(rawRules))
+	for i := range rawRules {
+		rule, err := parseEvaluatedPolicyRule(rawRules[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse policy rule: %v", err)
+		}
+		if rule.ID == d.Id() {
+			if priority == 0 {
+				priority = rule.Priority
+			}
+			continue
+		}
+		if rule.Status == statusInactive {
+			continue
+		}
+		if c := rule.Conditions; c != nil && c.People != nil && c.People.Groups != nil && len(c.People.Groups.Include) > 0 {
+			needsEveryone = true
+		}
+		rules = append(rules, rule)
+	}
+	var everyoneID string
+	if needsEveryone {
+		everyoneID, err = findEveryoneGroupID(ctx, m)
+		if err != nil {
+			logger(m).Warn("failed to find the Everyone group, skipping shadowing check", "error", err)
+			return nil, nil
+		}
+	}
+	planned.normalize(everyoneID)
+	var higher, lower []*policyRuleScope
+	for _, rule := range rules {
+		// a new rule without priority is added after the others
+		if priority == 0 || rule.Priority < priority {
+			higher = append(higher, existingPolicyRuleScope(rule, everyoneID))
+		} else if !rule.System {
+			lower = append(lower, existingPolicyRuleScope(rule, everyoneID))
+		}
+	}
+	var messages []string
+	for _, rule := range higher {
+		if rule.covers(planned) {
+			messages = append(messages, fmt.Sprintf("rule '%s' is shadowed by rule '%s' which has a higher priority", planned.Name, rule.Name))
+		}
+	}
+	for _, rule := range lower {
+		if planned.covers(rule) {
+			messages = append(messages, fmt.Sprintf("rule '%s' shadows rule '%s' which has a lower priority", planned.Name, rule.Name))
+		}
+	}
+	return messages, nil
+}
+
+func plannedPolicyRuleScope(d policyRuleData, otherConditions []string) *policyRuleScope {
+	list := func(k string) []string {
+		v, ok := d.GetOk(k)
+		if !ok {
+			return nil
+		}
+		if set, ok := v.(*schema.Set); ok {
+			return convertInterfaceToStringSet(set)
+		}
+		return convertInterfaceToStringArr(v)
+	}
+	scope := &policyRuleScope{
+		Name:           d.Get("name").(string),
+		UsersInclude:   list("users_included"),
+		UsersExclude:   list("users_excluded"),
+		GroupsInclude:  list("groups_included"),
+		GroupsExclude:  list("groups_excluded"),
+		NetworkInclude: list("network_includes"),
+		NetworkExclude: list("network_excludes"),
+	}
+	scope.NetworkConnection, _ = d.Get("network_connection").(string)
+	if v, ok := d.GetOk("platform_include"); ok {
+		for _, item := range v.(*schema.Set).List() {
+			value := item.(map[string]interface{})
+			scope.Platforms = append(scope.Platforms, &sdk.PlatformConditionEvaluatorPlatform{
+				Type: getMapString(value, "type"),
+				Os:   &sdk.PlatformConditionEvaluatorPlatformOperatingSystem{Type: getMapString(value, "os_type")},
+			})
+		}
+	}
+	for _, k := range otherConditions {
+		v, ok := d.GetOk(k)
+		if ok && !isNeutralCondition(v) {
+			scope.OtherConditions = true
+		}
+	}
+	return scope
+}
+
+// existingPolicyRuleScope returns the scope of a rule read from Okta.
+func existingPolicyRuleScope(rule *evaluatedPolicyRule, everyoneID string) *policyRuleScope {
+	scope := &policyRuleScope{Name: rule.Name}
+	if c := rule.Conditions; c != nil {
+		if c.People != nil && c.People.Users != nil {
+			scope.UsersInclude = c.People.Users.Include
+			scope.UsersExclude = c.People.Users.Exclude
+		}
+		if c.People != nil && c.People.Groups != nil {
+			scope.GroupsInclude = c.People.Groups.Include
+			scope.GroupsExclude = c.People.Groups.Exclude
+		}
+		if c.Network != nil {
+			scope.NetworkConnection = c.Network.Connection
+			scope.NetworkInclude = c.Network.Include
+			scope.NetworkExclude = c.Network.Exclude
+		}
+		if c.Platform != nil {
+			scope.Platforms = c.Platform.Include
+		}
+	}
+	for k, v := range rule.RawConditions {
+		if k != "people" && k != "network" && k != "platform" && !isNeutralCondition(v) {
+			scope.OtherConditions = true
+		}
+	}
+	scope.normalize(everyoneID)
+	return scope
+}
+
+// isNeutralCondition tells whether a condition value matches every sign-in,
+// e.g. {"authType": "ANY"} or an empty list.
+func isNeutralCondition(v interface{}) bool {
+	switch t := v.(type) {
+	case nil:
+		return true
+	case string:
+		return t == "" || t == "ANY"
+	case bool:
+		return !t
+	case int:
+		return t == 0
+	case float64:
+		return t == 0
+	case []interface{}:
+		return len(t) == 0
+	case *schema.Set:
+		return t.Len() == 0
+	case map[string]interface{}:
+		for _, e := range t {
+			if !isNeutralCondition(e) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// normalize treats the Everyone group as no group condition.
+func (s *policyRuleScope) normalize(everyoneID string) {
+	if everyoneID != "" && contains(s.GroupsInclude, everyoneID) {
+		s.GroupsInclude = nil
+	}
+	if s.NetworkConnection == "" {
+		s.NetworkConnection = "ANYWHERE"
+	}
+}
+
+// covers tells whether every sign-in matching o matches s as well.
+func (s *policyRuleScope) covers(o *policyRuleScope) bool {
+	if s.OtherConditions {
+		return false
+	}
+	if !setCovers(s.UsersInclude, s.UsersExclude, o.UsersInclude, o.UsersExclude, true) {
+		return false
+	}
+	if !setCovers(s.GroupsInclude, s.GroupsExclude, o.GroupsInclude, o.GroupsExclude, false) {
+		return false
+	}
+	switch {
+	case s.NetworkConnection == "ANYWHERE":
+	case s.NetworkConnection != o.NetworkConnection:
+		return false
+	case s.NetworkConnection == "ZONE" && !setCovers(s.NetworkInclude, s.NetworkExclude, o.NetworkInclude, o.NetworkExclude, false):
+		return false
+	}
+	return platformsCover(s.Platforms, o.Platforms)
+}
+
+// setCovers tells whether everything included and not excluded by the second
+// pair of lists is included and not excluded by the first pair. Groups can
+// share members and network zones IP ranges, so unless the IDs are disjoint,
+// like those of users, what the first pair excludes must be excluded by the
+// second pair as well.
+func setCovers(include, exclude, otherInclude, otherExclude []string, disjoint bool) bool {
+	if len(include) > 0 {
+		if len(otherInclude) == 0 {
+			return false
+		}
+		for _, id := range otherInclude {
+			if !contains(include, id) {
+				return false
+			}
+		}
+	}
+	for _, id := range exclude {
+		if contains(otherExclude, id) {
+			continue
+		}
+		if !disjoint || len(otherInclude) == 0 || contains(otherInclude, id) {
+			return false
+		}
+	}
+	return true
+}
+
+func platformsCover(platforms, other []*sdk.PlatformConditionEvaluatorPlatform) bool {
+	if isAnyPlatform(platforms) {
+		return true
+	}
+	if isAnyPlatform(other) {
+		return false
+	}
+	for _, o := range other {
+		covered := false
+		for _, p := range platforms {
+			if platformCovers(p, o) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			return false
+		}
+	}
+	return true
+}
+
+func isAnyPlatform(platforms []*sdk.PlatformConditionEvaluatorPlatform) bool {
+	for _, p := range platforms {
+		if platformCovers(p, &sdk.PlatformConditionEvaluatorPlatform{}) {
+			return true
+		}
+	}
+	return len(platforms) == 0
+}
+
+func platformCovers(p, o *sdk.PlatformConditionEvaluatorPlatform) bool {
+	if p == nil || o == nil {
+		return false
+	}
+	if p.Type != "" && p.Type != "ANY" && p.Type != o.Type {
+		return false
+	}
+	if p.Os == nil || p.Os.Type == "" || p.Os.Type == "ANY" {
+		return true
+	}
+	return o.Os != nil && p.Os.Type == o.Os.Type && p.Os.Expression == o.Os.Expression
+}
+
+func findEveryoneGroupID(ctx context.Context, m interface{}) (string, error) {
+	groups, _, err := getOktaClientFromMetadata(m).Group.ListGroups(ctx, &query.Params{Q: groupProfileEveryone})
+	if err != nil {
+		return "", err
+	}
+	for i := range groups {
+		if groups[i].Profile.Name == groupProfileEveryone {
+			return groups[i].Id, nil
+		}
+	}
+	return "", nil
+}
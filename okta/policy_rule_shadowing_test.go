@@ -0,0 +1,95 @@
+package okta
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/okta/terraform-provider-okta/sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicyRuleScopeCovers(t *testing.T) {
+	mac := &sdk.PlatformConditionEvaluatorPlatform{Type: "DESKTOP", Os: &sdk.PlatformConditionEvaluatorPlatformOperatingSystem{Type: "MACOS"}}
+	windows := &sdk.PlatformConditionEvaluatorPlatform{Type: "DESKTOP", Os: &sdk.PlatformConditionEvaluatorPlatformOperatingSystem{Type: "WINDOWS"}}
+	anyDesktop := &sdk.PlatformConditionEvaluatorPlatform{Type: "DESKTOP", Os: &sdk.PlatformConditionEvaluatorPlatformOperatingSystem{Type: "ANY"}}
+	tests := []struct {
+		name   string
+		higher policyRuleScope
+		lower  policyRuleScope
+		covers bool
+	}{
+		{"everything", policyRuleScope{}, policyRuleScope{GroupsInclude: []string{"grp1"}, NetworkConnection: "ZONE", NetworkInclude: []string{"zone1"}}, true},
+		{"nothing covers everything", policyRuleScope{GroupsInclude: []string{"grp1"}}, policyRuleScope{}, false},
+		{"groups subset", policyRuleScope{GroupsInclude: []string{"grp1", "grp2"}}, policyRuleScope{GroupsInclude: []string{"grp2"}}, true},
+		{"groups not subset", policyRuleScope{GroupsInclude: []string{"grp1"}}, policyRuleScope{GroupsInclude: []string{"grp1", "grp2"}}, false},
+		{"excluded group", policyRuleScope{GroupsExclude: []string{"grp1"}}, policyRuleScope{}, false},
+		{"excluded group excluded by both", policyRuleScope{GroupsExclude: []string{"grp1"}}, policyRuleScope{GroupsExclude: []string{"grp1"}}, true},
+		// users can be members of both groups
+		{"excluded group not included", policyRuleScope{GroupsExclude: []string{"grp1"}}, policyRuleScope{GroupsInclude: []string{"grp2"}}, false},
+		{"excluded user", policyRuleScope{UsersExclude: []string{"usr1"}}, policyRuleScope{UsersInclude: []string{"usr1"}}, false},
+		{"excluded user not included", policyRuleScope{UsersExclude: []string{"usr1"}}, policyRuleScope{UsersInclude: []string{"usr2"}}, true},
+		// zones can share IP ranges
+		{"excluded zone not included", policyRuleScope{NetworkConnection: "ZONE", NetworkExclude: []string{"zone1"}}, policyRuleScope{NetworkConnection: "ZONE", NetworkInclude: []string{"zone2"}}, false},
+		{"excluded zone excluded by both", policyRuleScope{NetworkConnection: "ZONE", NetworkExclude: []string{"zone1"}}, policyRuleScope{NetworkConnection: "ZONE", NetworkInclude: []string{"zone2"}, NetworkExclude: []string{"zone1"}}, true},
+		{"zones subset", policyRuleScope{NetworkConnection: "ZONE", NetworkInclude: []string{"zone1", "zone2"}}, policyRuleScope{NetworkConnection: "ZONE", NetworkInclude: []string{"zone1"}}, true},
+		{"zones not subset", policyRuleScope{NetworkConnection: "ZONE", NetworkInclude: []string{"zone1"}}, policyRuleScope{NetworkConnection: "ZONE", NetworkInclude: []string{"zone2"}}, false},
+		{"zone vs anywhere", policyRuleScope{NetworkConnection: "ZONE", NetworkInclude: []string{"zone1"}}, policyRuleScope{NetworkConnection: "ANYWHERE"}, false},
+		{"on network", policyRuleScope{NetworkConnection: "ON_NETWORK"}, policyRuleScope{NetworkConnection: "ON_NETWORK", GroupsInclude: []string{"grp1"}}, true},
+		{"platform subset", policyRuleScope{Platforms: []*sdk.PlatformConditionEvaluatorPlatform{anyDesktop}}, policyRuleScope{Platforms: []*sdk.PlatformConditionEvaluatorPlatform{mac, windows}}, true},
+		{"platform not subset", policyRuleScope{Platforms: []*sdk.PlatformConditionEvaluatorPlatform{mac}}, policyRuleScope{Platforms: []*sdk.PlatformConditionEvaluatorPlatform{mac, windows}}, false},
+		{"other conditions", policyRuleScope{OtherConditions: true}, policyRuleScope{}, false},
+		{"other conditions of the lower rule", policyRuleScope{}, policyRuleScope{OtherConditions: true}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.higher.normalize("")
+			tt.lower.normalize("")
+			assert.Equal(t, tt.covers, tt.higher.covers(&tt.lower))
+		})
+	}
+}
+
+func TestPolicyRuleScopeEveryoneGroup(t *testing.T) {
+	everyone := &policyRuleScope{GroupsInclude: []string{"everyone"}}
+	everyone.normalize("everyone")
+	assert.True(t, everyone.covers(&policyRuleScope{GroupsInclude: []string{"grp1"}, NetworkConnection: "ANYWHERE"}))
+}
+
+func TestIsNeutralCondition(t *testing.T) {
+	assert.True(t, isNeutralCondition(map[string]interface{}{"authType": "ANY"}))
+	assert.True(t, isNeutralCondition(map[string]interface{}{"condition": "", "include": []interface{}{}}))
+	assert.False(t, isNeutralCondition(map[string]interface{}{"level": "HIGH"}))
+	assert.False(t, isNeutralCondition(map[string]interface{}{"include": []interface{}{"app1"}}))
+}
+
+func TestPolicyRuleShadowingWarnings(t *testing.T) {
+	ctx, m := newTestConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/policies/pol1/rules" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[
+			{"id": "rul1", "name": "catch all", "status": "ACTIVE", "priority": 1, "conditions": {}},
+			{"id": "rul2", "name": "test", "status": "ACTIVE", "priority": 2, "conditions": {}}
+		]`))
+	})
+
+	d := schema.TestResourceDataRaw(t, resourcePolicyPasswordRule().Schema, map[string]interface{}{
+		"policy_id":       "pol1",
+		"name":            "test",
+		"shadowing_check": shadowingCheckWarn,
+	})
+	d.SetId("rul2")
+	diags := passwordPolicyRuleShadowing.warnings(ctx, d, m)
+	require.Len(t, diags, 1)
+	assert.Equal(t, diag.Warning, diags[0].Severity)
+	assert.Equal(t, "rule 'test' is shadowed by rule 'catch all' which has a higher priority", diags[0].Summary)
+
+	// ERROR is checked at plan time only
+	_ = d.Set("shadowing_check", shadowingCheckError)
+	assert.Empty(t, passwordPolicyRuleShadowing.warnings(ctx, d, m))
+}
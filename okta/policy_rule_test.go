@@ -0,0 +1,66 @@
+package okta
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/okta/terraform-provider-okta/sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateRuleAdoptsRuleAfterAmbiguousFailure(t *testing.T) {
+	var rules []map[string]interface{}
+	creates := 0
+	ctx, m := newTestConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/policies/00p1/rules":
+			_ = json.NewEncoder(w).Encode(rules)
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/policies/00p1/rules":
+			creates++
+			if len(rules) > 0 {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"errorCode":"E0000001","errorSummary":"Api validation failed: name","errorCauses":[{"errorSummary":"name: A rule with this name already exists."}]}`))
+				return
+			}
+			// the rule is created but the response is lost
+			rules = append(rules, map[string]interface{}{"id": "0pr1", "name": "test", "priority": 1, "status": "ACTIVE", "type": "SIGN_ON"})
+			w.WriteHeader(http.StatusGatewayTimeout)
+			_, _ = w.Write([]byte(`{"errorCode":"E0000009","errorSummary":"Gateway timeout"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/policies/00p1/rules/0pr1":
+			_ = json.NewEncoder(w).Encode(rules[0])
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	d := schema.TestResourceDataRaw(t, resourcePolicySignOnRule().Schema, map[string]interface{}{
+		"policy_id": "00p1",
+		"name":      "test",
+	})
+
+	err := createRule(ctx, d, m, buildSignOnPolicyRule(d), policyRuleSignOn)
+	require.NoError(t, err)
+	assert.Equal(t, "0pr1", d.Id())
+	assert.Equal(t, 1, creates)
+
+	// the rule exists now, the API reports the conflict which isn't retried
+	d = schema.TestResourceDataRaw(t, resourcePolicySignOnRule().Schema, map[string]interface{}{
+		"policy_id": "00p1",
+		"name":      "test",
+	})
+	err = createRule(ctx, d, m, buildSignOnPolicyRule(d), policyRuleSignOn)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "A rule with this name already exists")
+	assert.Equal(t, 2, creates)
+}
+
+func TestIsAmbiguousCreateFailure(t *testing.T) {
+	err := assert.AnError
+	assert.False(t, isAmbiguousCreateFailure(nil, nil))
+	assert.True(t, isAmbiguousCreateFailure(nil, err))
+	assert.True(t, isAmbiguousCreateFailure(&sdk.Response{Response: &http.Response{StatusCode: http.StatusBadGateway}}, err))
+	assert.False(t, isAmbiguousCreateFailure(&sdk.Response{Response: &http.Response{StatusCode: http.StatusBadRequest}}, err))
+}
@@ -0,0 +1,239 @@
+package okta
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/okta/terraform-provider-okta/sdk"
+)
+
+// policySnapshotVolatileKeys are the attributes of policies and rules set by
+// Okta, they are left out of snapshots so that a snapshot only changes when
+// the policy does.
+var policySnapshotVolatileKeys = []string{"id", "created", "lastUpdated", "_links", "_embedded"}
+
+// policySnapshot is a point-in-time copy of a policy and its rules, the rules
+// are ordered by priority.
+type policySnapshot struct {
+	Policy map[string]interface{}   `json:"policy"`
+	Rules  []map[string]interface{} `json:"rules"`
+}
+
+// policyRuleRef identifies an existing rule of a policy being restored.
+type policyRuleRef struct {
+	ID     string
+	Name   string
+	System bool
+}
+
+// policyRuleRestorePlan tells how the rules of a snapshot are restored:
+// snapshot rules are updated in place when Update has their index, the other
+// ones are created, and the rules in Delete are removed from the policy.
+type policyRuleRestorePlan struct {
+	Update map[int]string
+	Delete []string
+}
+
+func newPolicySnapshot(policy map[string]interface{}, rawRules []json.RawMessage) (*policySnapshot, error) {
+	snapshot := &policySnapshot{
+		Policy: stripPolicySnapshotKeys(policy),
+		Rules:  make([]map[string]interface{}, 0, len(rawRules)),
+	}
+	for i := range rawRules {
+		var rule map[string]interface{}
+		if err := json.Unmarshal(rawRules[i], &rule); err != nil {
+			return nil, fmt.Errorf("failed to parse policy rule: %v", err)
+		}
+		snapshot.Rules = append(snapshot.Rules, stripPolicySnapshotKeys(rule))
+	}
+	sort.SliceStable(snapshot.Rules, func(i, j int) bool {
+		return mapPriority(snapshot.Rules[i]) < mapPriority(snapshot.Rules[j])
+	})
+	return snapshot, nil
+}
+
+// parsePolicySnapshot reads a snapshot and checks that the policy and its
+// rules can be matched by name.
+func parsePolicySnapshot(s string) (*policySnapshot, error) {
+	var snapshot policySnapshot
+	if err := json.Unmarshal([]byte(s), &snapshot); err != nil {
+		return nil, fmt.Errorf("failed to parse policy snapshot: %v", err)
+	}
+	if snapshot.Policy == nil {
+		return nil, errors.New("policy snapshot has no 'policy'")
+	}
+	if getMapString(snapshot.Policy, "name") == "" || getMapString(snapshot.Policy, "type") == "" {
+		return nil, errors.New("policy of the snapshot must have a 'name' and a 'type'")
+	}
+	var names []string
+	for _, rule := range snapshot.Rules {
+		name := getMapString(rule, "name")
+		if name == "" {
+			return nil, errors.New("all the rules of the policy snapshot must have a 'name'")
+		}
+		if contains(names, name) {
+			return nil, fmt.Errorf("rule '%s' is more than once in the policy snapshot", name)
+		}
+		names = append(names, name)
+	}
+	sort.SliceStable(snapshot.Rules, func(i, j int) bool {
+		return mapPriority(snapshot.Rules[i]) < mapPriority(snapshot.Rules[j])
+	})
+	return &snapshot, nil
+}
+
+func (s *policySnapshot) String() (string, error) {
+	b, err := json.Marshal(s)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func stripPolicySnapshotKeys(v map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(v))
+	for k := range v {
+		if !contains(policySnapshotVolatileKeys, k) {
+			out[k] = v[k]
+		}
+	}
+	return out
+}
+
+// planPolicyRuleRestore matches the rules of a snapshot with the existing
+// rules by name. The system rule of the snapshot is matched with the system
+// rule of the policy, since it can be neither created nor deleted.
+func planPolicyRuleRestore(rules []map[string]interface{}, existing []policyRuleRef) policyRuleRestorePlan {
+	plan := policyRuleRestorePlan{Update: map[int]string{}}
+	matched := map[string]bool{}
+	for i, rule := range rules {
+		system, _ := rule["system"].(bool)
+		for _, ref := range existing {
+			if matched[ref.ID] {
+				continue
+			}
+			if ref.Name == getMapString(rule, "name") || (system && ref.System) {
+				plan.Update[i] = ref.ID
+				matched[ref.ID] = true
+				break
+			}
+		}
+	}
+	for _, ref := range existing {
+		if !matched[ref.ID] && !ref.System {
+			plan.Delete = append(plan.Delete, ref.ID)
+		}
+	}
+	return plan
+}
+
+// exportPolicy reads a policy and all its rules into a snapshot.
+func exportPolicy(ctx context.Context, m interface{}, policyID string) (*policySnapshot, error) {
+	policy, _, err := getAPISupplementFromMetadata(m).GetPolicyJSON(ctx, policyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get policy: %v", err)
+	}
+	rules, _, err := getAPISupplementFromMetadata(m).ListPolicyRulesJSON(ctx, policyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list policy rules: %v", err)
+	}
+	return newPolicySnapshot(policy, rules)
+}
+
+// restorePolicy creates or updates the policy of the snapshot with the same
+// name and type, then brings its rules in line with the snapshot. It returns
+// the ID of the policy and the IDs of its rules by name.
+func restorePolicy(ctx context.Context, m interface{}, snapshot *policySnapshot) (string, map[string]string, error) {
+	name, policyType := getMapString(snapshot.Policy, "name"), getMapString(snapshot.Policy, "type")
+	policyID, err := findPolicyIDByNameAndType(ctx, m, name, policyType)
+	if err != nil {
+		return "", nil, err
+	}
+	client := getAPISupplementFromMetadata(m)
+	var policy map[string]interface{}
+	if policyID == "" {
+		logger(m).Info("creating policy from snapshot", "name", name, "type", policyType)
+		policy, _, err = client.CreatePolicyJSON(ctx, snapshot.Policy)
+	} else {
+		logger(m).Info("updating policy from snapshot", "id", policyID, "name", name)
+		policy, _, err = client.UpdatePolicyJSON(ctx, policyID, snapshot.Policy)
+	}
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to restore policy '%s': %v", name, err)
+	}
+	policyID = getMapString(policy, "id")
+	if err = restorePolicyStatus(ctx, m, policyID, "", getMapString(snapshot.Policy, "status"), getMapString(policy, "status")); err != nil {
+		return policyID, nil, err
+	}
+
+	// restoring rules shifts the priorities of the other rules of the policy
+	oktaMutexKV.Lock(policyID)
+	defer oktaMutexKV.Unlock(policyID)
+	rawRules, _, err := client.ListPolicyRulesJSON(ctx, policyID)
+	if err != nil {
+		return policyID, nil, fmt.Errorf("failed to list policy rules: %v", err)
+	}
+	existing := make([]policyRuleRef, len(rawRules))
+	for i := range rawRules {
+		var ref sdk.PolicyRulePosition
+		if err = json.Unmarshal(rawRules[i], &ref); err != nil {
+			return policyID, nil, fmt.Errorf("failed to parse policy rule: %v", err)
+		}
+		existing[i] = policyRuleRef{ID: ref.Id, Name: ref.Name, System: ref.System != nil && *ref.System}
+	}
+	plan := planPolicyRuleRestore(snapshot.Rules, existing)
+	for _, ruleID := range plan.Delete {
+		logger(m).Info("deleting policy rule missing from snapshot", "policy_id", policyID, "rule_id", ruleID)
+		resp, err := getOktaClientFromMetadata(m).Policy.DeletePolicyRule(ctx, policyID, ruleID)
+		if err := suppressErrorOn404(resp, err); err != nil {
+			return policyID, nil, fmt.Errorf("failed to delete policy rule '%s': %v", ruleID, err)
+		}
+	}
+	ruleIDs := make(map[string]string, len(snapshot.Rules))
+	for i, body := range snapshot.Rules {
+		var rule map[string]interface{}
+		ruleID, ok := plan.Update[i]
+		if ok {
+			rule, _, err = client.UpdatePolicyRuleJSON(ctx, policyID, ruleID, body)
+		} else {
+			rule, _, err = client.CreatePolicyRuleJSON(ctx, policyID, body)
+		}
+		if err != nil {
+			return policyID, ruleIDs, fmt.Errorf("failed to restore policy rule '%s': %v", getMapString(body, "name"), err)
+		}
+		ruleID = getMapString(rule, "id")
+		ruleIDs[getMapString(rule, "name")] = ruleID
+		err = restorePolicyStatus(ctx, m, policyID, ruleID, getMapString(body, "status"), getMapString(rule, "status"))
+		if err != nil {
+			return policyID, ruleIDs, err
+		}
+	}
+	return policyID, ruleIDs, nil
+}
+
+// restorePolicyStatus activates or deactivates the policy, or the rule when
+// ruleID is set, when its status differs from the snapshot.
+func restorePolicyStatus(ctx context.Context, m interface{}, policyID, ruleID, expected, actual string) error {
+	if expected == "" || expected == actual {
+		return nil
+	}
+	policies := getOktaClientFromMetadata(m).Policy
+	var err error
+	switch {
+	case ruleID == "" && expected == statusActive:
+		_, err = policies.ActivatePolicy(ctx, policyID)
+	case ruleID == "" && expected == statusInactive:
+		_, err = policies.DeactivatePolicy(ctx, policyID)
+	case expected == statusActive:
+		_, err = policies.ActivatePolicyRule(ctx, policyID, ruleID)
+	case expected == statusInactive:
+		_, err = policies.DeactivatePolicyRule(ctx, policyID, ruleID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to change status to %s: %v", expected, err)
+	}
+	return nil
+}
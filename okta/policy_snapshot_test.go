@@ -0,0 +1,75 @@
+package okta
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPolicySnapshot(t *testing.T) {
+	policy := map[string]interface{}{
+		"id":          "00p1",
+		"name":        "Sign On",
+		"type":        "OKTA_SIGN_ON",
+		"status":      "ACTIVE",
+		"created":     "2023-01-01T00:00:00.000Z",
+		"lastUpdated": "2023-01-02T00:00:00.000Z",
+		"_links":      map[string]interface{}{"self": map[string]interface{}{"href": "https://example.okta.com"}},
+	}
+	rules := []json.RawMessage{
+		json.RawMessage(`{"id":"0pr2","name":"Default Rule","priority":2,"system":true,"actions":{"signon":{"access":"ALLOW"}}}`),
+		json.RawMessage(`{"id":"0pr1","name":"Deny","priority":1,"created":"2023-01-01T00:00:00.000Z","actions":{"signon":{"access":"DENY"}}}`),
+	}
+	snapshot, err := newPolicySnapshot(policy, rules)
+	require.NoError(t, err)
+	value, err := snapshot.String()
+	require.NoError(t, err)
+	assert.Equal(t, `{"policy":{"name":"Sign On","status":"ACTIVE","type":"OKTA_SIGN_ON"},"rules":[`+
+		`{"actions":{"signon":{"access":"DENY"}},"name":"Deny","priority":1},`+
+		`{"actions":{"signon":{"access":"ALLOW"}},"name":"Default Rule","priority":2,"system":true}]}`, value)
+
+	parsed, err := parsePolicySnapshot(value)
+	require.NoError(t, err)
+	again, err := parsed.String()
+	require.NoError(t, err)
+	assert.Equal(t, value, again)
+}
+
+func TestParsePolicySnapshot(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		err  string
+	}{
+		{"not json", `{`, "failed to parse"},
+		{"no policy", `{"rules":[]}`, "no 'policy'"},
+		{"no policy type", `{"policy":{"name":"test"}}`, "'name' and a 'type'"},
+		{"unnamed rule", `{"policy":{"name":"test","type":"OKTA_SIGN_ON"},"rules":[{"priority":1}]}`, "must have a 'name'"},
+		{"duplicate rule", `{"policy":{"name":"test","type":"OKTA_SIGN_ON"},"rules":[{"name":"r"},{"name":"r"}]}`, "more than once"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parsePolicySnapshot(tt.json)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.err)
+		})
+	}
+}
+
+func TestPlanPolicyRuleRestore(t *testing.T) {
+	rules := []map[string]interface{}{
+		{"name": "Deny", "priority": float64(1)},
+		{"name": "Allow", "priority": float64(2)},
+		{"name": "Renamed Default Rule", "priority": float64(3), "system": true},
+	}
+	existing := []policyRuleRef{
+		{ID: "0pr1", Name: "Allow"},
+		{ID: "0pr2", Name: "Extra"},
+		{ID: "0pr3", Name: "Default Rule", System: true},
+	}
+	plan := planPolicyRuleRestore(rules, existing)
+	assert.Equal(t, map[int]string{1: "0pr1", 2: "0pr3"}, plan.Update)
+	assert.Equal(t, []string{"0pr2"}, plan.Delete)
+}
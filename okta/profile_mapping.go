@@ -0,0 +1,144 @@
+package okta
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/okta/terraform-provider-okta/okta/internal/el"
+	"github.com/okta/terraform-provider-okta/sdk"
+)
+
+const (
+	profileMappingSourceUser    = "user"
+	profileMappingSourceAppUser = "appuser"
+)
+
+// profileAttributeReference is a `user.*` or `appuser.*` attribute found in an
+// Okta Expression Language expression.
+type profileAttributeReference struct {
+	Prefix    string
+	Attribute string
+}
+
+func (r profileAttributeReference) String() string {
+	return r.Prefix + "." + r.Attribute
+}
+
+// profileAttributeReferences parses an Okta Expression Language expression
+// and returns the profile attributes it references. Method calls on the
+// profile object, e.g. `user.getInternalProperty("id")`, are not treated as
+// attribute references.
+func profileAttributeReferences(expression string) ([]profileAttributeReference, error) {
+	expr, err := el.Parse(expression)
+	if err != nil {
+		return nil, err
+	}
+	var refs []profileAttributeReference
+	for _, ref := range expr.References(profileMappingSourceUser, profileMappingSourceAppUser) {
+		refs = append(refs, profileAttributeReference{Prefix: ref.Variable, Attribute: ref.Attribute})
+	}
+	return refs, nil
+}
+
+// userSchemaAttributeNames returns the set of base and custom attribute names
+// of the given schema.
+func userSchemaAttributeNames(s *sdk.UserSchema) map[string]bool {
+	names := map[string]bool{}
+	if s == nil || s.Definitions == nil {
+		return names
+	}
+	if s.Definitions.Base != nil {
+		for k := range s.Definitions.Base.Properties {
+			names[k] = true
+		}
+	}
+	if s.Definitions.Custom != nil {
+		for k := range s.Definitions.Custom.Properties {
+			names[k] = true
+		}
+	}
+	return names
+}
+
+// getProfileMappingSourceSchema fetches the schema of the mapping's source,
+// which is either an Okta user type or an application user.
+func getProfileMappingSourceSchema(ctx context.Context, m interface{}, source *sdk.ProfileMappingSource) (*sdk.UserSchema, error) {
+	client := getOktaClientFromMetadata(m)
+	switch source.Type {
+	case profileMappingSourceAppUser:
+		s, _, err := client.UserSchema.GetApplicationUserSchema(ctx, source.Id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get app user schema for app '%s': %v", source.Id, err)
+		}
+		return s, nil
+	case profileMappingSourceUser:
+		typeSchemaID, err := getUserTypeSchemaID(ctx, client, source.Id)
+		if err != nil {
+			return nil, err
+		}
+		s, _, err := client.UserSchema.GetUserSchema(ctx, typeSchemaID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user schema '%s': %v", typeSchemaID, err)
+		}
+		return s, nil
+	}
+	return nil, nil
+}
+
+// validateMappingExpressions checks that every `user.*` or `appuser.*`
+// attribute referenced by the mapping expressions exists in the source schema.
+// Only references whose prefix matches the source type are checked.
+func validateMappingExpressions(mappings []interface{}, sourceType string, attributes map[string]bool) error {
+	var errs []string
+	for _, raw := range mappings {
+		mapping, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		id, _ := mapping["id"].(string)
+		expression, _ := mapping["expression"].(string)
+		refs, err := profileAttributeReferences(expression)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("mapping '%s': invalid expression '%s': %v", id, expression, err))
+			continue
+		}
+		for _, ref := range refs {
+			if ref.Prefix != sourceType || attributes[ref.Attribute] {
+				continue
+			}
+			errs = append(errs, fmt.Sprintf("mapping '%s': expression '%s' references '%s' which does not exist in the source schema", id, expression, ref))
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	sort.Strings(errs)
+	return fmt.Errorf("invalid profile mapping expressions:\n  %s", strings.Join(errs, "\n  "))
+}
+
+func profileMappingCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() != "" && !d.HasChange("mappings") {
+		return nil
+	}
+	if !d.NewValueKnown("source_id") || !d.NewValueKnown("target_id") || !d.NewValueKnown("mappings") {
+		return nil
+	}
+	set, ok := d.Get("mappings").(*schema.Set)
+	if !ok || set.Len() == 0 {
+		return nil
+	}
+	mapping, _, err := getProfileMappingBySourceID(ctx, d.Get("source_id").(string), d.Get("target_id").(string), m)
+	if err != nil || mapping == nil || mapping.Source == nil {
+		logger(m).Warn("skipping profile mapping expression validation, mapping could not be read", "error", err)
+		return nil
+	}
+	sourceSchema, err := getProfileMappingSourceSchema(ctx, m, mapping.Source)
+	if err != nil || sourceSchema == nil {
+		logger(m).Warn("skipping profile mapping expression validation, source schema could not be read", "error", err)
+		return nil
+	}
+	return validateMappingExpressions(set.List(), mapping.Source.Type, userSchemaAttributeNames(sourceSchema))
+}
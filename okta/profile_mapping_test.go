@@ -0,0 +1,53 @@
+package okta
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProfileAttributeReferences(t *testing.T) {
+	tests := []struct {
+		expression string
+		expected   []string
+		wantErr    bool
+	}{
+		{expression: "appuser.firstName", expected: []string{"appuser.firstName"}},
+		{expression: `String.join(" ", user.firstName, user.lastName)`, expected: []string{"user.firstName", "user.lastName"}},
+		{expression: `user.getInternalProperty("id")`},
+		{expression: `"user.email" + appuser.email`, expected: []string{"appuser.email"}},
+		{expression: `source.user.email`},
+		{expression: `user.`, wantErr: true},
+		{expression: `"user.email`, wantErr: true},
+	}
+	for _, test := range tests {
+		refs, err := profileAttributeReferences(test.expression)
+		if test.wantErr {
+			assert.Error(t, err, test.expression)
+			continue
+		}
+		require.NoError(t, err, test.expression)
+		var actual []string
+		for _, ref := range refs {
+			actual = append(actual, ref.String())
+		}
+		assert.Equal(t, test.expected, actual, test.expression)
+	}
+}
+
+func TestValidateMappingExpressions(t *testing.T) {
+	attributes := map[string]bool{"firstName": true, "email": true}
+	mappings := []interface{}{
+		map[string]interface{}{"id": "firstName", "expression": "appuser.firstName"},
+		map[string]interface{}{"id": "login", "expression": "appuser.emial"},
+		map[string]interface{}{"id": "nickName", "expression": "user.nickName"},
+	}
+	err := validateMappingExpressions(mappings, profileMappingSourceAppUser, attributes)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "mapping 'login'")
+	assert.Contains(t, err.Error(), "appuser.emial")
+	assert.NotContains(t, err.Error(), "nickName")
+
+	assert.NoError(t, validateMappingExpressions(mappings[:1], profileMappingSourceAppUser, attributes))
+}
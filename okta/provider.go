@@ -21,6 +21,7 @@ const (
 	adminRoleCustomAssignments    = "okta_admin_role_custom_assignments"
 	adminRoleTargets              = "okta_admin_role_targets"
 	app                           = "okta_app"
+	appAssignments                = "okta_app_assignments"
 	appAutoLogin                  = "okta_app_auto_login"
 	appBasicAuth                  = "okta_app_basic_auth"
 	appBookmark                   = "okta_app_bookmark"
@@ -29,8 +30,12 @@ const (
 	appMetadataSaml               = "okta_app_metadata_saml"
 	appOAuth                      = "okta_app_oauth"
 	appOAuthAPIScope              = "okta_app_oauth_api_scope"
+	appOAuthGrants                = "okta_app_oauth_grants"
 	appOAuthPostLogoutRedirectURI = "okta_app_oauth_post_logout_redirect_uri"
 	appOAuthRedirectURI           = "okta_app_oauth_redirect_uri"
+	appOAuthScopeConsentGrant     = "okta_app_oauth_scope_consent_grant"
+	appOAuthTokenRevocation       = "okta_app_oauth_token_revocation"
+	apps                          = "okta_apps"
 	appSaml                       = "okta_app_saml"
 	appSamlAppSettings            = "okta_app_saml_app_settings"
 	appSecurePasswordStore        = "okta_app_secure_password_store"
@@ -44,11 +49,15 @@ const (
 	appUserBaseSchemaProperty     = "okta_app_user_base_schema_property"
 	appUserSchemaProperty         = "okta_app_user_schema_property"
 	authenticator                 = "okta_authenticator"
+	authenticatorEnrollmentPolicy = "okta_authenticator_enrollment_policy"
+	authenticatorEnrollmentRule   = "okta_authenticator_enrollment_policy_rule"
 	authServer                    = "okta_auth_server"
 	authServerClaim               = "okta_auth_server_claim"
 	authServerClaimDefault        = "okta_auth_server_claim_default"
 	authServerClaims              = "okta_auth_server_claims"
+	authServerClaimsPreview       = "okta_auth_server_claims_preview"
 	authServerDefault             = "okta_auth_server_default"
+	authServerKeyRotation         = "okta_auth_server_key_rotation"
 	authServerPolicy              = "okta_auth_server_policy"
 	authServerPolicyRule          = "okta_auth_server_policy_rule"
 	authServerScope               = "okta_auth_server_scope"
@@ -60,6 +69,7 @@ const (
 	captcha                       = "okta_captcha"
 	captchaOrgWideSettings        = "okta_captcha_org_wide_settings"
 	defaultPolicy                 = "okta_default_policy"
+	deviceAssurancePolicy         = "okta_device_assurance_policy"
 	domain                        = "okta_domain"
 	domainCertificate             = "okta_domain_certificate"
 	domainVerification            = "okta_domain_verification"
@@ -72,9 +82,12 @@ const (
 	emailTemplate                 = "okta_email_template"
 	emailTemplates                = "okta_email_templates"
 	eventHook                     = "okta_event_hook"
+	eventHookEventTypes           = "okta_event_hook_event_types"
 	eventHookVerification         = "okta_event_hook_verification"
 	factor                        = "okta_factor"
 	factorTotp                    = "okta_factor_totp"
+	globalSessionPolicy           = "okta_global_session_policy"
+	globalSessionPolicyRule       = "okta_global_session_policy_rule"
 	group                         = "okta_group"
 	groupEveryone                 = "okta_everyone_group"
 	groupMemberships              = "okta_group_memberships"
@@ -87,6 +100,8 @@ const (
 	idpSaml                       = "okta_idp_saml"
 	idpSamlKey                    = "okta_idp_saml_key"
 	idpSocial                     = "okta_idp_social"
+	idpUserLink                   = "okta_idp_user_link"
+	idpUsers                      = "okta_idp_users"
 	inlineHook                    = "okta_inline_hook"
 	linkDefinition                = "okta_link_definition"
 	linkValue                     = "okta_link_value"
@@ -94,14 +109,20 @@ const (
 	orgConfiguration              = "okta_org_configuration"
 	orgSupport                    = "okta_org_support"
 	policy                        = "okta_policy"
+	policyEvaluation              = "okta_policy_evaluation"
+	policyExport                  = "okta_policy_export"
 	policyMfa                     = "okta_policy_mfa"
 	policyMfaDefault              = "okta_policy_mfa_default"
 	policyPassword                = "okta_policy_password"
 	policyPasswordDefault         = "okta_policy_password_default"
 	policyProfileEnrollment       = "okta_policy_profile_enrollment"
 	policyProfileEnrollmentApps   = "okta_policy_profile_enrollment_apps"
+	policyRestore                 = "okta_policy_restore"
+	policyRule                    = "okta_policy_rule"
 	policyRuleIdpDiscovery        = "okta_policy_rule_idp_discovery"
+	policyRuleIdpRouting          = "okta_policy_rule_idp_routing"
 	policyRuleMfa                 = "okta_policy_rule_mfa"
+	policyRuleOrder               = "okta_policy_rule_order"
 	policyRulePassword            = "okta_policy_rule_password"
 	policyRuleProfileEnrollment   = "okta_policy_rule_profile_enrollment"
 	policyRuleSignOn              = "okta_policy_rule_signon"
@@ -254,6 +275,7 @@ func Provider() *schema.Provider {
 			adminRoleCustom:               resourceAdminRoleCustom(),
 			adminRoleCustomAssignments:    resourceAdminRoleCustomAssignments(),
 			adminRoleTargets:              resourceAdminRoleTargets(),
+			appAssignments:                resourceAppAssignments(),
 			appAutoLogin:                  resourceAppAutoLogin(),
 			appBasicAuth:                  resourceAppBasicAuth(),
 			appBookmark:                   resourceAppBookmark(),
@@ -263,6 +285,8 @@ func Provider() *schema.Provider {
 			appOAuthAPIScope:              resourceAppOAuthAPIScope(),
 			appOAuthPostLogoutRedirectURI: resourceAppOAuthPostLogoutRedirectURI(),
 			appOAuthRedirectURI:           resourceAppOAuthRedirectURI(),
+			appOAuthScopeConsentGrant:     resourceAppOAuthScopeConsentGrant(),
+			appOAuthTokenRevocation:       resourceAppOAuthTokenRevocation(),
 			appSaml:                       resourceAppSaml(),
 			appSamlAppSettings:            resourceAppSamlAppSettings(),
 			appSecurePasswordStore:        resourceAppSecurePasswordStore(),
@@ -275,10 +299,13 @@ func Provider() *schema.Provider {
 			appUserBaseSchemaProperty:     resourceAppUserBaseSchemaProperty(),
 			appUserSchemaProperty:         resourceAppUserSchemaProperty(),
 			authenticator:                 resourceAuthenticator(),
+			authenticatorEnrollmentPolicy: resourceAuthenticatorEnrollmentPolicy(),
+			authenticatorEnrollmentRule:   resourceAuthenticatorEnrollmentPolicyRule(),
 			authServer:                    resourceAuthServer(),
 			authServerClaim:               resourceAuthServerClaim(),
 			authServerClaimDefault:        resourceAuthServerClaimDefault(),
 			authServerDefault:             resourceAuthServerDefault(),
+			authServerKeyRotation:         resourceAuthServerKeyRotation(),
 			authServerPolicy:              resourceAuthServerPolicy(),
 			authServerPolicyRule:          resourceAuthServerPolicyRule(),
 			authServerScope:               resourceAuthServerScope(),
@@ -286,6 +313,7 @@ func Provider() *schema.Provider {
 			brand:                         resourceBrand(),
 			captcha:                       resourceCaptcha(),
 			captchaOrgWideSettings:        resourceCaptchaOrgWideSettings(),
+			deviceAssurancePolicy:         resourceDeviceAssurancePolicy(),
 			domain:                        resourceDomain(),
 			domainCertificate:             resourceDomainCertificate(),
 			domainVerification:            resourceDomainVerification(),
@@ -298,6 +326,8 @@ func Provider() *schema.Provider {
 			eventHookVerification:         resourceEventHookVerification(),
 			factor:                        resourceFactor(),
 			factorTotp:                    resourceFactorTOTP(),
+			globalSessionPolicy:           resourceGlobalSessionPolicy(),
+			globalSessionPolicyRule:       resourceGlobalSessionPolicyRule(),
 			group:                         resourceGroup(),
 			groupMemberships:              resourceGroupMemberships(),
 			groupRole:                     resourceGroupRole(),
@@ -307,6 +337,7 @@ func Provider() *schema.Provider {
 			idpSaml:                       resourceIdpSaml(),
 			idpSamlKey:                    resourceIdpSigningKey(),
 			idpSocial:                     resourceIdpSocial(),
+			idpUserLink:                   resourceIdpUserLink(),
 			inlineHook:                    resourceInlineHook(),
 			linkDefinition:                resourceLinkDefinition(),
 			linkValue:                     resourceLinkValue(),
@@ -319,8 +350,12 @@ func Provider() *schema.Provider {
 			policyPasswordDefault:         resourcePolicyPasswordDefault(),
 			policyProfileEnrollment:       resourcePolicyProfileEnrollment(),
 			policyProfileEnrollmentApps:   resourcePolicyProfileEnrollmentApps(),
+			policyRestore:                 resourcePolicyRestore(),
+			policyRule:                    resourcePolicyRule(),
 			policyRuleIdpDiscovery:        resourcePolicyRuleIdpDiscovery(),
+			policyRuleIdpRouting:          resourcePolicyRuleIdpRouting(),
 			policyRuleMfa:                 resourcePolicyMfaRule(),
+			policyRuleOrder:               resourcePolicyRuleOrder(),
 			policyRulePassword:            resourcePolicyPasswordRule(),
 			policyRuleProfileEnrollment:   resourcePolicyProfileEnrollmentRule(),
 			policyRuleSignOn:              resourcePolicySignOnRule(),
@@ -347,6 +382,8 @@ func Provider() *schema.Provider {
 			appGroupAssignments:      dataSourceAppGroupAssignments(),
 			appMetadataSaml:          dataSourceAppMetadataSaml(),
 			appOAuth:                 dataSourceAppOauth(),
+			appOAuthGrants:           dataSourceAppOAuthGrants(),
+			apps:                     dataSourceApps(),
 			appSaml:                  dataSourceAppSaml(),
 			appSignOnPolicy:          dataSourceAppSignOnPolicy(),
 			appUserAssignments:       dataSourceAppUserAssignments(),
@@ -354,6 +391,7 @@ func Provider() *schema.Provider {
 			authServer:               dataSourceAuthServer(),
 			authServerClaim:          dataSourceAuthServerClaim(),
 			authServerClaims:         dataSourceAuthServerClaims(),
+			authServerClaimsPreview:  dataSourceAuthServerClaimsPreview(),
 			authServerPolicy:         dataSourceAuthServerPolicy(),
 			authServerScopes:         dataSourceAuthServerScopes(),
 			behavior:                 dataSourceBehavior(),
@@ -365,6 +403,7 @@ func Provider() *schema.Provider {
 			emailCustomizations:      dataSourceEmailCustomizations(),
 			emailTemplate:            dataSourceEmailTemplate(),
 			emailTemplates:           dataSourceEmailTemplates(),
+			eventHookEventTypes:      dataSourceEventHookEventTypes(),
 			defaultPolicy:            dataSourceDefaultPolicy(),
 			group:                    dataSourceGroup(),
 			groupEveryone:            dataSourceEveryoneGroup(),
@@ -374,8 +413,11 @@ func Provider() *schema.Provider {
 			idpOidc:                  dataSourceIdpOidc(),
 			idpSaml:                  dataSourceIdpSaml(),
 			idpSocial:                dataSourceIdpSocial(),
+			idpUsers:                 dataSourceIdpUsers(),
 			networkZone:              dataSourceNetworkZone(),
 			policy:                   dataSourcePolicy(),
+			policyEvaluation:         dataSourcePolicyEvaluation(),
+			policyExport:             dataSourcePolicyExport(),
 			roleSubscription:         dataSourceRoleSubscription(),
 			theme:                    dataSourceTheme(),
 			themes:                   dataSourceThemes(),
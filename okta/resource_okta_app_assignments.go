@@ -0,0 +1,374 @@
+package okta
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/okta/terraform-provider-okta/sdk"
+)
+
+func resourceAppAssignments() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceAppAssignmentsCreate,
+		ReadContext:   resourceAppAssignmentsRead,
+		UpdateContext: resourceAppAssignmentsUpdate,
+		DeleteContext: resourceAppAssignmentsDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+				_ = d.Set("app_id", d.Id())
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+		Schema: map[string]*schema.Schema{
+			"app_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "App to manage the user and group assignments of",
+			},
+			"user": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "A user directly assigned to the application",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "User associated with the application",
+						},
+						"username": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Username of the user in the application",
+						},
+						"profile": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							Default:          "{}",
+							ValidateDiagFunc: stringIsJSON,
+							Description:      "JSON document of the app user profile attributes to manage",
+						},
+					},
+				},
+			},
+			"group": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "A group assigned to the application",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Group associated with the application",
+						},
+						"priority": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Description: "Priority of the group assignment",
+						},
+						"profile": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							Default:          "{}",
+							ValidateDiagFunc: stringIsJSON,
+							Description:      "JSON document of the group assignment profile attributes to manage",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceAppAssignmentsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// okta_app_assignments completely control all direct assignments for an application
+	d.SetId(d.Get("app_id").(string))
+	err := reconcileAppAssignments(ctx, d, m)
+	if err != nil {
+		return diag.Errorf("failed to create application assignments: %v", err)
+	}
+	return resourceAppAssignmentsRead(ctx, d, m)
+}
+
+func resourceAppAssignmentsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := getOktaClientFromMetadata(m)
+	appID := d.Get("app_id").(string)
+	groups, resp, err := listApplicationGroupAssignments(ctx, client, appID)
+	if is404(resp) {
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.Errorf("failed to fetch group assignments: %v", err)
+	}
+	users, _, err := listApplicationUsers(ctx, client, appID)
+	if err != nil {
+		return diag.Errorf("failed to fetch user assignments: %v", err)
+	}
+	configuredUsers := appAssignmentsByID(d.Get("user").(*schema.Set))
+	var arrUsers []interface{}
+	for _, user := range users {
+		if user.Scope != userScope {
+			continue
+		}
+		elem := map[string]interface{}{
+			"id":       user.Id,
+			"username": "",
+			"profile":  "{}",
+		}
+		if c, ok := configuredUsers[user.Id]; ok {
+			elem["profile"] = configuredProfileSubset(user.Profile, c["profile"].(string))
+			if c["username"].(string) != "" && user.Credentials != nil {
+				elem["username"] = user.Credentials.UserName
+			}
+		}
+		arrUsers = append(arrUsers, elem)
+	}
+	configuredGroups := appAssignmentsByID(d.Get("group").(*schema.Set))
+	var arrGroups []interface{}
+	for _, group := range groups {
+		elem := map[string]interface{}{
+			"id":       group.Id,
+			"priority": 0,
+			"profile":  "{}",
+		}
+		if c, ok := configuredGroups[group.Id]; ok {
+			elem["profile"] = configuredProfileSubset(group.Profile, c["profile"].(string))
+			if c["priority"].(int) != 0 && group.PriorityPtr != nil {
+				elem["priority"] = int(*group.PriorityPtr)
+			}
+		}
+		arrGroups = append(arrGroups, elem)
+	}
+	err = setNonPrimitives(d, map[string]interface{}{
+		"user":  schema.NewSet(d.Get("user").(*schema.Set).F, arrUsers),
+		"group": schema.NewSet(d.Get("group").(*schema.Set).F, arrGroups),
+	})
+	if err != nil {
+		return diag.Errorf("failed to set application assignments: %v", err)
+	}
+	return nil
+}
+
+func resourceAppAssignmentsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	err := reconcileAppAssignments(ctx, d, m)
+	if err != nil {
+		return diag.Errorf("failed to update application assignments: %v", err)
+	}
+	return resourceAppAssignmentsRead(ctx, d, m)
+}
+
+func resourceAppAssignmentsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := getOktaClientFromMetadata(m)
+	appID := d.Get("app_id").(string)
+	var tasks []func() error
+	for id := range appAssignmentsByID(d.Get("user").(*schema.Set)) {
+		userID := id
+		tasks = append(tasks, func() error {
+			resp, err := client.Application.DeleteApplicationUser(ctx, appID, userID, nil)
+			if err := suppressErrorOn404(resp, err); err != nil {
+				return fmt.Errorf("could not delete assignment for user %s, to application %s: %w", userID, appID, err)
+			}
+			return nil
+		})
+	}
+	for id := range appAssignmentsByID(d.Get("group").(*schema.Set)) {
+		groupID := id
+		tasks = append(tasks, func() error {
+			resp, err := client.Application.DeleteApplicationGroupAssignment(ctx, appID, groupID)
+			if err := suppressErrorOn404(resp, err); err != nil {
+				return fmt.Errorf("could not delete assignment for group %s, to application %s: %w", groupID, appID, err)
+			}
+			return nil
+		})
+	}
+	if err := runConcurrently(getParallelism(m), tasks); err != nil {
+		return diag.Errorf("failed to delete application assignments: %v", err)
+	}
+	return nil
+}
+
+// reconcileAppAssignments diffs the configured assignments against the ones
+// in Okta and concurrently applies only the assignments that changed.
+func reconcileAppAssignments(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	client := getOktaClientFromMetadata(m)
+	appID := d.Get("app_id").(string)
+	existingGroups, _, err := listApplicationGroupAssignments(ctx, client, appID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch group assignments: %v", err)
+	}
+	existingUsers, _, err := listApplicationUsers(ctx, client, appID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch user assignments: %v", err)
+	}
+	var tasks []func() error
+
+	desiredUsers := appAssignmentsByID(d.Get("user").(*schema.Set))
+	currentUsers := make(map[string]*sdk.AppUser)
+	for _, user := range existingUsers {
+		if user.Scope == userScope {
+			currentUsers[user.Id] = user
+		}
+	}
+	for id, raw := range desiredUsers {
+		desired := tfAppAssignmentToAppUser(id, raw)
+		current, ok := currentUsers[id]
+		switch {
+		case !ok:
+			tasks = append(tasks, func() error {
+				_, _, err := client.Application.AssignUserToApplication(ctx, appID, *desired)
+				if err != nil {
+					return fmt.Errorf("could not assign user %s to application %s: %w", desired.Id, appID, err)
+				}
+				return nil
+			})
+		case !appUserMatches(current, desired):
+			tasks = append(tasks, func() error {
+				_, _, err := client.Application.UpdateApplicationUser(ctx, appID, desired.Id, *desired)
+				if err != nil {
+					return fmt.Errorf("could not update assignment for user %s to application %s: %w", desired.Id, appID, err)
+				}
+				return nil
+			})
+		}
+	}
+	for id := range currentUsers {
+		if _, ok := desiredUsers[id]; ok {
+			continue
+		}
+		userID := id
+		tasks = append(tasks, func() error {
+			resp, err := client.Application.DeleteApplicationUser(ctx, appID, userID, nil)
+			if err := suppressErrorOn404(resp, err); err != nil {
+				return fmt.Errorf("could not delete assignment for user %s, to application %s: %w", userID, appID, err)
+			}
+			return nil
+		})
+	}
+
+	desiredGroups := appAssignmentsByID(d.Get("group").(*schema.Set))
+	currentGroups := make(map[string]*sdk.ApplicationGroupAssignment)
+	for _, group := range existingGroups {
+		currentGroups[group.Id] = group
+	}
+	for id, raw := range desiredGroups {
+		desired := tfAppAssignmentToGroupAssignment(id, raw)
+		if current, ok := currentGroups[id]; ok && groupAssignmentMatches(current, desired) {
+			continue
+		}
+		tasks = append(tasks, func() error {
+			_, _, err := client.Application.CreateApplicationGroupAssignment(ctx, appID, desired.Id, *desired)
+			if err != nil {
+				return fmt.Errorf("could not assign group %s to application %s: %w", desired.Id, appID, err)
+			}
+			return nil
+		})
+	}
+	for id := range currentGroups {
+		if _, ok := desiredGroups[id]; ok {
+			continue
+		}
+		groupID := id
+		tasks = append(tasks, func() error {
+			resp, err := client.Application.DeleteApplicationGroupAssignment(ctx, appID, groupID)
+			if err := suppressErrorOn404(resp, err); err != nil {
+				return fmt.Errorf("could not delete assignment for group %s, to application %s: %w", groupID, appID, err)
+			}
+			return nil
+		})
+	}
+
+	logger(m).Info("reconciling application assignments", "app_id", appID, "changes", len(tasks))
+	return runConcurrently(getParallelism(m), tasks)
+}
+
+func appAssignmentsByID(set *schema.Set) map[string]map[string]interface{} {
+	result := make(map[string]map[string]interface{}, set.Len())
+	for _, raw := range set.List() {
+		elem := raw.(map[string]interface{})
+		result[elem["id"].(string)] = elem
+	}
+	return result
+}
+
+func tfAppAssignmentToAppUser(id string, raw map[string]interface{}) *sdk.AppUser {
+	var profile interface{}
+	// JSON is already validated
+	_ = json.Unmarshal([]byte(raw["profile"].(string)), &profile)
+	user := &sdk.AppUser{
+		Id:      id,
+		Scope:   userScope,
+		Profile: profile,
+	}
+	if username := raw["username"].(string); username != "" {
+		user.Credentials = &sdk.AppUserCredentials{UserName: username}
+	}
+	return user
+}
+
+func tfAppAssignmentToGroupAssignment(id string, raw map[string]interface{}) *sdk.ApplicationGroupAssignment {
+	var profile interface{}
+	// JSON is already validated
+	_ = json.Unmarshal([]byte(raw["profile"].(string)), &profile)
+	assignment := &sdk.ApplicationGroupAssignment{
+		Id:      id,
+		Profile: profile,
+	}
+	if priority := raw["priority"].(int); priority != 0 {
+		assignment.PriorityPtr = int64Ptr(priority)
+	}
+	return assignment
+}
+
+func appUserMatches(current, desired *sdk.AppUser) bool {
+	if desired.Credentials != nil && (current.Credentials == nil || current.Credentials.UserName != desired.Credentials.UserName) {
+		return false
+	}
+	return profileContains(current.Profile, desired.Profile)
+}
+
+func groupAssignmentMatches(current, desired *sdk.ApplicationGroupAssignment) bool {
+	if desired.PriorityPtr != nil && (current.PriorityPtr == nil || *current.PriorityPtr != *desired.PriorityPtr) {
+		return false
+	}
+	return profileContains(current.Profile, desired.Profile)
+}
+
+// profileContains reports whether every attribute of the desired profile has
+// the same value in the actual profile.
+func profileContains(actual, desired interface{}) bool {
+	dm, _ := desired.(map[string]interface{})
+	if len(dm) == 0 {
+		return true
+	}
+	am, _ := actual.(map[string]interface{})
+	for k, v := range dm {
+		if !reflect.DeepEqual(am[k], v) {
+			return false
+		}
+	}
+	return true
+}
+
+// configuredProfileSubset returns the actual profile restricted to the
+// attributes present in the configured profile, so attributes Okta fills in
+// on its own don't show up as drift.
+func configuredProfileSubset(actual interface{}, configured string) string {
+	cm := make(map[string]interface{})
+	_ = json.Unmarshal([]byte(configured), &cm)
+	am, _ := actual.(map[string]interface{})
+	result := make(map[string]interface{}, len(cm))
+	for k := range cm {
+		if v, ok := am[k]; ok && v != nil {
+			result[k] = v
+		}
+	}
+	p, _ := json.Marshal(result)
+	return string(p)
+}
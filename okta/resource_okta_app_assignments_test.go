@@ -0,0 +1,54 @@
+package okta
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccAppAssignments_crud(t *testing.T) {
+	resourceName := fmt.Sprintf("%s.test", appAssignments)
+	mgr := newFixtureManager(appAssignments, t.Name())
+	config := mgr.GetFixtures("basic.tf", t)
+	updatedConfig := mgr.GetFixtures("updated.tf", t)
+
+	oktaResourceTest(t, resource.TestCase{
+		PreCheck:          testAccPreCheck(t),
+		ErrorCheck:        testAccErrorChecks(t),
+		ProviderFactories: testAccProvidersFactories,
+		CheckDestroy:      checkUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "app_id"),
+					resource.TestCheckResourceAttr(resourceName, "user.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "group.#", "2"),
+				),
+			},
+			{
+				Config: updatedConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "user.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "user.*", map[string]string{
+						"username": fmt.Sprintf("testAcc_%d_renamed@example.com", mgr.Seed),
+					}),
+					resource.TestCheckResourceAttr(resourceName, "group.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "group.*", map[string]string{
+						"priority": "1",
+					}),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				// Only the IDs of the assignments are read on import, the
+				// username, priority and profile are read for the configured
+				// assignments.
+				ImportStateVerifyIgnore: []string{"user.0.username", "user.0.profile", "group.0.priority", "group.0.profile"},
+			},
+		},
+	})
+}
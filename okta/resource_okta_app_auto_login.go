@@ -119,7 +119,7 @@ func resourceAppAutoLoginRead(ctx context.Context, d *schema.ResourceData, m int
 	_ = d.Set("user_name_template_type", app.Credentials.UserNameTemplate.Type)
 	_ = d.Set("user_name_template_suffix", app.Credentials.UserNameTemplate.Suffix)
 	_ = d.Set("user_name_template_push_status", app.Credentials.UserNameTemplate.PushStatus)
-	_ = d.Set("logo_url", linksValue(app.Links, "logo", "href"))
+	syncAppLogo(ctx, d, m, app.Links)
 	appRead(d, app.Name, app.Status, app.SignOnMode, app.Label, app.Accessibility, app.Visibility, app.Settings.Notes)
 	return nil
 }
@@ -135,11 +135,10 @@ func resourceAppAutoLoginUpdate(ctx context.Context, d *schema.ResourceData, m i
 	if err != nil {
 		return diag.Errorf("failed to set auto login application status: %v", err)
 	}
-	if d.HasChange("logo") {
+	if logoAttributes.hasChange(d) {
 		err = handleAppLogo(ctx, d, m, app.Id, app.Links)
 		if err != nil {
-			o, _ := d.GetChange("logo")
-			_ = d.Set("logo", o)
+			logoAttributes.rollback(d)
 			return diag.Errorf("failed to upload logo for auto login application: %v", err)
 		}
 	}
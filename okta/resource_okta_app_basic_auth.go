@@ -69,7 +69,7 @@ func resourceAppBasicAuthRead(ctx context.Context, d *schema.ResourceData, m int
 	_ = d.Set("url", app.Settings.App.Url)
 	_ = d.Set("auth_url", app.Settings.App.AuthURL)
 	appRead(d, app.Name, app.Status, app.SignOnMode, app.Label, app.Accessibility, app.Visibility, app.Settings.Notes)
-	_ = d.Set("logo_url", linksValue(app.Links, "logo", "href"))
+	syncAppLogo(ctx, d, m, app.Links)
 	return nil
 }
 
@@ -84,11 +84,10 @@ func resourceAppBasicAuthUpdate(ctx context.Context, d *schema.ResourceData, m i
 	if err != nil {
 		return diag.Errorf("failed to set basic auth application status: %v", err)
 	}
-	if d.HasChange("logo") {
+	if logoAttributes.hasChange(d) {
 		err = handleAppLogo(ctx, d, m, app.Id, app.Links)
 		if err != nil {
-			o, _ := d.GetChange("logo")
-			_ = d.Set("logo", o)
+			logoAttributes.rollback(d)
 			return diag.Errorf("failed to upload logo for basic auth application: %v", err)
 		}
 	}
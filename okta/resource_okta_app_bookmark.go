@@ -80,7 +80,7 @@ func resourceAppBookmarkRead(ctx context.Context, d *schema.ResourceData, m inte
 	_ = d.Set("url", app.Settings.App.Url)
 	_ = d.Set("request_integration", app.Settings.App.RequestIntegration)
 	appRead(d, app.Name, app.Status, app.SignOnMode, app.Label, app.Accessibility, app.Visibility, app.Settings.Notes)
-	_ = d.Set("logo_url", linksValue(app.Links, "logo", "href"))
+	syncAppLogo(ctx, d, m, app.Links)
 	return nil
 }
 
@@ -95,11 +95,10 @@ func resourceAppBookmarkUpdate(ctx context.Context, d *schema.ResourceData, m in
 	if err != nil {
 		return diag.Errorf("failed to set bookmark application status: %v", err)
 	}
-	if d.HasChange("logo") {
+	if logoAttributes.hasChange(d) {
 		err = handleAppLogo(ctx, d, m, app.Id, app.Links)
 		if err != nil {
-			o, _ := d.GetChange("logo")
-			_ = d.Set("logo", o)
+			logoAttributes.rollback(d)
 			return diag.Errorf("failed to upload logo for bookmark application: %v", err)
 		}
 	}
@@ -507,7 +507,7 @@ func resourceAppOAuthRead(ctx context.Context, d *schema.ResourceData, m interfa
 	if err != nil {
 		return diag.Errorf("failed to set OAuth application settings: %v", err)
 	}
-	_ = d.Set("logo_url", linksValue(app.Links, "logo", "href"))
+	syncAppLogo(ctx, d, m, app.Links)
 	if app.Settings.ImplicitAssignment != nil {
 		_ = d.Set("implicit_assignment", *app.Settings.ImplicitAssignment)
 	} else {
@@ -636,11 +636,10 @@ func resourceAppOAuthUpdate(ctx context.Context, d *schema.ResourceData, m inter
 	if err != nil {
 		return diag.Errorf("failed to set OAuth application status: %v", err)
 	}
-	if d.HasChange("logo") {
+	if logoAttributes.hasChange(d) {
 		err = handleAppLogo(ctx, d, m, app.Id, app.Links)
 		if err != nil {
-			o, _ := d.GetChange("logo")
-			_ = d.Set("logo", o)
+			logoAttributes.rollback(d)
 			return diag.Errorf("failed to upload logo for OAuth application: %v", err)
 		}
 	}
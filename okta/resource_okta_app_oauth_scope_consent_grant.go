@@ -0,0 +1,104 @@
+package okta
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/okta/terraform-provider-okta/sdk"
+)
+
+func resourceAppOAuthScopeConsentGrant() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceAppOAuthScopeConsentGrantCreate,
+		ReadContext:   resourceAppOAuthScopeConsentGrantRead,
+		DeleteContext: resourceAppOAuthScopeConsentGrantDelete,
+		Importer:      createNestedResourceImporter([]string{"app_id", "id"}),
+		Schema: map[string]*schema.Schema{
+			"app_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the OAuth application",
+			},
+			"scope": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: oktaScopeIsValid,
+				Description:      "Name of the Okta scope the admin consents to on behalf of the users of the application, e.g. okta.users.read",
+			},
+			"issuer": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "Issuer of the Org Authorization Server, your Org URL. Defaults to the Org URL of the provider",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Status of the grant",
+			},
+			"source": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "How the consent was given, ADMIN for the grants of this resource",
+			},
+			"created_by": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the user or client which created the grant",
+			},
+		},
+	}
+}
+
+// resourceAppOAuthScopeConsentGrantCreate grants an Okta scope, the issuer of
+// the grants of an application is the Org Authorization Server. The consent
+// to the scopes of custom authorization servers is not granted this way, see
+// the consent_method of okta_app_oauth.
+func resourceAppOAuthScopeConsentGrantCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := getOktaClientFromMetadata(m)
+	issuer, ok := d.GetOk("issuer")
+	if !ok {
+		issuer = strings.TrimSuffix(client.GetConfig().Okta.Client.OrgUrl, "/")
+	}
+	grant, _, err := client.Application.GrantConsentToScope(ctx, d.Get("app_id").(string), sdk.OAuth2ScopeConsentGrant{
+		Issuer:  issuer.(string),
+		ScopeId: d.Get("scope").(string),
+	})
+	if err != nil {
+		return diag.Errorf("failed to create application scope consent grant: %v", err)
+	}
+	d.SetId(grant.Id)
+	return resourceAppOAuthScopeConsentGrantRead(ctx, d, m)
+}
+
+func resourceAppOAuthScopeConsentGrantRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	grant, resp, err := getOktaClientFromMetadata(m).Application.GetScopeConsentGrant(ctx, d.Get("app_id").(string), d.Id(), nil)
+	if err := suppressErrorOn404(resp, err); err != nil {
+		return diag.Errorf("failed to get application scope consent grant: %v", err)
+	}
+	if grant == nil || grant.Status == "REVOKED" {
+		d.SetId("")
+		return nil
+	}
+	_ = d.Set("scope", grant.ScopeId)
+	_ = d.Set("issuer", grant.Issuer)
+	_ = d.Set("status", grant.Status)
+	_ = d.Set("source", grant.Source)
+	if grant.CreatedBy != nil {
+		_ = d.Set("created_by", grant.CreatedBy.Id)
+	}
+	return nil
+}
+
+func resourceAppOAuthScopeConsentGrantDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	resp, err := getOktaClientFromMetadata(m).Application.RevokeScopeConsentGrant(ctx, d.Get("app_id").(string), d.Id())
+	if err := suppressErrorOn404(resp, err); err != nil {
+		return diag.Errorf("failed to revoke application scope consent grant: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package okta
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/okta/terraform-provider-okta/sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccOktaAppOAuthScopeConsentGrant_crud(t *testing.T) {
+	resourceName := fmt.Sprintf("%s.test", appOAuthScopeConsentGrant)
+	mgr := newFixtureManager(appOAuthScopeConsentGrant, t.Name())
+	config := mgr.GetFixtures("basic.tf", t)
+
+	oktaResourceTest(t, resource.TestCase{
+		PreCheck:          testAccPreCheck(t),
+		ErrorCheck:        testAccErrorChecks(t),
+		ProviderFactories: testAccProvidersFactories,
+		CheckDestroy:      checkResourceDestroy(appOAuth, createDoesAppExist(sdk.NewOpenIdConnectApplication())),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "scope", "okta.users.read"),
+					resource.TestCheckResourceAttr(resourceName, "status", statusActive),
+					resource.TestCheckResourceAttr(resourceName, "source", "ADMIN"),
+					resource.TestCheckResourceAttrSet(resourceName, "issuer"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs := s.RootModule().Resources[resourceName]
+					return fmt.Sprintf("%s/%s", rs.Primary.Attributes["app_id"], rs.Primary.ID), nil
+				},
+			},
+		},
+	})
+}
+
+func TestAppOAuthScopeConsentGrantCreate(t *testing.T) {
+	var granted *sdk.OAuth2ScopeConsentGrant
+	var orgURL string
+	ctx, m := newTestConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		orgURL = "http://" + r.Host
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/apps/app1/grants":
+			granted = &sdk.OAuth2ScopeConsentGrant{}
+			_ = json.NewDecoder(r.Body).Decode(granted)
+			granted.Id, granted.Status, granted.Source = "oag1", statusActive, "ADMIN"
+			_ = json.NewEncoder(w).Encode(granted)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/apps/app1/grants/oag1":
+			_ = json.NewEncoder(w).Encode(granted)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	newGrant := func(raw map[string]interface{}) *schema.ResourceData {
+		return schema.TestResourceDataRaw(t, resourceAppOAuthScopeConsentGrant().Schema, raw)
+	}
+
+	d := newGrant(map[string]interface{}{"app_id": "app1", "scope": "okta.users.read"})
+	diags := resourceAppOAuthScopeConsentGrantCreate(ctx, d, m)
+	require.Empty(t, diags)
+	assert.Equal(t, "oag1", d.Id())
+	assert.Equal(t, orgURL, granted.Issuer)
+	assert.Equal(t, "okta.users.read", granted.ScopeId)
+	assert.Equal(t, orgURL, d.Get("issuer"))
+	assert.Equal(t, "ADMIN", d.Get("source"))
+
+	d = newGrant(map[string]interface{}{"app_id": "app1", "scope": "okta.groups.read", "issuer": "https://example.okta.com"})
+	diags = resourceAppOAuthScopeConsentGrantCreate(ctx, d, m)
+	require.Empty(t, diags)
+	assert.Equal(t, "https://example.okta.com", granted.Issuer)
+}
+
+func TestOktaScopeIsValid(t *testing.T) {
+	path := cty.GetAttrPath("scope")
+	assert.Empty(t, oktaScopeIsValid("okta.users.read", path))
+	diags := oktaScopeIsValid("orders:read", path)
+	require.Len(t, diags, 1)
+	assert.Contains(t, diags[0].Summary, "custom authorization servers")
+}
@@ -0,0 +1,82 @@
+package okta
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/okta/terraform-provider-okta/sdk"
+)
+
+func resourceAppOAuthTokenRevocation() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceAppOAuthTokenRevocationCreate,
+		ReadContext:   resourceFuncNoOp,
+		DeleteContext: resourceFuncNoOp,
+		Schema: map[string]*schema.Schema{
+			"app_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the OAuth application",
+			},
+			"user_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Revoke the tokens issued to this user for the application instead of those of the application",
+			},
+			"token_ids": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "IDs of the refresh tokens to revoke, e.g. from the okta_app_oauth_grants data source. All the tokens are revoked when empty",
+			},
+			"triggers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Arbitrary values, the tokens are revoked again every time they change",
+			},
+		},
+	}
+}
+
+func resourceAppOAuthTokenRevocationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := getOktaClientFromMetadata(m)
+	appID := d.Get("app_id").(string)
+	userID := d.Get("user_id").(string)
+	tokenIDs := convertInterfaceToStringSetNullable(d.Get("token_ids"))
+	if len(tokenIDs) == 0 {
+		var err error
+		if userID != "" {
+			_, err = client.User.RevokeTokensForUserAndClient(ctx, userID, appID)
+		} else {
+			_, err = client.Application.RevokeOAuth2TokensForApplication(ctx, appID)
+		}
+		if err != nil {
+			return diag.Errorf("failed to revoke application tokens: %v", err)
+		}
+	}
+	for _, tokenID := range tokenIDs {
+		var resp *sdk.Response
+		var err error
+		if userID != "" {
+			resp, err = client.User.RevokeTokenForUserAndClient(ctx, userID, appID, tokenID)
+		} else {
+			resp, err = client.Application.RevokeOAuth2TokenForApplication(ctx, appID, tokenID)
+		}
+		// the token may have expired or have been revoked already
+		if err := suppressErrorOn404(resp, err); err != nil {
+			return diag.Errorf("failed to revoke application token '%s': %v", tokenID, err)
+		}
+	}
+	if userID != "" {
+		d.SetId(appID + "/" + userID)
+	} else {
+		d.SetId(appID)
+	}
+	return nil
+}
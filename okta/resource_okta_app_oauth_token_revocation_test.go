@@ -0,0 +1,79 @@
+package okta
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccOktaAppOAuthTokenRevocation_crud(t *testing.T) {
+	resourceName := fmt.Sprintf("%s.test", appOAuthTokenRevocation)
+	mgr := newFixtureManager(appOAuthTokenRevocation, t.Name())
+	config := mgr.GetFixtures("basic.tf", t)
+
+	oktaResourceTest(t, resource.TestCase{
+		PreCheck:          testAccPreCheck(t),
+		ErrorCheck:        testAccErrorChecks(t),
+		ProviderFactories: testAccProvidersFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceName, "id", "okta_app_oauth.test", "id"),
+					resource.TestCheckResourceAttr(resourceName, "token_ids.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAppOAuthTokenRevocationCreate(t *testing.T) {
+	var revoked []string
+	ctx, m := newTestConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		switch r.URL.Path {
+		case "/api/v1/apps/app1/tokens/oar404", "/api/v1/users/usr1/clients/app1/tokens/oar404":
+			w.WriteHeader(http.StatusNotFound)
+			return
+		case "/api/v1/apps/app1/tokens/oar500":
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		revoked = append(revoked, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	revoke := func(raw map[string]interface{}) (*schema.ResourceData, []string) {
+		revoked = nil
+		d := schema.TestResourceDataRaw(t, resourceAppOAuthTokenRevocation().Schema, raw)
+		require.Empty(t, resourceAppOAuthTokenRevocationCreate(ctx, d, m))
+		return d, revoked
+	}
+
+	d, paths := revoke(map[string]interface{}{"app_id": "app1"})
+	assert.Equal(t, "app1", d.Id())
+	assert.Equal(t, []string{"/api/v1/apps/app1/tokens"}, paths)
+
+	d, paths = revoke(map[string]interface{}{"app_id": "app1", "user_id": "usr1"})
+	assert.Equal(t, "app1/usr1", d.Id())
+	assert.Equal(t, []string{"/api/v1/users/usr1/clients/app1/tokens"}, paths)
+
+	_, paths = revoke(map[string]interface{}{"app_id": "app1", "token_ids": []interface{}{"oar1", "oar404"}})
+	assert.Equal(t, []string{"/api/v1/apps/app1/tokens/oar1"}, paths)
+
+	_, paths = revoke(map[string]interface{}{"app_id": "app1", "user_id": "usr1", "token_ids": []interface{}{"oar1", "oar404"}})
+	assert.Equal(t, []string{"/api/v1/users/usr1/clients/app1/tokens/oar1"}, paths)
+
+	d = schema.TestResourceDataRaw(t, resourceAppOAuthTokenRevocation().Schema, map[string]interface{}{"app_id": "app1", "token_ids": []interface{}{"oar500"}})
+	diags := resourceAppOAuthTokenRevocationCreate(ctx, d, m)
+	require.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Summary, "failed to revoke application token 'oar500'")
+	assert.Empty(t, d.Id())
+}
@@ -471,7 +471,7 @@ func resourceAppSamlRead(ctx context.Context, d *schema.ResourceData, m interfac
 	_ = d.Set("user_name_template_suffix", app.Credentials.UserNameTemplate.Suffix)
 	_ = d.Set("user_name_template_push_status", app.Credentials.UserNameTemplate.PushStatus)
 	_ = d.Set("preconfigured_app", app.Name)
-	_ = d.Set("logo_url", linksValue(app.Links, "logo", "href"))
+	syncAppLogo(ctx, d, m, app.Links)
 	_ = d.Set("embed_url", linksValue(app.Links, "appLinks", "href"))
 
 	if app.Settings.ImplicitAssignment != nil {
@@ -544,11 +544,10 @@ func resourceAppSamlUpdate(ctx context.Context, d *schema.ResourceData, m interf
 			return diag.Errorf("failed to create new certificate for SAML application: %v", err)
 		}
 	}
-	if d.HasChange("logo") {
+	if logoAttributes.hasChange(d) {
 		err = handleAppLogo(ctx, d, m, app.Id, app.Links)
 		if err != nil {
-			o, _ := d.GetChange("logo")
-			_ = d.Set("logo", o)
+			logoAttributes.rollback(d)
 			return diag.Errorf("failed to upload logo for SAML application: %v", err)
 		}
 	}
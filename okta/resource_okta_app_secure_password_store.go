@@ -141,6 +141,7 @@ func resourceAppSecurePasswordStoreRead(ctx context.Context, d *schema.ResourceD
 	_ = d.Set("user_name_template_type", app.Credentials.UserNameTemplate.Type)
 	_ = d.Set("user_name_template_suffix", app.Credentials.UserNameTemplate.Suffix)
 	_ = d.Set("user_name_template_push_status", app.Credentials.UserNameTemplate.PushStatus)
+	syncAppLogo(ctx, d, m, app.Links)
 	appRead(d, app.Name, app.Status, app.SignOnMode, app.Label, app.Accessibility, app.Visibility, app.Settings.Notes)
 	return nil
 }
@@ -156,11 +157,10 @@ func resourceAppSecurePasswordStoreUpdate(ctx context.Context, d *schema.Resourc
 	if err != nil {
 		return diag.Errorf("failed to set secure password store application status: %v", err)
 	}
-	if d.HasChange("logo") {
+	if logoAttributes.hasChange(d) {
 		err = handleAppLogo(ctx, d, m, app.Id, app.Links)
 		if err != nil {
-			o, _ := d.GetChange("logo")
-			_ = d.Set("logo", o)
+			logoAttributes.rollback(d)
 			return diag.Errorf("failed to upload logo for secure password store application: %v", err)
 		}
 	}
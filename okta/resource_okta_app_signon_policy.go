@@ -15,6 +15,13 @@ func resourceAppSignOnPolicy() *schema.Resource {
 		ReadContext:   resourceAppSignOnPolicyRead,
 		UpdateContext: resourceAppSignOnPolicyUpdate,
 		DeleteContext: resourceAppSignOnPolicyDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+				_ = d.Set("reassign_apps_on_destroy", true)
+				_ = d.Set("list_apps", false)
+				return []*schema.ResourceData{d}, nil
+			},
+		},
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:        schema.TypeString,
@@ -26,6 +33,24 @@ func resourceAppSignOnPolicy() *schema.Resource {
 				Required:    true,
 				Description: "Policy Description",
 			},
+			"reassign_apps_on_destroy": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Reassign the apps using this policy to the default access policy before it is destroyed",
+			},
+			"list_apps": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "List the apps using this policy in `apps` on every read, this lists every app in the org",
+			},
+			"apps": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "IDs of the apps using this policy as their authentication policy",
+			},
 		},
 	}
 }
@@ -74,6 +99,19 @@ func resourceAppSignOnPolicyRead(ctx context.Context, d *schema.ResourceData, m
 	d.SetId(policyFromServer.Id)
 	d.Set("name", policyFromServer.Name)
 	d.Set("description", policyFromServer.Description)
+	if !d.Get("list_apps").(bool) {
+		_ = d.Set("apps", nil)
+		return nil
+	}
+	apps, err := listAppsUsingAccessPolicy(ctx, getOktaClientFromMetadata(m), d.Id())
+	if err != nil {
+		return diag.Errorf("failed to list apps using authentication policy: %v", err)
+	}
+	appIDs := make([]interface{}, len(apps))
+	for i := range apps {
+		appIDs[i] = apps[i].Id
+	}
+	_ = d.Set("apps", schema.NewSet(schema.HashString, appIDs))
 	return nil
 }
 
@@ -91,45 +129,56 @@ func resourceAppSignOnPolicyUpdate(ctx context.Context, d *schema.ResourceData,
 	return resourceAppSignOnPolicyRead(ctx, d, m)
 }
 
-// resourceAppSignOnPolicyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 func resourceAppSignOnPolicyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	if isClassicOrg(ctx, m) {
 		return resourceOIEOnlyFeatureError(appSignOnPolicy)
 	}
 
-	// 1. find the default app policy
-	// 2. assign default policy to all apps whose authentication policy is the policy about to be deleted
-	// 3. delete the policy
-	defaultPolicy, err := findDefaultAccessPolicy(ctx, m)
-	if err != nil {
-		return diag.Errorf("Error finding default access policy: %v", err)
-	}
-
 	client := getOktaClientFromMetadata(m)
-	apps, err := listApps(ctx, client, nil, defaultPaginationLimit)
-	if err != nil {
-		return diag.Errorf("failed to list apps in preparation to delete authentication policy: %v", err)
-	}
-
-	// assign the default app policy to all clients using the current policy
-	for _, app := range apps {
-		accessPolicy := linksValue(app.Links, "accessPolicy", "href")
-		// ignore apps that don't have an access policy, typically Classic org apps.
-		if accessPolicy == "" {
-			continue
+	if d.Get("reassign_apps_on_destroy").(bool) {
+		apps, err := listAppsUsingAccessPolicy(ctx, client, d.Id())
+		if err != nil {
+			return diag.Errorf("failed to list apps in preparation to delete authentication policy: %v", err)
 		}
-		// app uses this policy as its access policy, change that back to using the default policy
-		if path.Base(accessPolicy) == d.Id() {
-			// update the app with the default policy, ignore errors
-			_, _ = client.Application.UpdateApplicationPolicy(ctx, app.Id, defaultPolicy.Id)
+		if len(apps) > 0 {
+			defaultPolicy, err := findDefaultAccessPolicy(ctx, m)
+			if err != nil {
+				return diag.Errorf("Error finding default access policy: %v", err)
+			}
+			// assign the default app policy to all apps using the current policy
+			for _, app := range apps {
+				logger(m).Info("reassigning app to the default authentication policy", "app_id", app.Id, "policy_id", defaultPolicy.Id)
+				_, err = client.Application.UpdateApplicationPolicy(ctx, app.Id, defaultPolicy.Id)
+				if err != nil {
+					return diag.Errorf("failed to assign default authentication policy to app '%s': %v", app.Id, err)
+				}
+			}
 		}
 	}
 
 	// delete will error out if the policy is still associated with apps
-	_, err = client.Policy.DeletePolicy(ctx, d.Id())
+	_, err := client.Policy.DeletePolicy(ctx, d.Id())
 	if err != nil {
 		return diag.Errorf("failed delete authentication policy: %v", err)
 	}
 
 	return nil
 }
+
+// listAppsUsingAccessPolicy returns the apps whose authentication policy is
+// the given access policy.
+func listAppsUsingAccessPolicy(ctx context.Context, client *sdk.Client, policyID string) ([]*sdk.Application, error) {
+	apps, err := listApps(ctx, client, nil, defaultPaginationLimit)
+	if err != nil {
+		return nil, err
+	}
+	var result []*sdk.Application
+	for _, app := range apps {
+		accessPolicy := linksValue(app.Links, "accessPolicy", "href")
+		// ignore apps that don't have an access policy, typically Classic org apps.
+		if accessPolicy != "" && path.Base(accessPolicy) == policyID {
+			result = append(result, app)
+		}
+	}
+	return result, nil
+}
@@ -10,6 +10,8 @@ import (
 	"github.com/okta/terraform-provider-okta/sdk"
 )
 
+var appSignOnPolicyRuleShadowing = policyRuleShadowing{"device_is_registered", "device_assurance_included", "custom_expression", "user_types_included", "user_types_excluded"}
+
 func resourceAppSignOnPolicyRule() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceAppSignOnPolicyRuleCreate,
@@ -17,7 +19,8 @@ func resourceAppSignOnPolicyRule() *schema.Resource {
 		UpdateContext: resourceAppSignOnPolicyRuleUpdate,
 		DeleteContext: resourceAppSignOnPolicyRuleDelete,
 		Importer:      createPolicyRuleImporter(),
-		Schema: map[string]*schema.Schema{
+		CustomizeDiff: appSignOnPolicyRuleShadowing.customizeDiff,
+		Schema: buildSchema(shadowingCheckSchema, map[string]*schema.Schema{
 			"name": {
 				Type:        schema.TypeString,
 				Required:    true,
@@ -111,15 +114,23 @@ func resourceAppSignOnPolicyRule() *schema.Resource {
 				RequiredWith: []string{"device_is_registered"},
 				Description:  "If the device is managed. A device is managed if it's managed by a device management system. When managed is passed, registered must also be included and must be set to true.",
 			},
+			"device_assurance_included": {
+				Type:         schema.TypeSet,
+				Optional:     true,
+				RequiredWith: []string{"device_is_registered"},
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				Description:  "List of device assurance policy IDs to include. The device has to satisfy one of them, registered must be set to true.",
+			},
 			"platform_include": {
 				Type:     schema.TypeSet,
 				Elem:     platformIncludeResource,
 				Optional: true,
 			},
 			"custom_expression": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "This is an optional advanced setting. If the expression is formatted incorrectly or conflicts with conditions set above, the rule may not match any users.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: expressionIsValid(),
+				Description:      "This is an optional advanced setting. If the expression is formatted incorrectly or conflicts with conditions set above, the rule may not match any users.",
 			},
 			"user_types_excluded": {
 				Type:        schema.TypeSet,
@@ -173,7 +184,7 @@ func resourceAppSignOnPolicyRule() *schema.Resource {
 				Optional:    true,
 				Description: "An array that contains nested Authenticator Constraint objects that are organized by the Authenticator class",
 			},
-		},
+		}),
 	}
 }
 
@@ -195,7 +206,7 @@ func resourceAppSignOnPolicyRuleCreate(ctx context.Context, d *schema.ResourceDa
 			}
 		}
 	}
-	return resourceAppSignOnPolicyRuleRead(ctx, d, m)
+	return append(resourceAppSignOnPolicyRuleRead(ctx, d, m), appSignOnPolicyRuleShadowing.warnings(ctx, d, m)...)
 }
 
 func resourceAppSignOnPolicyRuleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -248,6 +259,9 @@ func resourceAppSignOnPolicyRuleRead(ctx context.Context, d *schema.ResourceData
 		if rule.Conditions.Device != nil {
 			_ = d.Set("device_is_managed", rule.Conditions.Device.Managed)
 			_ = d.Set("device_is_registered", rule.Conditions.Device.Registered)
+			if rule.Conditions.Device.Assurance != nil {
+				m["device_assurance_included"] = convertStringSliceToSetNullable(rule.Conditions.Device.Assurance.Include)
+			}
 		}
 		if rule.Conditions.People != nil {
 			if rule.Conditions.People.Users != nil {
@@ -288,7 +302,7 @@ func resourceAppSignOnPolicyRuleUpdate(ctx context.Context, d *schema.ResourceDa
 			return diag.Errorf("failed to change app sign on policy rule status: %v", err)
 		}
 	}
-	return resourceAppSignOnPolicyRuleRead(ctx, d, m)
+	return append(resourceAppSignOnPolicyRuleRead(ctx, d, m), appSignOnPolicyRuleShadowing.warnings(ctx, d, m)...)
 }
 
 func resourceAppSignOnPolicyRuleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -354,6 +368,11 @@ func buildAppSignOnPolicyRule(d *schema.ResourceData) sdk.AccessPolicyRule {
 			Managed:    boolPtr(d.Get("device_is_managed").(bool)),
 			Registered: boolPtr(isRegistered.(bool)),
 		}
+		if assurance, ok := d.GetOk("device_assurance_included"); ok {
+			rule.Conditions.Device.Assurance = &sdk.DeviceAssurancePolicyRuleCondition{
+				Include: convertInterfaceToStringSet(assurance),
+			}
+		}
 	}
 	usersExcluded, usersExcludedOk := d.GetOk("users_excluded")
 	usersIncluded, usersIncludedOk := d.GetOk("users_included")
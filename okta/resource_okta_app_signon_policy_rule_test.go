@@ -72,6 +72,29 @@ func TestAccOktaAppSignOnPolicyRule(t *testing.T) {
 	})
 }
 
+func TestAccOktaAppSignOnPolicyRule_deviceAssurance(t *testing.T) {
+	resourceName := fmt.Sprintf("%s.test", appSignOnPolicyRule)
+	mgr := newFixtureManager(appSignOnPolicyRule, t.Name())
+	config := mgr.GetFixtures("device_assurance.tf", t)
+
+	oktaResourceTest(t, resource.TestCase{
+		PreCheck:          testAccPreCheck(t),
+		ErrorCheck:        testAccErrorChecks(t),
+		ProviderFactories: testAccProvidersFactories,
+		CheckDestroy:      checkAppSignOnPolicyRuleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "device_is_registered", "true"),
+					resource.TestCheckResourceAttr(resourceName, "device_assurance_included.#", "1"),
+					resource.TestCheckTypeSetElemAttrPair(resourceName, "device_assurance_included.*", "okta_device_assurance_policy.test", "id"),
+				),
+			},
+		},
+	})
+}
+
 func checkAppSignOnPolicyRuleDestroy(s *terraform.State) error {
 	if isVCRPlayMode() {
 		return nil
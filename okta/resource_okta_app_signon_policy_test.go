@@ -44,6 +44,11 @@ func TestAccOktaAppSignOnPolicy_crud(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "description", "The app signon policy used by our test app."),
 				),
 			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
@@ -68,6 +73,7 @@ data "okta_policy" "test" {
 resource "okta_app_signon_policy" "test" {
   name        = "testAcc_Policy_replace_with_uuid"
   description = "Sign On Policy"
+  list_apps   = true
   depends_on = [
     data.okta_policy.test
   ]
@@ -108,6 +114,11 @@ data "okta_app_signon_policy" "test2" {
 					resource.TestCheckResourceAttrPair("okta_app_signon_policy.test", "id", "data.okta_app_signon_policy.test1", "id"),
 				),
 			},
+			{
+				// the apps are created after the policy, they are listed on refresh
+				RefreshState: true,
+				Check:        resource.TestCheckResourceAttr("okta_app_signon_policy.test", "apps.#", "2"),
+			},
 			{
 				Config: mgr.ConfigReplace(`
 
@@ -2,9 +2,11 @@ package okta
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/okta/terraform-provider-okta/okta/internal/el"
 	"github.com/okta/terraform-provider-okta/sdk"
 )
 
@@ -15,6 +17,7 @@ func resourceAuthServerClaim() *schema.Resource {
 		UpdateContext: resourceAuthServerClaimUpdate,
 		DeleteContext: resourceAuthServerClaimDelete,
 		Importer:      createNestedResourceImporter([]string{"auth_server_id", "id"}),
+		CustomizeDiff: authServerClaimValueCustomizeDiff,
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:        schema.TypeString,
@@ -60,6 +63,21 @@ func resourceAuthServerClaim() *schema.Resource {
 	}
 }
 
+// authServerClaimValueCustomizeDiff checks the expression of an EXPRESSION
+// claim. The value of a GROUPS claim is a group filter, not an expression, so
+// it can't be checked by a ValidateDiagFunc of the value. Calls of unknown
+// functions are accepted as CustomizeDiff can't warn.
+func authServerClaimValueCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown("value") || !d.NewValueKnown("value_type") || d.Get("value_type").(string) != "EXPRESSION" {
+		return nil
+	}
+	value := d.Get("value").(string)
+	if _, err := el.Check(value, nil); err != nil {
+		return fmt.Errorf("expected value to be a valid Okta Expression Language expression, got '%s': %v", value, err)
+	}
+	return nil
+}
+
 func resourceAuthServerClaimCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	claim := buildAuthServerClaim(d)
 	respClaim, _, err := getOktaClientFromMetadata(m).AuthorizationServer.CreateOAuth2Claim(ctx, d.Get("auth_server_id").(string), claim)
@@ -2,6 +2,7 @@ package okta
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -76,3 +77,21 @@ func TestAccOktaAuthServerClaim_groupType(t *testing.T) {
 		},
 	})
 }
+
+func TestAccOktaAuthServerClaim_invalidExpression(t *testing.T) {
+	mgr := newFixtureManager(authServerClaim, t.Name())
+	config := mgr.GetFixtures("invalid_expression.tf", t)
+
+	oktaResourceTest(t, resource.TestCase{
+		PreCheck:          testAccPreCheck(t),
+		ErrorCheck:        testAccErrorChecks(t),
+		ProviderFactories: testAccProvidersFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      config,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`String.substringBefore expects 2 arguments, got 1`),
+			},
+		},
+	})
+}
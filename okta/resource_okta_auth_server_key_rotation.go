@@ -0,0 +1,333 @@
+package okta
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/okta/terraform-provider-okta/sdk"
+)
+
+// authServerKeyRotationTimeout is the default timeout of a rotation, which
+// includes the wait for the overlap.
+const authServerKeyRotationTimeout = 30 * time.Minute
+
+func resourceAuthServerKeyRotation() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceAuthServerKeyRotationCreate,
+		ReadContext:   resourceAuthServerKeyRotationRead,
+		UpdateContext: resourceAuthServerKeyRotationUpdate,
+		DeleteContext: resourceFuncNoOp,
+		Importer: &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+				_ = d.Set("auth_server_id", d.Id())
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+		CustomizeDiff: authServerKeyRotationCustomizeDiff,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(authServerKeyRotationTimeout),
+			Update: schema.DefaultTimeout(authServerKeyRotationTimeout),
+		},
+		Schema: map[string]*schema.Schema{
+			"auth_server_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the authorization server, its credentials rotation mode must be MANUAL",
+			},
+			"rotation_trigger": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Arbitrary value, the keys are rotated every time it changes",
+			},
+			"wait_for_jwks": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Wait until the keys endpoint of the authorization server publishes the next key before rotating",
+			},
+			"overlap": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "0s",
+				ValidateDiagFunc: durationIsValid,
+				Description:      "How long the next key must have been published before it becomes the signing key and the current key is retired, e.g. '15m'",
+			},
+			"active_kid": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the key signing the tokens",
+			},
+			"next_kid": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the key which will sign the tokens after the next rotation",
+			},
+			"current_jwks": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "JWKS of the key signing the tokens",
+			},
+			"next_jwks": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "JWKS of the key which will sign the tokens after the next rotation",
+			},
+			"last_rotated": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Time of the last rotation of the keys",
+			},
+		},
+	}
+}
+
+// authServerKeyRotationCustomizeDiff rejects an overlap the rotation can't
+// wait for within the timeout of the operation.
+func authServerKeyRotationCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown("overlap") {
+		return nil
+	}
+	overlap, err := time.ParseDuration(d.Get("overlap").(string))
+	if err != nil {
+		// reported by the validation of overlap
+		return nil
+	}
+	operation := schema.TimeoutUpdate
+	if d.Id() == "" {
+		operation = schema.TimeoutCreate
+	}
+	timeout := configuredTimeout(d.GetRawConfig(), operation, authServerKeyRotationTimeout)
+	if overlap >= timeout {
+		return fmt.Errorf("'overlap' (%s) must be shorter than the %s timeout (%s), the rotation waits for it within the timeout", overlap, operation, timeout)
+	}
+	return nil
+}
+
+// configuredTimeout returns the timeout of the operation set in the timeouts
+// block of the configuration, or def when it isn't set or known.
+func configuredTimeout(config cty.Value, operation string, def time.Duration) time.Duration {
+	if config.IsNull() || !config.IsKnown() || !config.Type().IsObjectType() || !config.Type().HasAttribute(schema.TimeoutsConfigKey) {
+		return def
+	}
+	timeouts := config.GetAttr(schema.TimeoutsConfigKey)
+	if timeouts.IsNull() || !timeouts.IsKnown() || !timeouts.Type().HasAttribute(operation) {
+		return def
+	}
+	v := timeouts.GetAttr(operation)
+	if v.IsNull() || !v.IsKnown() {
+		return def
+	}
+	timeout, err := time.ParseDuration(v.AsString())
+	if err != nil {
+		return def
+	}
+	return timeout
+}
+
+func resourceAuthServerKeyRotationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	authServerID := d.Get("auth_server_id").(string)
+	if err := rotateAuthServerKeys(ctx, d, m, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(authServerID)
+	return resourceAuthServerKeyRotationRead(ctx, d, m)
+}
+
+func resourceAuthServerKeyRotationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	authServer, resp, err := getOktaClientFromMetadata(m).AuthorizationServer.GetAuthorizationServer(ctx, d.Id())
+	if err := suppressErrorOn404(resp, err); err != nil {
+		return diag.Errorf("failed to get authorization server: %v", err)
+	}
+	if authServer == nil {
+		d.SetId("")
+		return nil
+	}
+	keys, _, err := getOktaClientFromMetadata(m).AuthorizationServer.ListAuthorizationServerKeys(ctx, d.Id())
+	if err != nil {
+		return diag.Errorf("failed to list authorization server keys: %v", err)
+	}
+	active, next := authServerKeysByStatus(keys)
+	currentJWKS, err := jwksJSON(active)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	nextJWKS, err := jwksJSON(next)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	_ = d.Set("auth_server_id", d.Id())
+	_ = d.Set("active_kid", kidOf(active))
+	_ = d.Set("next_kid", kidOf(next))
+	_ = d.Set("current_jwks", currentJWKS)
+	_ = d.Set("next_jwks", nextJWKS)
+	if authServer.Credentials != nil && authServer.Credentials.Signing != nil && authServer.Credentials.Signing.LastRotated != nil {
+		_ = d.Set("last_rotated", authServer.Credentials.Signing.LastRotated.String())
+	}
+	return nil
+}
+
+func resourceAuthServerKeyRotationUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if d.HasChange("rotation_trigger") {
+		if err := rotateAuthServerKeys(ctx, d, m, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return resourceAuthServerKeyRotationRead(ctx, d, m)
+}
+
+// rotateAuthServerKeys makes the next key of the authorization server its
+// signing key. The next key is published ahead of the rotation, so it first
+// waits until the keys endpoint serves it and it has been published for the
+// overlap, giving the relying parties the time to refresh their cached JWKS.
+func rotateAuthServerKeys(ctx context.Context, d *schema.ResourceData, m interface{}, timeout time.Duration) error {
+	authServerID := d.Get("auth_server_id").(string)
+	client := getOktaClientFromMetadata(m)
+	authServer, _, err := client.AuthorizationServer.GetAuthorizationServer(ctx, authServerID)
+	if err != nil {
+		return fmt.Errorf("failed to get authorization server: %v", err)
+	}
+	if authServer.Credentials == nil || authServer.Credentials.Signing == nil || authServer.Credentials.Signing.RotationMode != "MANUAL" {
+		return fmt.Errorf("keys of authorization server '%s' can only be rotated when its credentials rotation mode is MANUAL", authServerID)
+	}
+	keys, _, err := client.AuthorizationServer.ListAuthorizationServerKeys(ctx, authServerID)
+	if err != nil {
+		return fmt.Errorf("failed to list authorization server keys: %v", err)
+	}
+	_, next := authServerKeysByStatus(keys)
+	if next == nil {
+		return fmt.Errorf("authorization server '%s' has no next key to rotate to", authServerID)
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	if d.Get("wait_for_jwks").(bool) {
+		logger(m).Info("waiting for the next key to be published", "auth_server_id", authServerID, "kid", next.Kid)
+		if err := waitForPublishedKey(ctx, m, authServerID, next.Kid); err != nil {
+			return err
+		}
+	}
+	overlap, _ := time.ParseDuration(d.Get("overlap").(string))
+	if wait := time.Until(rotationReadyAt(next, overlap, time.Now())); wait > 0 {
+		logger(m).Info("waiting for the overlap of the next key", "auth_server_id", authServerID, "kid", next.Kid, "wait", wait.String())
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for the overlap of key '%s': %v", next.Kid, ctx.Err())
+		}
+	}
+	_, _, err = client.AuthorizationServer.RotateAuthorizationServerKeys(ctx, authServerID, sdk.JwkUse{Use: "sig"})
+	if err != nil {
+		return fmt.Errorf("failed to rotate authorization server keys: %v", err)
+	}
+	return nil
+}
+
+// waitForPublishedKey polls the public keys endpoint of the authorization
+// server until it serves the key.
+func waitForPublishedKey(ctx context.Context, m interface{}, authServerID, kid string) error {
+	bOff := backoff.NewExponentialBackOff()
+	bOff.InitialInterval = time.Second
+	bOff.MaxInterval = 30 * time.Second
+	bOff.MaxElapsedTime = 0
+	err := backoff.Retry(func() error {
+		keySet, _, err := getAPISupplementFromMetadata(m).GetAuthorizationServerPublicKeys(ctx, authServerID)
+		if err != nil {
+			return fmt.Errorf("failed to get authorization server public keys: %v", err)
+		}
+		for _, key := range keySet.Keys {
+			if key != nil && key.Kid == kid {
+				return nil
+			}
+		}
+		return fmt.Errorf("key '%s' is not published yet", kid)
+	}, backoff.WithContext(bOff, ctx))
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) || ctx.Err() != nil {
+			return fmt.Errorf("timed out waiting for key '%s' to be published: %v", kid, err)
+		}
+		return err
+	}
+	return nil
+}
+
+// rotationReadyAt returns the time after which the next key has been
+// published for the overlap. The creation time of the key is when it was
+// published, keys without it are assumed to be published now.
+func rotationReadyAt(next *sdk.JsonWebKey, overlap time.Duration, now time.Time) time.Time {
+	if next.Created == nil {
+		return now.Add(overlap)
+	}
+	return next.Created.Add(overlap)
+}
+
+// authServerKeysByStatus returns the ACTIVE and NEXT keys of an authorization
+// server.
+func authServerKeysByStatus(keys []*sdk.JsonWebKey) (active, next *sdk.JsonWebKey) {
+	for _, key := range keys {
+		if key == nil {
+			continue
+		}
+		switch key.Status {
+		case statusActive:
+			active = key
+		case "NEXT":
+			next = key
+		}
+	}
+	return active, next
+}
+
+func kidOf(key *sdk.JsonWebKey) string {
+	if key == nil {
+		return ""
+	}
+	return key.Kid
+}
+
+// publicJWK is the public part of a key as published in a JWKS.
+type publicJWK struct {
+	Alg     string   `json:"alg,omitempty"`
+	E       string   `json:"e,omitempty"`
+	Kid     string   `json:"kid,omitempty"`
+	Kty     string   `json:"kty,omitempty"`
+	N       string   `json:"n,omitempty"`
+	Use     string   `json:"use,omitempty"`
+	X5c     []string `json:"x5c,omitempty"`
+	X5t     string   `json:"x5t,omitempty"`
+	X5tS256 string   `json:"x5t#S256,omitempty"`
+}
+
+// jwksJSON returns the JWKS of the keys, omitting nil keys.
+func jwksJSON(keys ...*sdk.JsonWebKey) (string, error) {
+	jwks := struct {
+		Keys []publicJWK `json:"keys"`
+	}{Keys: []publicJWK{}}
+	for _, key := range keys {
+		if key == nil {
+			continue
+		}
+		jwks.Keys = append(jwks.Keys, publicJWK{
+			Alg:     key.Alg,
+			E:       key.E,
+			Kid:     key.Kid,
+			Kty:     key.Kty,
+			N:       key.N,
+			Use:     key.Use,
+			X5c:     key.X5c,
+			X5t:     key.X5t,
+			X5tS256: key.X5tS256,
+		})
+	}
+	b, err := json.Marshal(jwks)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal JWKS: %v", err)
+	}
+	return string(b), nil
+}
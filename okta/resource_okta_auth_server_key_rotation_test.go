@@ -0,0 +1,165 @@
+package okta
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/okta/terraform-provider-okta/sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccOktaAuthServerKeyRotation_crud(t *testing.T) {
+	resourceName := fmt.Sprintf("%s.test", authServerKeyRotation)
+	mgr := newFixtureManager(authServerKeyRotation, t.Name())
+	config := mgr.GetFixtures("basic.tf", t)
+	updatedConfig := mgr.GetFixtures("basic_updated.tf", t)
+	var activeKid string
+
+	oktaResourceTest(t, resource.TestCase{
+		PreCheck:          testAccPreCheck(t),
+		ErrorCheck:        testAccErrorChecks(t),
+		ProviderFactories: testAccProvidersFactories,
+		CheckDestroy:      checkResourceDestroy(authServer, authServerExists),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "active_kid"),
+					resource.TestCheckResourceAttrSet(resourceName, "next_kid"),
+					resource.TestCheckResourceAttrSet(resourceName, "current_jwks"),
+					resource.TestCheckResourceAttrSet(resourceName, "next_jwks"),
+					func(s *terraform.State) error {
+						activeKid = s.RootModule().Resources[resourceName].Primary.Attributes["active_kid"]
+						return nil
+					},
+				),
+			},
+			{
+				Config: updatedConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "rotation_trigger", "2"),
+					func(s *terraform.State) error {
+						if kid := s.RootModule().Resources[resourceName].Primary.Attributes["active_kid"]; kid == activeKid {
+							return fmt.Errorf("active key '%s' wasn't rotated", kid)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestRotateAuthServerKeysWaitsForPublishedKey(t *testing.T) {
+	created := time.Now().Add(-time.Hour)
+	keys := []*sdk.JsonWebKey{
+		{Kid: "active", Status: statusActive, Kty: "RSA", Use: "sig"},
+		{Kid: "next", Status: "NEXT", Kty: "RSA", Use: "sig", Created: &created},
+	}
+	polls, rotations := 0, 0
+	ctx, m := newTestConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/authorizationServers/aus1":
+			_, _ = w.Write([]byte(`{"id":"aus1","credentials":{"signing":{"rotationMode":"MANUAL"}}}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/authorizationServers/aus1/credentials/keys":
+			_ = json.NewEncoder(w).Encode(keys)
+		case r.Method == http.MethodGet && r.URL.Path == "/oauth2/aus1/v1/keys":
+			polls++
+			published := keys[:1]
+			if polls > 1 {
+				published = keys
+			}
+			_ = json.NewEncoder(w).Encode(&sdk.JsonWebKeySet{Keys: published})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/authorizationServers/aus1/credentials/lifecycle/keyRotate":
+			rotations++
+			_ = json.NewEncoder(w).Encode(keys)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	d := schema.TestResourceDataRaw(t, resourceAuthServerKeyRotation().Schema, map[string]interface{}{
+		"auth_server_id": "aus1",
+		"overlap":        "30m",
+	})
+
+	err := rotateAuthServerKeys(ctx, d, m, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, 2, polls)
+	assert.Equal(t, 1, rotations)
+}
+
+func TestAuthServerKeysByStatus(t *testing.T) {
+	active, next := authServerKeysByStatus([]*sdk.JsonWebKey{
+		{Kid: "expired", Status: "EXPIRED"},
+		nil,
+		{Kid: "next", Status: "NEXT"},
+		{Kid: "active", Status: statusActive},
+	})
+	assert.Equal(t, "active", kidOf(active))
+	assert.Equal(t, "next", kidOf(next))
+
+	active, next = authServerKeysByStatus(nil)
+	assert.Nil(t, active)
+	assert.Equal(t, "", kidOf(next))
+}
+
+func TestJWKSJSON(t *testing.T) {
+	created := time.Now()
+	jwks, err := jwksJSON(&sdk.JsonWebKey{Kid: "k1", Kty: "RSA", Alg: "RS256", Use: "sig", E: "AQAB", N: "abc", Status: statusActive, Created: &created}, nil)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"keys":[{"alg":"RS256","e":"AQAB","kid":"k1","kty":"RSA","n":"abc","use":"sig"}]}`, jwks)
+
+	jwks, err = jwksJSON(nil)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"keys":[]}`, jwks)
+}
+
+func TestRotationReadyAt(t *testing.T) {
+	now := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	created := now.Add(-10 * time.Minute)
+	assert.Equal(t, now.Add(5*time.Minute), rotationReadyAt(&sdk.JsonWebKey{Created: &created}, 15*time.Minute, now))
+	assert.Equal(t, now.Add(15*time.Minute), rotationReadyAt(&sdk.JsonWebKey{}, 15*time.Minute, now))
+}
+
+func TestDurationIsValid(t *testing.T) {
+	path := cty.GetAttrPath("overlap")
+	assert.Empty(t, durationIsValid("15m", path))
+	assert.Empty(t, durationIsValid("0s", path))
+	assert.NotEmpty(t, durationIsValid("15", path))
+	assert.NotEmpty(t, durationIsValid("-1m", path))
+}
+
+func TestAuthServerKeyRotationCustomizeDiff(t *testing.T) {
+	r := resourceAuthServerKeyRotation()
+	diff := func(overlap string) error {
+		_, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+			"auth_server_id": "aus1",
+			"overlap":        overlap,
+		}), nil)
+		return err
+	}
+	assert.NoError(t, diff("15m"))
+	assert.EqualError(t, diff("24h"), "'overlap' (24h0m0s) must be shorter than the create timeout (30m0s), the rotation waits for it within the timeout")
+}
+
+func TestConfiguredTimeout(t *testing.T) {
+	config := func(timeouts cty.Value) cty.Value {
+		return cty.ObjectVal(map[string]cty.Value{"overlap": cty.StringVal("24h"), "timeouts": timeouts})
+	}
+	timeoutsType := cty.Object(map[string]cty.Type{"create": cty.String, "update": cty.String})
+	set := config(cty.ObjectVal(map[string]cty.Value{"create": cty.StringVal("25h"), "update": cty.NullVal(cty.String)}))
+	assert.Equal(t, 25*time.Hour, configuredTimeout(set, schema.TimeoutCreate, time.Minute))
+	assert.Equal(t, time.Minute, configuredTimeout(set, schema.TimeoutUpdate, time.Minute))
+	assert.Equal(t, time.Minute, configuredTimeout(config(cty.NullVal(timeoutsType)), schema.TimeoutCreate, time.Minute))
+	assert.Equal(t, time.Minute, configuredTimeout(cty.NullVal(set.Type()), schema.TimeoutCreate, time.Minute))
+}
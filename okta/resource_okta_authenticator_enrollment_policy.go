@@ -0,0 +1,192 @@
+package okta
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/okta/terraform-provider-okta/sdk"
+)
+
+var authenticatorEnrollValues = []string{"REQUIRED", "OPTIONAL", "NOT_ALLOWED"}
+
+var authenticatorEnrollmentResource = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"key": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Key of the authenticator, see okta_authenticator",
+		},
+		"enroll": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Enrollment requirement of the authenticator: REQUIRED, OPTIONAL or NOT_ALLOWED",
+		},
+	},
+}
+
+func resourceAuthenticatorEnrollmentPolicy() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceAuthenticatorEnrollmentPolicyCreate,
+		ReadContext:   resourceAuthenticatorEnrollmentPolicyRead,
+		UpdateContext: resourceAuthenticatorEnrollmentPolicyUpdate,
+		DeleteContext: resourceAuthenticatorEnrollmentPolicyDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		CustomizeDiff: validateAuthenticatorEnrollments,
+		Timeouts:      policyCreateTimeouts(),
+		Schema: buildPolicySchema(map[string]*schema.Schema{
+			"authenticator": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				Elem:        authenticatorEnrollmentResource,
+				Description: "Authenticators of the policy and whether users have to enroll in them",
+			},
+		}),
+	}
+}
+
+func resourceAuthenticatorEnrollmentPolicyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if isClassicOrg(ctx, m) {
+		return resourceOIEOnlyFeatureError(authenticatorEnrollmentPolicy)
+	}
+
+	err := createPolicy(ctx, d, m, buildAuthenticatorEnrollmentPolicy(d))
+	if err != nil {
+		return diag.Errorf("failed to create authenticator enrollment policy: %v", err)
+	}
+	return resourceAuthenticatorEnrollmentPolicyRead(ctx, d, m)
+}
+
+func resourceAuthenticatorEnrollmentPolicyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if isClassicOrg(ctx, m) {
+		return resourceOIEOnlyFeatureError(authenticatorEnrollmentPolicy)
+	}
+
+	policy, err := getPolicy(ctx, d, m)
+	if err != nil {
+		return diag.Errorf("failed to get authenticator enrollment policy: %v", err)
+	}
+	if policy == nil {
+		return nil
+	}
+	if policy.Type != sdk.MfaPolicyType || policy.Settings == nil || policy.Settings.Type != "AUTHENTICATORS" {
+		return diag.Errorf("policy '%s' is not an authenticator enrollment policy, classic MFA policies are managed with %s", d.Id(), policyMfa)
+	}
+	arr := make([]interface{}, 0, len(policy.Settings.Authenticators))
+	for _, authenticator := range policy.Settings.Authenticators {
+		enroll := ""
+		if authenticator.Enroll != nil {
+			enroll = authenticator.Enroll.Self
+		}
+		arr = append(arr, map[string]interface{}{
+			"key":    authenticator.Key,
+			"enroll": enroll,
+		})
+	}
+	err = setNonPrimitives(d, map[string]interface{}{
+		"authenticator": schema.NewSet(schema.HashResource(authenticatorEnrollmentResource), arr),
+	})
+	if err != nil {
+		return diag.Errorf("failed to set authenticators of the authenticator enrollment policy: %v", err)
+	}
+	err = syncPolicyFromUpstream(d, policy)
+	if err != nil {
+		return diag.Errorf("failed to sync policy: %v", err)
+	}
+	return nil
+}
+
+func resourceAuthenticatorEnrollmentPolicyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if isClassicOrg(ctx, m) {
+		return resourceOIEOnlyFeatureError(authenticatorEnrollmentPolicy)
+	}
+
+	err := updatePolicy(ctx, d, m, buildAuthenticatorEnrollmentPolicy(d))
+	if err != nil {
+		return diag.Errorf("failed to update authenticator enrollment policy: %v", err)
+	}
+	return resourceAuthenticatorEnrollmentPolicyRead(ctx, d, m)
+}
+
+func resourceAuthenticatorEnrollmentPolicyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if isClassicOrg(ctx, m) {
+		return resourceOIEOnlyFeatureError(authenticatorEnrollmentPolicy)
+	}
+
+	err := deletePolicy(ctx, d, m)
+	if err != nil {
+		return diag.Errorf("failed to delete authenticator enrollment policy: %v", err)
+	}
+	return nil
+}
+
+func buildAuthenticatorEnrollmentPolicy(d *schema.ResourceData) sdk.SdkPolicy {
+	policy := sdk.MfaPolicy()
+	policy.Name = d.Get("name").(string)
+	policy.Status = d.Get("status").(string)
+	policy.Description = d.Get("description").(string)
+	if priority, ok := d.GetOk("priority"); ok {
+		policy.PriorityPtr = int64Ptr(priority.(int))
+	}
+	var authenticators []*sdk.PolicyAuthenticator
+	for _, item := range d.Get("authenticator").(*schema.Set).List() {
+		value := item.(map[string]interface{})
+		authenticators = append(authenticators, &sdk.PolicyAuthenticator{
+			Key:    getMapString(value, "key"),
+			Enroll: &sdk.Enroll{Self: getMapString(value, "enroll")},
+		})
+	}
+	policy.Settings = &sdk.SdkPolicySettings{
+		Type:           "AUTHENTICATORS",
+		Authenticators: authenticators,
+	}
+	policy.Conditions = &sdk.PolicyRuleConditions{
+		People: getGroups(d),
+	}
+	return policy
+}
+
+// validateAuthenticatorEnrollments checks the enrollment requirements and
+// that the authenticators exist in the org.
+func validateAuthenticatorEnrollments(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("authenticator") {
+		return nil
+	}
+	var keys []string
+	for _, item := range d.Get("authenticator").(*schema.Set).List() {
+		value := item.(map[string]interface{})
+		key, enroll := getMapString(value, "key"), getMapString(value, "enroll")
+		if key == "" {
+			// unknown until apply
+			return nil
+		}
+		if contains(keys, key) {
+			return fmt.Errorf("authenticator '%s' is listed more than once", key)
+		}
+		if enroll != "" && !contains(authenticatorEnrollValues, enroll) {
+			return fmt.Errorf("'enroll' of authenticator '%s' must be one of %s, got '%s'", key, strings.Join(authenticatorEnrollValues, ", "), enroll)
+		}
+		keys = append(keys, key)
+	}
+	authenticators, _, err := getOktaClientFromMetadata(m).Authenticator.ListAuthenticators(ctx)
+	if err != nil {
+		logger(m).Warn("failed to list authenticators, skipping the validation of the authenticator keys", "error", err)
+		return nil
+	}
+	existing := make([]string, len(authenticators))
+	for i := range authenticators {
+		existing[i] = authenticators[i].Key
+	}
+	sort.Strings(existing)
+	for _, key := range keys {
+		if !contains(existing, key) {
+			return fmt.Errorf("authenticator '%s' does not exist in the org, existing authenticators are %s", key, strings.Join(existing, ", "))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package okta
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/okta/terraform-provider-okta/sdk"
+)
+
+var authenticatorEnrollmentPolicyRuleShadowing = policyRuleShadowing{}
+
+func resourceAuthenticatorEnrollmentPolicyRule() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceAuthenticatorEnrollmentPolicyRuleCreate,
+		ReadContext:   resourceAuthenticatorEnrollmentPolicyRuleRead,
+		UpdateContext: resourceAuthenticatorEnrollmentPolicyRuleUpdate,
+		DeleteContext: resourceAuthenticatorEnrollmentPolicyRuleDelete,
+		Importer:      createPolicyRuleImporter(),
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+			if d.NewValueKnown("enroll") && d.NewValueKnown("grace_period") &&
+				d.Get("grace_period").(string) != "" && d.Get("enroll").(string) == "NEVER" {
+				return fmt.Errorf("'grace_period' can't be set when 'enroll' is NEVER")
+			}
+			return authenticatorEnrollmentPolicyRuleShadowing.customizeDiff(ctx, d, m)
+		},
+		Timeouts: policyCreateTimeouts(),
+		Schema: buildRuleSchema(map[string]*schema.Schema{
+			"enroll": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: stringInSlice("CHALLENGE", "LOGIN", "NEVER"),
+				Default:          "CHALLENGE",
+				Description:      "When users enroll in the authenticators required by the policy: CHALLENGE when they are missing, LOGIN at the first sign-in of the user, or NEVER",
+			},
+			"grace_period": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "ISO 8601 duration, e.g. P7D, during which users can postpone the enrollment in the required authenticators",
+			},
+		}),
+	}
+}
+
+func resourceAuthenticatorEnrollmentPolicyRuleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if isClassicOrg(ctx, m) {
+		return resourceOIEOnlyFeatureError(authenticatorEnrollmentRule)
+	}
+
+	err := createRule(ctx, d, m, buildAuthenticatorEnrollmentPolicyRule(d), authenticatorEnrollmentRule)
+	if err != nil {
+		return diag.Errorf("failed to create authenticator enrollment policy rule: %v", err)
+	}
+	return append(resourceAuthenticatorEnrollmentPolicyRuleRead(ctx, d, m), authenticatorEnrollmentPolicyRuleShadowing.warnings(ctx, d, m)...)
+}
+
+func resourceAuthenticatorEnrollmentPolicyRuleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if isClassicOrg(ctx, m) {
+		return resourceOIEOnlyFeatureError(authenticatorEnrollmentRule)
+	}
+
+	rule, err := getPolicyRule(ctx, d, m)
+	if err != nil {
+		return diag.Errorf("failed to get authenticator enrollment policy rule: %v", err)
+	}
+	if rule == nil {
+		return nil
+	}
+	err = syncRuleFromUpstream(d, rule)
+	if err != nil {
+		return diag.Errorf("failed to sync authenticator enrollment policy rule: %v", err)
+	}
+	gracePeriod := ""
+	if actions := rule.Actions.PasswordPolicyRuleActions; actions != nil && actions.Enroll != nil {
+		_ = d.Set("enroll", actions.Enroll.Self)
+		if actions.Enroll.GracePeriod != nil {
+			gracePeriod = actions.Enroll.GracePeriod.Duration
+		}
+	}
+	_ = d.Set("grace_period", gracePeriod)
+	return nil
+}
+
+func resourceAuthenticatorEnrollmentPolicyRuleUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if isClassicOrg(ctx, m) {
+		return resourceOIEOnlyFeatureError(authenticatorEnrollmentRule)
+	}
+
+	err := updateRule(ctx, d, m, buildAuthenticatorEnrollmentPolicyRule(d))
+	if err != nil {
+		return diag.Errorf("failed to update authenticator enrollment policy rule: %v", err)
+	}
+	return append(resourceAuthenticatorEnrollmentPolicyRuleRead(ctx, d, m), authenticatorEnrollmentPolicyRuleShadowing.warnings(ctx, d, m)...)
+}
+
+func resourceAuthenticatorEnrollmentPolicyRuleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if isClassicOrg(ctx, m) {
+		return resourceOIEOnlyFeatureError(authenticatorEnrollmentRule)
+	}
+
+	err := deleteRule(ctx, d, m, false)
+	if err != nil {
+		return diag.Errorf("failed to delete authenticator enrollment policy rule: %v", err)
+	}
+	return nil
+}
+
+func buildAuthenticatorEnrollmentPolicyRule(d *schema.ResourceData) sdk.SdkPolicyRule {
+	rule := sdk.MfaPolicyRule()
+	rule.Name = d.Get("name").(string)
+	rule.Status = d.Get("status").(string)
+	if priority, ok := d.GetOk("priority"); ok {
+		rule.Priority = int64(priority.(int))
+	}
+	rule.Conditions = &sdk.PolicyRuleConditions{
+		Network: buildPolicyNetworkCondition(d),
+		People:  getUsers(d),
+	}
+	enroll := &sdk.PolicyRuleActionsEnroll{
+		Self: d.Get("enroll").(string),
+	}
+	if gracePeriod, ok := d.GetOk("grace_period"); ok {
+		enroll.GracePeriod = &sdk.PolicyRuleActionsEnrollGracePeriod{
+			Type:     "BY_DURATION",
+			Duration: gracePeriod.(string),
+		}
+	}
+	rule.Actions = sdk.SdkPolicyRuleActions{
+		PasswordPolicyRuleActions: &sdk.PasswordPolicyRuleActions{
+			Enroll: enroll,
+		},
+	}
+	return rule
+}
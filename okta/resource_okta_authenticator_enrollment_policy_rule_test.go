@@ -0,0 +1,68 @@
+package okta
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthenticatorEnrollmentPolicyRuleCustomizeDiff(t *testing.T) {
+	// the value of unknown attributes in a raw terraform.ResourceConfig
+	const unknownValue = "74D93920-ED26-11E3-AC10-0800200C9A66"
+	r := resourceAuthenticatorEnrollmentPolicyRule()
+	diff := func(config map[string]interface{}) error {
+		config["policy_id"] = "pol1"
+		config["name"] = "test"
+		_, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), nil)
+		return err
+	}
+	assert.NoError(t, diff(map[string]interface{}{"enroll": "LOGIN", "grace_period": "P7D"}))
+	assert.EqualError(t, diff(map[string]interface{}{"enroll": "NEVER", "grace_period": "P7D"}), "'grace_period' can't be set when 'enroll' is NEVER")
+	// enroll is known after apply, e.g. when it comes from another resource
+	require.NoError(t, diff(map[string]interface{}{"enroll": unknownValue, "grace_period": "P7D"}))
+
+	enroll := r.Schema["enroll"]
+	assert.True(t, enroll.ValidateDiagFunc("ALWAYS", cty.GetAttrPath("enroll")).HasError())
+	assert.False(t, enroll.ValidateDiagFunc("NEVER", cty.GetAttrPath("enroll")).HasError())
+}
+
+func TestAccOktaAuthenticatorEnrollmentPolicyRule_crud(t *testing.T) {
+	mgr := newFixtureManager(authenticatorEnrollmentRule, t.Name())
+	config := mgr.GetFixtures("basic.tf", t)
+	updatedConfig := mgr.GetFixtures("updated.tf", t)
+	resourceName := fmt.Sprintf("%s.test", authenticatorEnrollmentRule)
+
+	oktaResourceTest(t, resource.TestCase{
+		PreCheck:          testAccPreCheck(t),
+		ErrorCheck:        testAccErrorChecks(t),
+		ProviderFactories: testAccProvidersFactories,
+		CheckDestroy:      checkRuleDestroy(authenticatorEnrollmentRule),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					ensureRuleExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "name", buildResourceName(mgr.Seed)),
+					resource.TestCheckResourceAttr(resourceName, "status", statusActive),
+					resource.TestCheckResourceAttr(resourceName, "enroll", "CHALLENGE"),
+					resource.TestCheckResourceAttr(resourceName, "grace_period", "P7D"),
+				),
+			},
+			{
+				Config: updatedConfig,
+				Check: resource.ComposeTestCheckFunc(
+					ensureRuleExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "status", statusInactive),
+					resource.TestCheckResourceAttr(resourceName, "enroll", "LOGIN"),
+					resource.TestCheckResourceAttr(resourceName, "grace_period", ""),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,80 @@
+package okta
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccOktaAuthenticatorEnrollmentPolicy_crud(t *testing.T) {
+	mgr := newFixtureManager(authenticatorEnrollmentPolicy, t.Name())
+	config := mgr.GetFixtures("basic.tf", t)
+	updatedConfig := mgr.GetFixtures("updated.tf", t)
+	resourceName := fmt.Sprintf("%s.test", authenticatorEnrollmentPolicy)
+
+	oktaResourceTest(t, resource.TestCase{
+		PreCheck:          testAccPreCheck(t),
+		ErrorCheck:        testAccErrorChecks(t),
+		ProviderFactories: testAccProvidersFactories,
+		CheckDestroy:      checkPolicyDestroy(authenticatorEnrollmentPolicy),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					ensurePolicyExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "name", buildResourceName(mgr.Seed)),
+					resource.TestCheckResourceAttr(resourceName, "status", statusActive),
+					resource.TestCheckResourceAttr(resourceName, "authenticator.#", "3"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "authenticator.*", map[string]string{
+						"key":    "okta_verify",
+						"enroll": "OPTIONAL",
+					}),
+				),
+			},
+			{
+				Config: updatedConfig,
+				Check: resource.ComposeTestCheckFunc(
+					ensurePolicyExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "name", buildResourceName(mgr.Seed)+"_updated"),
+					resource.TestCheckResourceAttr(resourceName, "status", statusInactive),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "authenticator.*", map[string]string{
+						"key":    "okta_verify",
+						"enroll": "NOT_ALLOWED",
+					}),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccOktaAuthenticatorEnrollmentPolicy_unknownAuthenticator(t *testing.T) {
+	mgr := newFixtureManager(authenticatorEnrollmentPolicy, t.Name())
+	config := `
+resource "okta_authenticator_enrollment_policy" "test" {
+  name = "testAcc_replace_with_uuid"
+
+  authenticator {
+    key    = "no_such_authenticator"
+    enroll = "REQUIRED"
+  }
+}`
+
+	oktaResourceTest(t, resource.TestCase{
+		PreCheck:          testAccPreCheck(t),
+		ErrorCheck:        testAccErrorChecks(t),
+		ProviderFactories: testAccProvidersFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      mgr.ConfigReplace(config),
+				ExpectError: regexp.MustCompile(`authenticator 'no_such_authenticator' does not exist in the org`),
+			},
+		},
+	})
+}
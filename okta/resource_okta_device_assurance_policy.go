@@ -0,0 +1,215 @@
+package okta
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/okta/terraform-provider-okta/sdk"
+)
+
+// deviceAssurancePlatformAttributes lists the attributes supported by the
+// device assurance policies of each platform.
+var deviceAssurancePlatformAttributes = map[string][]string{
+	"ANDROID":  {"os_version_minimum", "disk_encryption_types", "screen_lock_types", "jailbreak", "secure_hardware_present"},
+	"CHROMEOS": {"third_party_signal_providers"},
+	"IOS":      {"os_version_minimum", "screen_lock_types", "jailbreak"},
+	"MACOS":    {"os_version_minimum", "disk_encryption_types", "secure_hardware_present", "third_party_signal_providers"},
+	"WINDOWS":  {"os_version_minimum", "disk_encryption_types", "secure_hardware_present", "third_party_signal_providers"},
+}
+
+func resourceDeviceAssurancePolicy() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDeviceAssurancePolicyCreate,
+		ReadContext:   resourceDeviceAssurancePolicyRead,
+		UpdateContext: resourceDeviceAssurancePolicyUpdate,
+		DeleteContext: resourceDeviceAssurancePolicyDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+			if !d.NewValueKnown("platform") {
+				return nil
+			}
+			platform := d.Get("platform").(string)
+			supported, ok := deviceAssurancePlatformAttributes[platform]
+			if !ok {
+				return fmt.Errorf("'platform' must be one of ANDROID, CHROMEOS, IOS, MACOS or WINDOWS, got '%s'", platform)
+			}
+			for _, attributes := range deviceAssurancePlatformAttributes {
+				for _, k := range attributes {
+					if !contains(supported, k) && !d.GetRawConfig().GetAttr(k).IsNull() {
+						return fmt.Errorf("'%s' is not supported by device assurance policies of the %s platform", k, platform)
+					}
+				}
+			}
+			return nil
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the device assurance policy",
+			},
+			"platform": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Platform of the devices: ANDROID, CHROMEOS, IOS, MACOS or WINDOWS",
+			},
+			"os_version_minimum": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Minimum version of the operating system, e.g. 12.4.5 or 10.0.19041",
+			},
+			"disk_encryption_types": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Required disk encryption: FULL or USER on Android, ALL_INTERNAL_VOLUMES on macOS and Windows",
+			},
+			"screen_lock_types": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Accepted screen locks: BIOMETRIC and/or PASSCODE",
+			},
+			"jailbreak": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Whether jailbroken or rooted devices are accepted, set it to false to reject them",
+			},
+			"secure_hardware_present": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Whether the device has to have secure hardware, like a TPM",
+			},
+			"third_party_signal_providers": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: stringIsJSON,
+				StateFunc:        normalizeDataJSON,
+				Description:      "JSON of the device signals collected by third party providers, like Chrome Device Trust, as expected by the Okta API",
+			},
+		},
+	}
+}
+
+func resourceDeviceAssurancePolicyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if isClassicOrg(ctx, m) {
+		return resourceOIEOnlyFeatureError(deviceAssurancePolicy)
+	}
+
+	logger(m).Info("creating device assurance policy", "name", d.Get("name").(string))
+	policy, _, err := getAPISupplementFromMetadata(m).CreateDeviceAssurancePolicy(ctx, buildDeviceAssurancePolicy(d))
+	if err != nil {
+		return diag.Errorf("failed to create device assurance policy: %v", err)
+	}
+	d.SetId(policy.Id)
+	return resourceDeviceAssurancePolicyRead(ctx, d, m)
+}
+
+func resourceDeviceAssurancePolicyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if isClassicOrg(ctx, m) {
+		return resourceOIEOnlyFeatureError(deviceAssurancePolicy)
+	}
+
+	logger(m).Info("reading device assurance policy", "id", d.Id())
+	policy, resp, err := getAPISupplementFromMetadata(m).GetDeviceAssurancePolicy(ctx, d.Id())
+	if err := suppressErrorOn404(resp, err); err != nil {
+		return diag.Errorf("failed to get device assurance policy: %v", err)
+	}
+	if policy == nil {
+		d.SetId("")
+		return nil
+	}
+	_ = d.Set("name", policy.Name)
+	_ = d.Set("platform", policy.Platform)
+	_ = d.Set("jailbreak", policy.Jailbreak)
+	_ = d.Set("secure_hardware_present", policy.SecureHardwarePresent)
+	if policy.OsVersion != nil {
+		_ = d.Set("os_version_minimum", policy.OsVersion.Minimum)
+	} else {
+		_ = d.Set("os_version_minimum", "")
+	}
+	providers := ""
+	if len(policy.ThirdPartySignalProviders) > 0 && string(policy.ThirdPartySignalProviders) != "null" {
+		var actual interface{}
+		if err = json.Unmarshal(policy.ThirdPartySignalProviders, &actual); err != nil {
+			return diag.Errorf("failed to read third party signal providers: %v", err)
+		}
+		providers, err = projectPolicyRuleJSON(d.Get("third_party_signal_providers").(string), actual)
+		if err != nil {
+			return diag.Errorf("failed to read third party signal providers: %v", err)
+		}
+	}
+	_ = d.Set("third_party_signal_providers", providers)
+	arr := map[string]interface{}{
+		"disk_encryption_types": nil,
+		"screen_lock_types":     nil,
+	}
+	if policy.DiskEncryptionType != nil {
+		arr["disk_encryption_types"] = convertStringSliceToSetNullable(policy.DiskEncryptionType.Include)
+	}
+	if policy.ScreenLockType != nil {
+		arr["screen_lock_types"] = convertStringSliceToSetNullable(policy.ScreenLockType.Include)
+	}
+	if err = setNonPrimitives(d, arr); err != nil {
+		return diag.Errorf("failed to set device assurance policy properties: %v", err)
+	}
+	return nil
+}
+
+func resourceDeviceAssurancePolicyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if isClassicOrg(ctx, m) {
+		return resourceOIEOnlyFeatureError(deviceAssurancePolicy)
+	}
+
+	logger(m).Info("updating device assurance policy", "id", d.Id(), "name", d.Get("name").(string))
+	_, _, err := getAPISupplementFromMetadata(m).UpdateDeviceAssurancePolicy(ctx, d.Id(), buildDeviceAssurancePolicy(d))
+	if err != nil {
+		return diag.Errorf("failed to update device assurance policy: %v", err)
+	}
+	return resourceDeviceAssurancePolicyRead(ctx, d, m)
+}
+
+func resourceDeviceAssurancePolicyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if isClassicOrg(ctx, m) {
+		return resourceOIEOnlyFeatureError(deviceAssurancePolicy)
+	}
+
+	resp, err := getAPISupplementFromMetadata(m).DeleteDeviceAssurancePolicy(ctx, d.Id())
+	if err := suppressErrorOn404(resp, err); err != nil {
+		return diag.Errorf("failed to delete device assurance policy: %v", err)
+	}
+	return nil
+}
+
+func buildDeviceAssurancePolicy(d *schema.ResourceData) sdk.DeviceAssurance {
+	policy := sdk.DeviceAssurance{
+		Name:     d.Get("name").(string),
+		Platform: d.Get("platform").(string),
+	}
+	if v, ok := d.GetOk("os_version_minimum"); ok {
+		policy.OsVersion = &sdk.DeviceAssuranceOsVersion{Minimum: v.(string)}
+	}
+	if v, ok := d.GetOk("disk_encryption_types"); ok {
+		policy.DiskEncryptionType = &sdk.DeviceAssuranceIncludeCondition{Include: convertInterfaceToStringSet(v)}
+	}
+	if v, ok := d.GetOk("screen_lock_types"); ok {
+		policy.ScreenLockType = &sdk.DeviceAssuranceIncludeCondition{Include: convertInterfaceToStringSet(v)}
+	}
+	// false is a meaningful value of both booleans, only unset ones are omitted
+	if v := d.GetRawConfig().GetAttr("jailbreak"); !v.IsNull() {
+		policy.Jailbreak = boolPtr(v.True())
+	}
+	if v := d.GetRawConfig().GetAttr("secure_hardware_present"); !v.IsNull() {
+		policy.SecureHardwarePresent = boolPtr(v.True())
+	}
+	if v, ok := d.GetOk("third_party_signal_providers"); ok {
+		policy.ThirdPartySignalProviders = json.RawMessage(v.(string))
+	}
+	return policy
+}
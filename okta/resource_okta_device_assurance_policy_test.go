@@ -0,0 +1,92 @@
+package okta
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccOktaDeviceAssurancePolicy_crud(t *testing.T) {
+	mgr := newFixtureManager(deviceAssurancePolicy, t.Name())
+	config := mgr.GetFixtures("basic.tf", t)
+	updatedConfig := mgr.GetFixtures("updated.tf", t)
+	resourceName := fmt.Sprintf("%s.test", deviceAssurancePolicy)
+
+	oktaResourceTest(t, resource.TestCase{
+		PreCheck:          testAccPreCheck(t),
+		ErrorCheck:        testAccErrorChecks(t),
+		ProviderFactories: testAccProvidersFactories,
+		CheckDestroy:      checkDeviceAssurancePolicyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", buildResourceName(mgr.Seed)),
+					resource.TestCheckResourceAttr(resourceName, "platform", "MACOS"),
+					resource.TestCheckResourceAttr(resourceName, "os_version_minimum", "12.4.5"),
+					resource.TestCheckResourceAttr(resourceName, "disk_encryption_types.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "secure_hardware_present", "true"),
+				),
+			},
+			{
+				Config: updatedConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", buildResourceName(mgr.Seed)+"_updated"),
+					resource.TestCheckResourceAttr(resourceName, "os_version_minimum", "13.0.0"),
+					resource.TestCheckResourceAttr(resourceName, "disk_encryption_types.#", "0"),
+					resource.TestCheckResourceAttr(resourceName, "secure_hardware_present", "false"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccOktaDeviceAssurancePolicy_unsupportedAttribute(t *testing.T) {
+	mgr := newFixtureManager(deviceAssurancePolicy, t.Name())
+	config := `
+resource "okta_device_assurance_policy" "test" {
+  name              = "testAcc_replace_with_uuid"
+  platform          = "CHROMEOS"
+  screen_lock_types = ["PASSCODE"]
+}`
+
+	oktaResourceTest(t, resource.TestCase{
+		PreCheck:          testAccPreCheck(t),
+		ErrorCheck:        testAccErrorChecks(t),
+		ProviderFactories: testAccProvidersFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      mgr.ConfigReplace(config),
+				ExpectError: regexp.MustCompile(`'screen_lock_types' is not supported by device assurance policies of the CHROMEOS platform`),
+			},
+		},
+	})
+}
+
+func checkDeviceAssurancePolicyDestroy(s *terraform.State) error {
+	if isVCRPlayMode() {
+		return nil
+	}
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != deviceAssurancePolicy {
+			continue
+		}
+		policy, resp, err := apiSupplementForTest().GetDeviceAssurancePolicy(context.Background(), rs.Primary.ID)
+		if err := suppressErrorOn404(resp, err); err != nil {
+			return err
+		}
+		if policy != nil {
+			return fmt.Errorf("device assurance policy still exists, ID: %s", rs.Primary.ID)
+		}
+	}
+	return nil
+}
@@ -6,10 +6,14 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/okta/terraform-provider-okta/sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAccOktaEventHook_crud(t *testing.T) {
@@ -111,6 +115,21 @@ func TestAccOktaEventHook_crud(t *testing.T) {
 	})
 }
 
+func TestEventHookEventTypeIsValid(t *testing.T) {
+	path := cty.GetAttrPath("events")
+	assert.Empty(t, eventHookEventTypeIsValid("user.lifecycle.create", path))
+
+	diags := eventHookEventTypeIsValid("user.lifecycle.creat", path)
+	require.Len(t, diags, 1)
+	assert.Equal(t, diag.Warning, diags[0].Severity)
+	assert.Equal(t, "'user.lifecycle.creat' is not a known event hook eligible event type, did you mean 'user.lifecycle.create'?", diags[0].Summary)
+
+	diags = eventHookEventTypeIsValid("widget.lifecycle.create", path)
+	require.Len(t, diags, 1)
+	assert.Equal(t, diag.Warning, diags[0].Severity)
+	assert.Equal(t, path, diags[0].AttributePath)
+}
+
 func eventHookExists(id string) (bool, error) {
 	client := oktaClientForTest()
 	eh, resp, err := client.EventHook.GetEventHook(context.Background(), id)
@@ -0,0 +1,81 @@
+package okta
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/okta/terraform-provider-okta/sdk"
+)
+
+func resourceGlobalSessionPolicy() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceGlobalSessionPolicyCreate,
+		ReadContext:   resourceGlobalSessionPolicyRead,
+		UpdateContext: resourceGlobalSessionPolicyUpdate,
+		DeleteContext: resourceGlobalSessionPolicyDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: policyCreateTimeouts(),
+		Schema:   basePolicySchema,
+	}
+}
+
+func resourceGlobalSessionPolicyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if isClassicOrg(ctx, m) {
+		return resourceOIEOnlyFeatureError(globalSessionPolicy)
+	}
+
+	err := createPolicy(ctx, d, m, buildSignOnPolicy(d))
+	if err != nil {
+		return diag.Errorf("failed to create global session policy: %v", err)
+	}
+	return resourceGlobalSessionPolicyRead(ctx, d, m)
+}
+
+func resourceGlobalSessionPolicyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if isClassicOrg(ctx, m) {
+		return resourceOIEOnlyFeatureError(globalSessionPolicy)
+	}
+
+	policy, err := getPolicy(ctx, d, m)
+	if err != nil {
+		return diag.Errorf("failed to get global session policy: %v", err)
+	}
+	if policy == nil {
+		return nil
+	}
+	if policy.Type != sdk.SignOnPolicyType {
+		return diag.Errorf("policy '%s' is not a global session policy, its type is %s", d.Id(), policy.Type)
+	}
+	err = syncPolicyFromUpstream(d, policy)
+	if err != nil {
+		return diag.Errorf("failed to set global session policy: %v", err)
+	}
+	return nil
+}
+
+func resourceGlobalSessionPolicyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if isClassicOrg(ctx, m) {
+		return resourceOIEOnlyFeatureError(globalSessionPolicy)
+	}
+
+	err := updatePolicy(ctx, d, m, buildSignOnPolicy(d))
+	if err != nil {
+		return diag.Errorf("failed to update global session policy: %v", err)
+	}
+	return resourceGlobalSessionPolicyRead(ctx, d, m)
+}
+
+func resourceGlobalSessionPolicyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if isClassicOrg(ctx, m) {
+		return resourceOIEOnlyFeatureError(globalSessionPolicy)
+	}
+
+	err := deletePolicy(ctx, d, m)
+	if err != nil {
+		return diag.Errorf("failed to delete global session policy: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,265 @@
+package okta
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/okta/terraform-provider-okta/sdk"
+)
+
+var globalSessionPolicyRuleShadowing = policyRuleShadowing{"identity_provider", "identity_provider_ids"}
+
+func resourceGlobalSessionPolicyRule() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceGlobalSessionPolicyRuleCreate,
+		ReadContext:   resourceGlobalSessionPolicyRuleRead,
+		UpdateContext: resourceGlobalSessionPolicyRuleUpdate,
+		DeleteContext: resourceGlobalSessionPolicyRuleDelete,
+		Importer:      createPolicyRuleImporter(),
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+			known := true
+			for _, k := range globalSessionPolicyRuleValidatedFields {
+				known = known && d.NewValueKnown(k)
+			}
+			if known {
+				if err := validateGlobalSessionPolicyRule(d.Get); err != nil {
+					return err
+				}
+			}
+			return globalSessionPolicyRuleShadowing.customizeDiff(ctx, d, m)
+		},
+		Timeouts: policyCreateTimeouts(),
+		Schema: buildRuleSchema(map[string]*schema.Schema{
+			"access": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: stringInSlice("ALLOW", "DENY"),
+				Default:          "ALLOW",
+				Description:      "Allow or deny access based on the rule conditions: ALLOW or DENY",
+			},
+			"primary_factor": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: stringInSlice("PASSWORD_IDP_ANY_FACTOR", "PASSWORD_IDP"),
+				Default:          "PASSWORD_IDP_ANY_FACTOR",
+				Description:      "Factor establishing the session: PASSWORD_IDP_ANY_FACTOR for any factor required by the authentication policy of the app, or PASSWORD_IDP for a password",
+			},
+			"mfa_required": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Require a secondary factor after the password, only when primary_factor is PASSWORD_IDP",
+			},
+			"mfa_prompt": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: stringInSlice("ALWAYS", "DEVICE", "SESSION"),
+				Description:      "When users are prompted for the secondary factor: ALWAYS at every sign-in, DEVICE when signing in with a new device, or SESSION when the MFA lifetime expired",
+			},
+			"mfa_remember_device": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Remember the device by default, only when mfa_prompt is DEVICE",
+			},
+			"mfa_lifetime": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Minutes before users are prompted again for the secondary factor, only when mfa_prompt is SESSION",
+			},
+			"session_idle": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     120,
+				Description: "Max minutes a session can be idle",
+			},
+			"session_lifetime": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     0,
+				Description: "Max minutes a session is active: 0 = no limit",
+			},
+			"session_persistent": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether session cookies last across browser sessions, requires session_lifetime. Okta administrators can never have persistent session cookies",
+			},
+			"identity_provider": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: stringInSlice("ANY", "OKTA", "SPECIFIC_IDP"),
+				Default:          "ANY",
+				Description:      "Apply rule based on the IdP used: ANY, OKTA or SPECIFIC_IDP",
+			},
+			"identity_provider_ids": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "When identity_provider is SPECIFIC_IDP then this is the list of IdP IDs to apply the rule on",
+			},
+		}),
+	}
+}
+
+func resourceGlobalSessionPolicyRuleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if isClassicOrg(ctx, m) {
+		return resourceOIEOnlyFeatureError(globalSessionPolicyRule)
+	}
+
+	err := createRule(ctx, d, m, buildGlobalSessionPolicyRule(d), globalSessionPolicyRule)
+	if err != nil {
+		return diag.Errorf("failed to create global session policy rule: %v", err)
+	}
+	return append(resourceGlobalSessionPolicyRuleRead(ctx, d, m), globalSessionPolicyRuleShadowing.warnings(ctx, d, m)...)
+}
+
+func resourceGlobalSessionPolicyRuleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if isClassicOrg(ctx, m) {
+		return resourceOIEOnlyFeatureError(globalSessionPolicyRule)
+	}
+
+	rule, err := getPolicyRule(ctx, d, m)
+	if err != nil {
+		return diag.Errorf("failed to get global session policy rule: %v", err)
+	}
+	if rule == nil {
+		return nil
+	}
+	if signOn := rule.Actions.SignOn; signOn != nil {
+		_ = d.Set("access", signOn.Access)
+		if signOn.PrimaryFactor != "" {
+			_ = d.Set("primary_factor", signOn.PrimaryFactor)
+		}
+		_ = d.Set("mfa_required", signOn.RequireFactor != nil && *signOn.RequireFactor)
+		_ = d.Set("mfa_prompt", signOn.FactorPromptMode)
+		_ = d.Set("mfa_remember_device", signOn.RememberDeviceByDefault != nil && *signOn.RememberDeviceByDefault)
+		_ = d.Set("mfa_lifetime", signOn.FactorLifetime)
+		if session := signOn.Session; session != nil {
+			if session.MaxSessionIdleMinutesPtr != nil {
+				_ = d.Set("session_idle", *session.MaxSessionIdleMinutesPtr)
+			}
+			if session.MaxSessionLifetimeMinutesPtr != nil {
+				_ = d.Set("session_lifetime", *session.MaxSessionLifetimeMinutesPtr)
+			}
+			_ = d.Set("session_persistent", session.UsePersistentCookie != nil && *session.UsePersistentCookie)
+		}
+	}
+	if rule.Conditions != nil && rule.Conditions.IdentityProvider != nil {
+		_ = d.Set("identity_provider", rule.Conditions.IdentityProvider.Provider)
+		if rule.Conditions.IdentityProvider.Provider == "SPECIFIC_IDP" {
+			_ = d.Set("identity_provider_ids", convertStringSliceToInterfaceSlice(rule.Conditions.IdentityProvider.IdpIds))
+		}
+	}
+	err = syncRuleFromUpstream(d, rule)
+	if err != nil {
+		return diag.Errorf("failed to sync global session policy rule: %v", err)
+	}
+	return nil
+}
+
+func resourceGlobalSessionPolicyRuleUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if isClassicOrg(ctx, m) {
+		return resourceOIEOnlyFeatureError(globalSessionPolicyRule)
+	}
+
+	err := updateRule(ctx, d, m, buildGlobalSessionPolicyRule(d))
+	if err != nil {
+		return diag.Errorf("failed to update global session policy rule: %v", err)
+	}
+	return append(resourceGlobalSessionPolicyRuleRead(ctx, d, m), globalSessionPolicyRuleShadowing.warnings(ctx, d, m)...)
+}
+
+func resourceGlobalSessionPolicyRuleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if isClassicOrg(ctx, m) {
+		return resourceOIEOnlyFeatureError(globalSessionPolicyRule)
+	}
+
+	err := deleteRule(ctx, d, m, false)
+	if err != nil {
+		return diag.Errorf("failed to delete global session policy rule: %v", err)
+	}
+	return nil
+}
+
+func buildGlobalSessionPolicyRule(d *schema.ResourceData) sdk.SdkPolicyRule {
+	rule := sdk.SignOnPolicyRule()
+	rule.Name = d.Get("name").(string)
+	rule.Status = d.Get("status").(string)
+	if priority, ok := d.GetOk("priority"); ok {
+		rule.Priority = int64(priority.(int))
+	}
+	rule.Conditions = &sdk.PolicyRuleConditions{
+		Network: buildPolicyNetworkCondition(d),
+		People:  getUsers(d),
+		IdentityProvider: &sdk.IdentityProviderPolicyRuleCondition{
+			Provider: d.Get("identity_provider").(string),
+			IdpIds:   convertInterfaceToStringArr(d.Get("identity_provider_ids")),
+		},
+	}
+	signOn := &sdk.SdkSignOnPolicyRuleSignOnActions{
+		Access:        d.Get("access").(string),
+		PrimaryFactor: d.Get("primary_factor").(string),
+		RequireFactor: boolPtr(d.Get("mfa_required").(bool)),
+		Session: &sdk.OktaSignOnPolicyRuleSignonSessionActions{
+			MaxSessionIdleMinutesPtr:     int64Ptr(d.Get("session_idle").(int)),
+			MaxSessionLifetimeMinutesPtr: int64Ptr(d.Get("session_lifetime").(int)),
+			UsePersistentCookie:          boolPtr(d.Get("session_persistent").(bool)),
+		},
+	}
+	if d.Get("mfa_required").(bool) {
+		signOn.FactorPromptMode = d.Get("mfa_prompt").(string)
+		switch signOn.FactorPromptMode {
+		case "DEVICE":
+			signOn.RememberDeviceByDefault = boolPtr(d.Get("mfa_remember_device").(bool))
+		case "SESSION":
+			signOn.FactorLifetime = int64(d.Get("mfa_lifetime").(int))
+		}
+	}
+	rule.Actions = sdk.SdkPolicyRuleActions{SignOn: signOn}
+	return rule
+}
+
+var globalSessionPolicyRuleValidatedFields = []string{"primary_factor", "mfa_required", "mfa_prompt", "mfa_remember_device",
+	"mfa_lifetime", "session_idle", "session_lifetime", "session_persistent", "identity_provider", "identity_provider_ids"}
+
+// validateGlobalSessionPolicyRule checks the combinations of the session and
+// MFA settings the API rejects.
+func validateGlobalSessionPolicyRule(get func(string) interface{}) error {
+	primaryFactor := get("primary_factor").(string)
+	mfaRequired := get("mfa_required").(bool)
+	if mfaRequired && primaryFactor != "PASSWORD_IDP" {
+		return errors.New("'mfa_required' can only be set when 'primary_factor' is PASSWORD_IDP, otherwise the authentication policies of the apps require the factors")
+	}
+	prompt := get("mfa_prompt").(string)
+	if mfaRequired && prompt == "" {
+		return errors.New("'mfa_prompt' must be set when 'mfa_required' is true")
+	}
+	if !mfaRequired && prompt != "" {
+		return errors.New("'mfa_prompt' can only be set when 'mfa_required' is true")
+	}
+	if get("mfa_remember_device").(bool) && prompt != "DEVICE" {
+		return errors.New("'mfa_remember_device' can only be set when 'mfa_prompt' is DEVICE")
+	}
+	mfaLifetime := get("mfa_lifetime").(int)
+	if prompt == "SESSION" && mfaLifetime <= 0 {
+		return errors.New("'mfa_lifetime' must be set when 'mfa_prompt' is SESSION")
+	}
+	if prompt != "SESSION" && mfaLifetime != 0 {
+		return errors.New("'mfa_lifetime' can only be set when 'mfa_prompt' is SESSION")
+	}
+	idle, lifetime := get("session_idle").(int), get("session_lifetime").(int)
+	if lifetime > 0 && idle > lifetime {
+		return fmt.Errorf("'session_idle' (%d) can't be greater than 'session_lifetime' (%d)", idle, lifetime)
+	}
+	if get("session_persistent").(bool) && lifetime == 0 {
+		return errors.New("'session_persistent' requires 'session_lifetime' to be set")
+	}
+	if get("identity_provider").(string) == "SPECIFIC_IDP" && len(get("identity_provider_ids").([]interface{})) == 0 {
+		return errors.New("'identity_provider_ids' should have at least one element when 'identity_provider' is 'SPECIFIC_IDP'")
+	}
+	return nil
+}
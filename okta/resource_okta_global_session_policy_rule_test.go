@@ -0,0 +1,105 @@
+package okta
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateGlobalSessionPolicyRule(t *testing.T) {
+	valid := func() map[string]interface{} {
+		return map[string]interface{}{
+			"access":                "ALLOW",
+			"primary_factor":        "PASSWORD_IDP_ANY_FACTOR",
+			"mfa_required":          false,
+			"mfa_prompt":            "",
+			"mfa_remember_device":   false,
+			"mfa_lifetime":          0,
+			"session_idle":          120,
+			"session_lifetime":      0,
+			"session_persistent":    false,
+			"identity_provider":     "ANY",
+			"identity_provider_ids": []interface{}{},
+		}
+	}
+	tests := []struct {
+		name    string
+		changes map[string]interface{}
+		err     string
+	}{
+		{"defaults", nil, ""},
+		{"mfa on session", map[string]interface{}{"primary_factor": "PASSWORD_IDP", "mfa_required": true, "mfa_prompt": "SESSION", "mfa_lifetime": 60}, ""},
+		{"persistent session", map[string]interface{}{"session_lifetime": 720, "session_persistent": true}, ""},
+		{"mfa with any factor", map[string]interface{}{"mfa_required": true, "mfa_prompt": "ALWAYS"}, "'mfa_required'"},
+		{"mfa without prompt", map[string]interface{}{"primary_factor": "PASSWORD_IDP", "mfa_required": true}, "'mfa_prompt'"},
+		{"prompt without mfa", map[string]interface{}{"mfa_prompt": "ALWAYS"}, "'mfa_prompt'"},
+		{"remember device on session", map[string]interface{}{"primary_factor": "PASSWORD_IDP", "mfa_required": true, "mfa_prompt": "SESSION", "mfa_lifetime": 60, "mfa_remember_device": true}, "'mfa_remember_device'"},
+		{"session without lifetime", map[string]interface{}{"primary_factor": "PASSWORD_IDP", "mfa_required": true, "mfa_prompt": "SESSION"}, "'mfa_lifetime'"},
+		{"idle longer than lifetime", map[string]interface{}{"session_lifetime": 60}, "'session_idle'"},
+		{"persistent without lifetime", map[string]interface{}{"session_persistent": true}, "'session_persistent'"},
+		{"specific idp without ids", map[string]interface{}{"identity_provider": "SPECIFIC_IDP"}, "'identity_provider_ids'"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values := valid()
+			for k, v := range tt.changes {
+				values[k] = v
+			}
+			err := validateGlobalSessionPolicyRule(func(k string) interface{} { return values[k] })
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tt.err)
+			}
+		})
+	}
+	ruleSchema := resourceGlobalSessionPolicyRule().Schema
+	assert.True(t, ruleSchema["access"].ValidateDiagFunc("CHALLENGE", cty.GetAttrPath("access")).HasError())
+	assert.True(t, ruleSchema["mfa_prompt"].ValidateDiagFunc("NEVER", cty.GetAttrPath("mfa_prompt")).HasError())
+	assert.False(t, ruleSchema["identity_provider"].ValidateDiagFunc("SPECIFIC_IDP", cty.GetAttrPath("identity_provider")).HasError())
+}
+
+func TestAccOktaGlobalSessionPolicyRule_crud(t *testing.T) {
+	mgr := newFixtureManager(globalSessionPolicyRule, t.Name())
+	config := mgr.GetFixtures("basic.tf", t)
+	updatedConfig := mgr.GetFixtures("updated.tf", t)
+	resourceName := fmt.Sprintf("%s.test", globalSessionPolicyRule)
+
+	oktaResourceTest(t, resource.TestCase{
+		PreCheck:          testAccPreCheck(t),
+		ErrorCheck:        testAccErrorChecks(t),
+		ProviderFactories: testAccProvidersFactories,
+		CheckDestroy:      checkRuleDestroy(globalSessionPolicyRule),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					ensureRuleExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "name", buildResourceName(mgr.Seed)),
+					resource.TestCheckResourceAttr(resourceName, "status", statusActive),
+					resource.TestCheckResourceAttr(resourceName, "access", "ALLOW"),
+					resource.TestCheckResourceAttr(resourceName, "primary_factor", "PASSWORD_IDP_ANY_FACTOR"),
+					resource.TestCheckResourceAttr(resourceName, "session_idle", "60"),
+					resource.TestCheckResourceAttr(resourceName, "session_lifetime", "720"),
+					resource.TestCheckResourceAttr(resourceName, "session_persistent", "true"),
+				),
+			},
+			{
+				Config: updatedConfig,
+				Check: resource.ComposeTestCheckFunc(
+					ensureRuleExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "status", statusInactive),
+					resource.TestCheckResourceAttr(resourceName, "primary_factor", "PASSWORD_IDP"),
+					resource.TestCheckResourceAttr(resourceName, "mfa_required", "true"),
+					resource.TestCheckResourceAttr(resourceName, "mfa_prompt", "SESSION"),
+					resource.TestCheckResourceAttr(resourceName, "mfa_lifetime", "60"),
+					resource.TestCheckResourceAttr(resourceName, "session_lifetime", "0"),
+					resource.TestCheckResourceAttr(resourceName, "session_persistent", "false"),
+				),
+			},
+		},
+	})
+}
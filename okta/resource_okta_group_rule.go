@@ -41,8 +41,9 @@ func resourceGroupRule() *schema.Resource {
 				Optional: true,
 			},
 			"expression_value": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: expressionIsValid("user"),
 			},
 			"status": statusSchema,
 			"remove_assigned_users": {
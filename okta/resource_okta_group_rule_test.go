@@ -6,7 +6,10 @@ import (
 	"regexp"
 	"testing"
 
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestAccOktaGroupRule_crud(t *testing.T) {
@@ -104,6 +107,63 @@ func TestAccOktaGroupRule_invalidHandle(t *testing.T) {
 	})
 }
 
+func TestAccOktaGroupRule_invalidExpression(t *testing.T) {
+	mgr := newFixtureManager(groupRule, t.Name())
+	config := mgr.GetFixtures("invalid_expression.tf", t)
+
+	oktaResourceTest(t, resource.TestCase{
+		PreCheck:          testAccPreCheck(t),
+		ErrorCheck:        testAccErrorChecks(t),
+		ProviderFactories: testAccProvidersFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      config,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`String.startsWith: argument 2 must be a string, got number`),
+			},
+		},
+	})
+}
+
+func TestExpressionIsValid(t *testing.T) {
+	path := cty.GetAttrPath("expression_value")
+	assert.Empty(t, expressionIsValid("user")(`String.startsWith(user.firstName, "andy")`, path))
+	assert.Empty(t, expressionIsValid()(`isMemberOfAnyGroup("00g1") && device.profile.managed`, path))
+
+	diags := expressionIsValid("user")(`String.startsWith(user.firstName, "andy"`, path)
+	if assert.Len(t, diags, 1) {
+		assert.Contains(t, diags[0].Summary, "expected ',' or ')'")
+	}
+	diags = expressionIsValid("user")(`appuser.firstName == "andy"`, path)
+	if assert.Len(t, diags, 1) {
+		assert.Contains(t, diags[0].Summary, "unknown variable 'appuser'")
+	}
+
+	assert.Empty(t, expressionIsValid()(`getFilteredGroups({"00gabc123"}, "group.name", 40)`, path))
+	assert.Empty(t, expressionIsValid()(`user.getGroups({'group.type': {'OKTA_GROUP'}}).![name]`, path))
+	for _, expr := range []string{
+		`user.email matches ".*@x.com"`,
+		`user?.firstName`,
+		`user.getGroups().?[name == 'A']`,
+		`user.firstName instanceof T(String)`,
+		`user.level between {1, 5}`,
+		`1.5e3 > 1`,
+		`#root.user.firstName`,
+	} {
+		assert.Empty(t, expressionIsValid("user")(expr, path), expr)
+	}
+	diags = expressionIsValid("user")(`String.startWith(user.firstName, "andy")`, path)
+	if assert.Len(t, diags, 1) {
+		assert.Equal(t, diag.Warning, diags[0].Severity)
+		assert.Contains(t, diags[0].Summary, "unknown function 'String.startWith'")
+	}
+	diags = expressionIsValid("user")(`user.firstName.capitalize()`, path)
+	if assert.Len(t, diags, 1) {
+		assert.Equal(t, diag.Warning, diags[0].Severity)
+		assert.Contains(t, diags[0].Summary, "unknown method 'capitalize'")
+	}
+}
+
 func buildInvalidBuild(n string) string {
 	return fmt.Sprintf(`
 resource "okta_group" "test" {
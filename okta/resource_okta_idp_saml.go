@@ -3,10 +3,15 @@ package okta
 import (
 	"context"
 	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/okta/terraform-provider-okta/sdk"
+	"github.com/okta/terraform-provider-okta/sdk/query"
 )
 
 func resourceIdpSaml() *schema.Resource {
@@ -14,10 +19,11 @@ func resourceIdpSaml() *schema.Resource {
 		CreateContext: resourceIdpSamlCreate,
 		ReadContext:   resourceIdpSamlRead,
 		UpdateContext: resourceIdpSamlUpdate,
-		DeleteContext: resourceIdpDelete,
+		DeleteContext: resourceIdpSamlDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: idpSamlMetadataCustomizeDiff,
 		Schema: buildIdpSchema(map[string]*schema.Schema{
 			"type": {
 				Type:     schema.TypeString,
@@ -32,9 +38,26 @@ func resourceIdpSaml() *schema.Resource {
 				Optional: true,
 				Default:  "INSTANCE",
 			},
+			"metadata_xml": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: samlIdpMetadataIsValid,
+				ConflictsWith:    []string{"metadata_file"},
+				Description:      "Metadata of the IdP, sso_url, issuer and kid are derived from it",
+			},
+			"metadata_file": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: samlIdpMetadataFileIsValid,
+				StateFunc:        localFileStateFunc,
+				ConflictsWith:    []string{"metadata_xml"},
+				Description:      "Local path to the metadata of the IdP, sso_url, issuer and kid are derived from it",
+			},
 			"sso_url": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"metadata_xml", "metadata_file"},
 			},
 			"sso_binding": {
 				Type:     schema.TypeString,
@@ -60,8 +83,10 @@ func resourceIdpSaml() *schema.Resource {
 				Optional: true,
 			},
 			"issuer": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"metadata_xml", "metadata_file"},
 			},
 			"issuer_mode": issuerMode,
 			"audience": {
@@ -69,8 +94,15 @@ func resourceIdpSaml() *schema.Resource {
 				Computed: true,
 			},
 			"kid": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"metadata_xml", "metadata_file"},
+			},
+			"kid_created": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the resource created the signing key of kid from the metadata, only such a key is deleted by the resource",
 			},
 			"max_clock_skew": {
 				Type:     schema.TypeInt,
@@ -89,6 +121,13 @@ func resourceIdpSaml() *schema.Resource {
 }
 
 func resourceIdpSamlCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if hasIdpSamlMetadata(d) {
+		if err := applyIdpSamlMetadata(ctx, d, m); err != nil {
+			return diag.FromErr(err)
+		}
+	} else {
+		_ = d.Set("kid_created", false)
+	}
 	idp, err := buildIdPSaml(d)
 	if err != nil {
 		return diag.FromErr(err)
@@ -163,6 +202,14 @@ func resourceIdpSamlRead(ctx context.Context, d *schema.ResourceData, m interfac
 }
 
 func resourceIdpSamlUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	createdKid := idpSamlCreatedKid(d)
+	if hasIdpSamlMetadata(d) && d.HasChanges("metadata_xml", "metadata_file", "sso_binding") {
+		if err := applyIdpSamlMetadata(ctx, d, m); err != nil {
+			return diag.FromErr(err)
+		}
+	} else {
+		_ = d.Set("kid_created", createdKid != "" && createdKid == d.Get("kid").(string))
+	}
 	idp, err := buildIdPSaml(d)
 	if err != nil {
 		return diag.FromErr(err)
@@ -171,6 +218,11 @@ func resourceIdpSamlUpdate(ctx context.Context, d *schema.ResourceData, m interf
 	if err != nil {
 		return diag.Errorf("failed to update SAML identity provider: %v", err)
 	}
+	if createdKid != "" && createdKid != idp.Protocol.Credentials.Trust.Kid {
+		if err := deleteUnusedIdpKey(ctx, m, createdKid); err != nil {
+			return diag.Errorf("failed to delete the previous signing key of SAML identity provider: %v", err)
+		}
+	}
 	err = setIdpStatus(ctx, d, getOktaClientFromMetadata(m), idp.Status)
 	if err != nil {
 		return diag.Errorf("failed to update SAML identity provider's status: %v", err)
@@ -178,6 +230,171 @@ func resourceIdpSamlUpdate(ctx context.Context, d *schema.ResourceData, m interf
 	return resourceIdpSamlRead(ctx, d, m)
 }
 
+func resourceIdpSamlDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if diags := resourceIdpDelete(ctx, d, m); diags.HasError() {
+		return diags
+	}
+	if d.Get("kid_created").(bool) {
+		if err := deleteUnusedIdpKey(ctx, m, d.Get("kid").(string)); err != nil {
+			return diag.Errorf("failed to delete the signing key of SAML identity provider: %v", err)
+		}
+	}
+	return nil
+}
+
+// idpSamlMetadataCustomizeDiff requires sso_url, issuer and kid unless they
+// are derived from the metadata, in which case they are known after apply
+// whenever the metadata changes.
+func idpSamlMetadataCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	config := d.GetRawConfig()
+	if config.GetAttr("metadata_xml").IsNull() && config.GetAttr("metadata_file").IsNull() {
+		for _, k := range []string{"sso_url", "issuer", "kid"} {
+			if config.GetAttr(k).IsNull() {
+				return fmt.Errorf("'%s' is required unless 'metadata_xml' or 'metadata_file' is set", k)
+			}
+		}
+		if d.HasChange("kid") {
+			return d.SetNewComputed("kid_created")
+		}
+		return nil
+	}
+	if d.HasChanges("metadata_xml", "metadata_file", "sso_binding") || !d.NewValueKnown("metadata_xml") || !d.NewValueKnown("metadata_file") {
+		for _, k := range []string{"sso_url", "issuer", "kid", "kid_created"} {
+			if err := d.SetNewComputed(k); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func hasIdpSamlMetadata(d *schema.ResourceData) bool {
+	return d.Get("metadata_xml").(string) != "" || d.Get("metadata_file").(string) != ""
+}
+
+// idpSamlCreatedKid returns the kid of the prior state if the resource created
+// the key, an empty string otherwise.
+func idpSamlCreatedKid(d *schema.ResourceData) string {
+	oldKid, _ := d.GetChange("kid")
+	oldCreated, _ := d.GetChange("kid_created")
+	if !oldCreated.(bool) {
+		return ""
+	}
+	return oldKid.(string)
+}
+
+// applyIdpSamlMetadata sets sso_url, issuer and kid from the metadata. The
+// signing certificate of the metadata becomes an IdP key, an existing key
+// with the same certificate is reused and kid_created tells whether the
+// resource created it.
+func applyIdpSamlMetadata(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	raw := []byte(d.Get("metadata_xml").(string))
+	if path := idpSamlMetadataFile(d); path != "" {
+		var err error
+		raw, err = os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read SAML identity provider metadata: %w", err)
+		}
+	}
+	metadata, err := parseSamlIdpMetadata(raw)
+	if err != nil {
+		return fmt.Errorf("invalid SAML identity provider metadata: %w", err)
+	}
+	binding := d.Get("sso_binding").(string)
+	ssoURL, ok := metadata.ssoURLs[binding]
+	if !ok {
+		var bindings []string
+		for b := range metadata.ssoURLs {
+			bindings = append(bindings, b)
+		}
+		sort.Strings(bindings)
+		return fmt.Errorf("metadata has no %s SingleSignOnService, set 'sso_binding' to %s", binding, strings.Join(bindings, " or "))
+	}
+	kid, created, err := ensureIdpKey(ctx, m, metadata.signingCertificate)
+	if err != nil {
+		return fmt.Errorf("failed to create the signing key of SAML identity provider: %w", err)
+	}
+	createdKid := idpSamlCreatedKid(d)
+	_ = d.Set("sso_url", ssoURL)
+	_ = d.Set("issuer", metadata.entityID)
+	_ = d.Set("kid", kid)
+	_ = d.Set("kid_created", created || (createdKid != "" && createdKid == kid))
+	return nil
+}
+
+// idpSamlMetadataFile returns the path of metadata_file. The state holds the
+// hash of the file, so the path comes from the configuration unless it changed.
+func idpSamlMetadataFile(d *schema.ResourceData) string {
+	if d.HasChange("metadata_file") {
+		return d.Get("metadata_file").(string)
+	}
+	config := d.GetRawConfig()
+	if config.IsNull() || !config.IsKnown() {
+		return ""
+	}
+	if path := config.GetAttr("metadata_file"); !path.IsNull() && path.IsKnown() {
+		return path.AsString()
+	}
+	return ""
+}
+
+// ensureIdpKey returns the ID of the IdP key of the certificate, creating the
+// key if there is none, and whether it created the key.
+func ensureIdpKey(ctx context.Context, m interface{}, cert string) (string, bool, error) {
+	client := getOktaClientFromMetadata(m)
+	keys, resp, err := client.IdentityProvider.ListIdentityProviderKeys(ctx, &query.Params{Limit: defaultPaginationLimit})
+	if err != nil {
+		return "", false, err
+	}
+	for resp.HasNextPage() {
+		var nextKeys []*sdk.JsonWebKey
+		resp, err = resp.Next(ctx, &nextKeys)
+		if err != nil {
+			return "", false, err
+		}
+		keys = append(keys, nextKeys...)
+	}
+	thumbprint := x5tS256(cert)
+	for _, key := range keys {
+		if key.X5tS256 == thumbprint {
+			return key.Kid, false, nil
+		}
+	}
+	key, _, err := client.IdentityProvider.CreateIdentityProviderKey(ctx, sdk.JsonWebKey{X5c: []string{cert}})
+	if err != nil {
+		return "", false, err
+	}
+	return key.Kid, true, nil
+}
+
+// deleteUnusedIdpKey deletes the IdP key unless a SAML IdP still trusts it.
+func deleteUnusedIdpKey(ctx context.Context, m interface{}, kid string) error {
+	if kid == "" {
+		return nil
+	}
+	client := getOktaClientFromMetadata(m)
+	idps, resp, err := client.IdentityProvider.ListIdentityProviders(ctx, &query.Params{Limit: defaultPaginationLimit, Type: saml2Idp})
+	if err != nil {
+		return err
+	}
+	for resp.HasNextPage() {
+		var nextIdps []*sdk.IdentityProvider
+		resp, err = resp.Next(ctx, &nextIdps)
+		if err != nil {
+			return err
+		}
+		idps = append(idps, nextIdps...)
+	}
+	for _, idp := range idps {
+		if idp.Protocol != nil && idp.Protocol.Credentials != nil && idp.Protocol.Credentials.Trust != nil &&
+			idp.Protocol.Credentials.Trust.Kid == kid {
+			return nil
+		}
+	}
+	resp, err = client.IdentityProvider.DeleteIdentityProviderKey(ctx, kid)
+	return suppressErrorOn404(resp, err)
+}
+
 func buildIdPSaml(d *schema.ResourceData) (sdk.IdentityProvider, error) {
 	if d.Get("subject_match_type").(string) != "CUSTOM_ATTRIBUTE" &&
 		len(d.Get("subject_match_attribute").(string)) > 0 {
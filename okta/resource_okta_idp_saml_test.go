@@ -1,11 +1,20 @@
 package okta
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"os"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/okta/terraform-provider-okta/sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAccOktaIdpSaml_crud(t *testing.T) {
@@ -128,3 +137,142 @@ resource "okta_idp_saml" "test" {
 		},
 	})
 }
+
+func TestAccOktaIdpSaml_metadata(t *testing.T) {
+	mgr := newFixtureManager(idpSaml, t.Name())
+	fileConfig := mgr.GetFixtures("metadata_file.tf", t)
+	fileRedirectConfig := mgr.GetFixtures("metadata_file_redirect.tf", t)
+	xmlConfig := mgr.GetFixtures("metadata_xml.tf", t)
+	resourceName := fmt.Sprintf("%s.test", idpSaml)
+
+	oktaResourceTest(t, resource.TestCase{
+		PreCheck:          testAccPreCheck(t),
+		ErrorCheck:        testAccErrorChecks(t),
+		ProviderFactories: testAccProvidersFactories,
+		CheckDestroy:      checkResourceDestroy(idpSaml, createDoesIdpExist),
+		Steps: []resource.TestStep{
+			{
+				Config: fileConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "sso_url", "https://idp.example.com/sso/post"),
+					resource.TestCheckResourceAttr(resourceName, "sso_binding", "HTTP-POST"),
+					resource.TestCheckResourceAttr(resourceName, "issuer", "https://idp.example.com/metadata"),
+					resource.TestCheckResourceAttrSet(resourceName, "kid"),
+					resource.TestCheckResourceAttr(resourceName, "kid_created", "true"),
+				),
+			},
+			{
+				// only sso_binding changes, the metadata is read from the file again
+				Config: fileRedirectConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "sso_url", "https://idp.example.com/sso/redirect"),
+					resource.TestCheckResourceAttr(resourceName, "sso_binding", "HTTP-REDIRECT"),
+					resource.TestCheckResourceAttr(resourceName, "issuer", "https://idp.example.com/metadata"),
+					resource.TestCheckResourceAttrSet(resourceName, "kid"),
+				),
+			},
+			{
+				Config: xmlConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceName, "sso_url", "okta_app_saml.test", "http_redirect_binding"),
+					resource.TestCheckResourceAttr(resourceName, "sso_binding", "HTTP-REDIRECT"),
+					resource.TestCheckResourceAttrPair(resourceName, "issuer", "okta_app_saml.test", "entity_url"),
+					resource.TestCheckResourceAttrSet(resourceName, "kid"),
+				),
+			},
+		},
+	})
+}
+
+func TestApplyIdpSamlMetadata(t *testing.T) {
+	raw, err := os.ReadFile("../examples/okta_idp_saml/idp_metadata.xml")
+	require.NoError(t, err)
+	metadata, err := parseSamlIdpMetadata(raw)
+	require.NoError(t, err)
+
+	var keys []*sdk.JsonWebKey
+	created := 0
+	ctx, m := newTestConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/idps/credentials/keys":
+			_ = json.NewEncoder(w).Encode(keys)
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/idps/credentials/keys":
+			var key sdk.JsonWebKey
+			_ = json.NewDecoder(r.Body).Decode(&key)
+			created++
+			key.Kid = fmt.Sprintf("key%d", created)
+			key.X5tS256 = x5tS256(key.X5c[0])
+			keys = append(keys, &key)
+			_ = json.NewEncoder(w).Encode(key)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	newIdp := func(binding string) *schema.ResourceData {
+		return schema.TestResourceDataRaw(t, resourceIdpSaml().Schema, map[string]interface{}{
+			"name":         "test",
+			"metadata_xml": string(raw),
+			"sso_binding":  binding,
+		})
+	}
+
+	d := newIdp(redirectBindingAlias)
+	require.NoError(t, applyIdpSamlMetadata(ctx, d, m))
+	assert.Equal(t, "https://idp.example.com/sso/redirect", d.Get("sso_url"))
+	assert.Equal(t, "https://idp.example.com/metadata", d.Get("issuer"))
+	assert.Equal(t, "key1", d.Get("kid"))
+	assert.True(t, d.Get("kid_created").(bool))
+	assert.Equal(t, []string{metadata.signingCertificate}, keys[0].X5c)
+
+	// the key of the certificate is reused
+	d = newIdp(postBindingAlias)
+	require.NoError(t, applyIdpSamlMetadata(ctx, d, m))
+	assert.Equal(t, "https://idp.example.com/sso/post", d.Get("sso_url"))
+	assert.Equal(t, "key1", d.Get("kid"))
+	assert.False(t, d.Get("kid_created").(bool))
+	assert.Equal(t, 1, created)
+
+	err = applyIdpSamlMetadata(ctx, newIdp("HTTP-ARTIFACT"), m)
+	assert.EqualError(t, err, "metadata has no HTTP-ARTIFACT SingleSignOnService, set 'sso_binding' to HTTP-POST or HTTP-REDIRECT")
+}
+
+func TestDeleteUnusedIdpKey(t *testing.T) {
+	var deleted []string
+	ctx, m := newTestConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/idps" && r.URL.Query().Get("after") == "idp1":
+			_, _ = w.Write([]byte(`[{"id":"idp2","type":"SAML2","protocol":{"credentials":{"trust":{"kid":"key2"}}}}]`))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/idps":
+			w.Header().Set("Link", nextPageLink(r, "idp1"))
+			_, _ = w.Write([]byte(`[{"id":"idp1","type":"SAML2","protocol":{"credentials":{"trust":{"kid":"key1"}}}}]`))
+		case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/api/v1/idps/credentials/keys/"):
+			deleted = append(deleted, strings.TrimPrefix(r.URL.Path, "/api/v1/idps/credentials/keys/"))
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	// key2 is trusted by an IdP of the second page
+	require.NoError(t, deleteUnusedIdpKey(ctx, m, "key2"))
+	assert.Empty(t, deleted)
+	require.NoError(t, deleteUnusedIdpKey(ctx, m, "key3"))
+	assert.Equal(t, []string{"key3"}, deleted)
+}
+
+func TestIdpSamlCreatedKid(t *testing.T) {
+	newIdp := func(created bool) *schema.ResourceData {
+		return resourceIdpSaml().Data(&terraform.InstanceState{
+			ID: "idp1",
+			Attributes: map[string]string{
+				"kid":         "key1",
+				"kid_created": fmt.Sprintf("%t", created),
+			},
+		})
+	}
+	assert.Equal(t, "key1", idpSamlCreatedKid(newIdp(true)))
+	// e.g. an imported IdP or a key reused from another IdP
+	assert.Empty(t, idpSamlCreatedKid(newIdp(false)))
+}
@@ -0,0 +1,87 @@
+package okta
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/okta/terraform-provider-okta/sdk"
+)
+
+func resourceIdpUserLink() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceIdpUserLinkCreate,
+		ReadContext:   resourceIdpUserLinkRead,
+		DeleteContext: resourceIdpUserLinkDelete,
+		Importer:      createNestedResourceImporter([]string{"idp_id", "id"}),
+		Schema: map[string]*schema.Schema{
+			"idp_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the social or OIDC IdP",
+			},
+			"user_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the Okta user",
+			},
+			"external_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the user at the IdP, the subject of the IdP tokens",
+			},
+			"created": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "When the user was linked",
+			},
+		},
+	}
+}
+
+func resourceIdpUserLinkCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := getOktaClientFromMetadata(m)
+	idpID := d.Get("idp_id").(string)
+	idp, _, err := client.IdentityProvider.GetIdentityProvider(ctx, idpID)
+	if err != nil {
+		return diag.Errorf("failed to get identity provider: %v", err)
+	}
+	if idp.Type == saml2Idp {
+		return diag.Errorf("users can't be linked to the %s identity provider '%s', only to social and OIDC identity providers", saml2Idp, idpID)
+	}
+	userID := d.Get("user_id").(string)
+	_, _, err = client.IdentityProvider.LinkUserToIdentityProvider(ctx, idpID, userID, sdk.UserIdentityProviderLinkRequest{
+		ExternalId: d.Get("external_id").(string),
+	})
+	if err != nil {
+		return diag.Errorf("failed to link user '%s' to identity provider: %v", userID, err)
+	}
+	d.SetId(userID)
+	return resourceIdpUserLinkRead(ctx, d, m)
+}
+
+func resourceIdpUserLinkRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	user, resp, err := getOktaClientFromMetadata(m).IdentityProvider.GetIdentityProviderApplicationUser(ctx, d.Get("idp_id").(string), d.Id())
+	if err := suppressErrorOn404(resp, err); err != nil {
+		return diag.Errorf("failed to get identity provider user: %v", err)
+	}
+	if user == nil {
+		d.SetId("")
+		return nil
+	}
+	_ = d.Set("user_id", d.Id())
+	_ = d.Set("external_id", user.ExternalId)
+	_ = d.Set("created", user.Created)
+	return nil
+}
+
+func resourceIdpUserLinkDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	resp, err := getOktaClientFromMetadata(m).IdentityProvider.UnlinkUserFromIdentityProvider(ctx, d.Get("idp_id").(string), d.Id())
+	if err := suppressErrorOn404(resp, err); err != nil {
+		return diag.Errorf("failed to unlink user from identity provider: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package okta
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/okta/terraform-provider-okta/sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccOktaIdpUserLink_crud(t *testing.T) {
+	resourceName := fmt.Sprintf("%s.test", idpUserLink)
+	mgr := newFixtureManager(idpUserLink, t.Name())
+	config := mgr.GetFixtures("basic.tf", t)
+
+	oktaResourceTest(t, resource.TestCase{
+		PreCheck:          testAccPreCheck(t),
+		ErrorCheck:        testAccErrorChecks(t),
+		ProviderFactories: testAccProvidersFactories,
+		CheckDestroy:      checkResourceDestroy(idpSocial, createDoesIdpExist),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceName, "id", "okta_user.test", "id"),
+					resource.TestCheckResourceAttr(resourceName, "external_id", "118296312345678901234"),
+					resource.TestCheckResourceAttrSet(resourceName, "created"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs := s.RootModule().Resources[resourceName]
+					return fmt.Sprintf("%s/%s", rs.Primary.Attributes["idp_id"], rs.Primary.ID), nil
+				},
+			},
+		},
+	})
+}
+
+func TestIdpUserLinkCRUD(t *testing.T) {
+	var linked *sdk.IdentityProviderApplicationUser
+	ctx, m := newTestConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/idps/0oa1":
+			_, _ = w.Write([]byte(`{"id":"0oa1","type":"GOOGLE"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/idps/0oa2":
+			_, _ = w.Write([]byte(`{"id":"0oa2","type":"SAML2"}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/idps/0oa1/users/00u1":
+			linked = &sdk.IdentityProviderApplicationUser{}
+			_ = json.NewDecoder(r.Body).Decode(linked)
+			linked.Id, linked.Created = "00u1", "2023-06-01T12:00:00.000Z"
+			_ = json.NewEncoder(w).Encode(linked)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/idps/0oa1/users/00u1" && linked != nil:
+			_ = json.NewEncoder(w).Encode(linked)
+		case r.Method == http.MethodDelete && r.URL.Path == "/api/v1/idps/0oa1/users/00u1":
+			linked = nil
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errorCode":"E0000007","errorSummary":"Not found"}`))
+		}
+	})
+	newLink := func(idpID string) *schema.ResourceData {
+		return schema.TestResourceDataRaw(t, resourceIdpUserLink().Schema, map[string]interface{}{
+			"idp_id":      idpID,
+			"user_id":     "00u1",
+			"external_id": "1182963",
+		})
+	}
+
+	d := newLink("0oa1")
+	require.Empty(t, resourceIdpUserLinkCreate(ctx, d, m))
+	assert.Equal(t, "00u1", d.Id())
+	assert.Equal(t, "1182963", linked.ExternalId)
+	assert.Equal(t, "2023-06-01T12:00:00.000Z", d.Get("created"))
+
+	require.Empty(t, resourceIdpUserLinkDelete(ctx, d, m))
+	assert.Nil(t, linked)
+	require.Empty(t, resourceIdpUserLinkRead(ctx, d, m))
+	assert.Empty(t, d.Id())
+
+	diags := resourceIdpUserLinkCreate(ctx, newLink("0oa2"), m)
+	require.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Summary, "only to social and OIDC identity providers")
+}
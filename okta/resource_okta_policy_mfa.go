@@ -19,7 +19,8 @@ func resourcePolicyMfa() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
-		Schema: buildMfaPolicySchema(buildFactorSchemaProviders()),
+		Timeouts: policyCreateTimeouts(),
+		Schema:   buildMfaPolicySchema(buildFactorSchemaProviders()),
 	}
 }
 
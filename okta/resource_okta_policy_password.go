@@ -17,6 +17,7 @@ func resourcePolicyPassword() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: policyCreateTimeouts(),
 		Schema: buildPolicySchema(map[string]*schema.Schema{
 			"auth_provider": {
 				Type:        schema.TypeString,
@@ -124,6 +125,21 @@ func resourcePolicyPassword() *schema.Resource {
 				Description: "Notification channels to use to notify a user when their account has been locked.",
 				Elem:        &schema.Schema{Type: schema.TypeString},
 			},
+			"password_breached_protection_expire_after_days": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Number of days after which the password of a user is expired when it's found in a breach: 0 = expire immediately, unset = never expire.",
+			},
+			"password_breached_protection_logout_enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "If the sessions of a user should be ended when their password is found in a breach.",
+			},
+			"password_breached_protection_workflow_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "ID of the delegated Okta Workflow invoked when the password of a user is found in a breach.",
+			},
 			"question_min_length": {
 				Type:        schema.TypeInt,
 				Optional:    true,
@@ -237,6 +253,7 @@ func resourcePolicyPasswordRead(ctx context.Context, d *schema.ResourceData, m i
 			_ = d.Set("password_auto_unlock_minutes", *policy.Settings.Password.Lockout.AutoUnlockMinutesPtr)
 		}
 		_ = d.Set("password_show_lockout_failures", policy.Settings.Password.Lockout.ShowLockoutFailures)
+		syncPasswordBreachedProtection(d, policy.Settings.Password.BreachedProtection)
 		if policy.Settings.Recovery.Factors.RecoveryQuestion.Properties.Complexity.MinLengthPtr != nil {
 			_ = d.Set("question_min_length", *policy.Settings.Recovery.Factors.RecoveryQuestion.Properties.Complexity.MinLengthPtr)
 		}
@@ -332,6 +349,7 @@ func buildPasswordPolicy(d *schema.ResourceData) sdk.SdkPolicy {
 				ShowLockoutFailures:             boolPtr(d.Get("password_show_lockout_failures").(bool)),
 				UserLockoutNotificationChannels: convertInterfaceToStringSet(d.Get("password_lockout_notification_channels")),
 			},
+			BreachedProtection: buildPasswordBreachedProtection(d),
 		},
 		Recovery: &sdk.PasswordPolicyRecoverySettings{
 			Factors: &sdk.PasswordPolicyRecoveryFactors{
@@ -378,3 +396,34 @@ func getExcludedAttrs(excludeFirstName, excludeLastName bool) []string {
 	}
 	return excludedAttrs
 }
+
+// buildPasswordBreachedProtection returns the breached password protection
+// settings, nil when none of them is configured.
+func buildPasswordBreachedProtection(d *schema.ResourceData) *sdk.PasswordPolicyPasswordSettingsBreachedProtection {
+	protection := sdk.NewPasswordPolicyPasswordSettingsBreachedProtection()
+	// 0 expires the password immediately, only an unset value means never
+	if v := d.GetRawConfig().GetAttr("password_breached_protection_expire_after_days"); !v.IsNull() {
+		protection.ExpireAfterDaysPtr = int64Ptr(d.Get("password_breached_protection_expire_after_days").(int))
+	}
+	if v := d.GetRawConfig().GetAttr("password_breached_protection_logout_enabled"); !v.IsNull() {
+		protection.LogoutEnabled = boolPtr(v.True())
+	}
+	protection.DelegatedWorkflowId = d.Get("password_breached_protection_workflow_id").(string)
+	if protection.ExpireAfterDaysPtr == nil && protection.LogoutEnabled == nil && protection.DelegatedWorkflowId == "" {
+		return nil
+	}
+	return protection
+}
+
+func syncPasswordBreachedProtection(d *schema.ResourceData, protection *sdk.PasswordPolicyPasswordSettingsBreachedProtection) {
+	if protection == nil {
+		protection = sdk.NewPasswordPolicyPasswordSettingsBreachedProtection()
+	}
+	if protection.ExpireAfterDaysPtr != nil {
+		_ = d.Set("password_breached_protection_expire_after_days", *protection.ExpireAfterDaysPtr)
+	} else {
+		_ = d.Set("password_breached_protection_expire_after_days", nil)
+	}
+	_ = d.Set("password_breached_protection_logout_enabled", protection.LogoutEnabled)
+	_ = d.Set("password_breached_protection_workflow_id", protection.DelegatedWorkflowId)
+}
@@ -132,6 +132,21 @@ func resourcePolicyPasswordDefault() *schema.Resource {
 				Description: "Notification channels to use to notify a user when their account has been locked.",
 				Elem:        &schema.Schema{Type: schema.TypeString},
 			},
+			"password_breached_protection_expire_after_days": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Number of days after which the password of a user is expired when it's found in a breach: 0 = expire immediately, unset = never expire.",
+			},
+			"password_breached_protection_logout_enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "If the sessions of a user should be ended when their password is found in a breach.",
+			},
+			"password_breached_protection_workflow_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "ID of the delegated Okta Workflow invoked when the password of a user is found in a breach.",
+			},
 			"question_min_length": {
 				Type:        schema.TypeInt,
 				Optional:    true,
@@ -245,6 +260,7 @@ func resourcePolicyPasswordDefaultRead(ctx context.Context, d *schema.ResourceDa
 		_ = d.Set("password_auto_unlock_minutes", *policy.Settings.Password.Lockout.AutoUnlockMinutesPtr)
 	}
 	_ = d.Set("password_show_lockout_failures", policy.Settings.Password.Lockout.ShowLockoutFailures)
+	syncPasswordBreachedProtection(d, policy.Settings.Password.BreachedProtection)
 	if policy.Settings.Recovery.Factors.RecoveryQuestion.Properties.Complexity.MinLengthPtr != nil {
 		_ = d.Set("question_min_length", *policy.Settings.Recovery.Factors.RecoveryQuestion.Properties.Complexity.MinLengthPtr)
 	}
@@ -314,6 +330,7 @@ func buildDefaultPasswordPolicy(d *schema.ResourceData) sdk.SdkPolicy {
 				ShowLockoutFailures:             boolPtr(d.Get("password_show_lockout_failures").(bool)),
 				UserLockoutNotificationChannels: convertInterfaceToStringSet(d.Get("password_lockout_notification_channels")),
 			},
+			BreachedProtection: buildPasswordBreachedProtection(d),
 		},
 		Recovery: &sdk.PasswordPolicyRecoverySettings{
 			Factors: &sdk.PasswordPolicyRecoveryFactors{
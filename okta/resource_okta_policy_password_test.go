@@ -94,6 +94,31 @@ func TestAccOktaPolicyPassword_crud(t *testing.T) {
 	})
 }
 
+func TestAccOktaPolicyPassword_breachedProtection(t *testing.T) {
+	mgr := newFixtureManager(policyPassword, t.Name())
+	config := mgr.GetFixtures("breached_protection.tf", t)
+	resourceName := fmt.Sprintf("%s.test", policyPassword)
+
+	oktaResourceTest(t, resource.TestCase{
+		PreCheck:          testAccPreCheck(t),
+		ErrorCheck:        testAccErrorChecks(t),
+		ProviderFactories: testAccProvidersFactories,
+		CheckDestroy:      checkPolicyDestroy(policyPassword),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					ensurePolicyExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "password_dictionary_lookup", "true"),
+					resource.TestCheckResourceAttr(resourceName, "password_breached_protection_expire_after_days", "0"),
+					resource.TestCheckResourceAttr(resourceName, "password_breached_protection_logout_enabled", "true"),
+					resource.TestCheckResourceAttr(resourceName, "password_breached_protection_workflow_id", ""),
+				),
+			},
+		},
+	})
+}
+
 func ensurePolicyExists(resourceName string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		missingErr := fmt.Errorf("resource not found: %s", resourceName)
@@ -0,0 +1,75 @@
+package okta
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourcePolicyRestore() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePolicyRestoreCreate,
+		ReadContext:   resourcePolicyRestoreRead,
+		UpdateContext: resourcePolicyRestoreCreate,
+		DeleteContext: resourceFuncNoOp,
+		CustomizeDiff: func(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+			if !d.NewValueKnown("json") {
+				return nil
+			}
+			_, err := parsePolicySnapshot(d.Get("json").(string))
+			return err
+		},
+		Schema: map[string]*schema.Schema{
+			"json": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: stringIsJSON,
+				StateFunc:        normalizeDataJSON,
+				Description:      "JSON of the policy and its rules, as exported by okta_policy_export",
+			},
+			"policy_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the restored policy",
+			},
+			"rule_ids": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "IDs of the restored rules by name",
+			},
+		},
+	}
+}
+
+func resourcePolicyRestoreCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	snapshot, err := parsePolicySnapshot(d.Get("json").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	policyID, ruleIDs, err := restorePolicy(ctx, m, snapshot)
+	if policyID != "" {
+		// the policy is under Terraform's control even if its rules failed
+		d.SetId(policyID)
+		_ = d.Set("policy_id", policyID)
+	}
+	if err != nil {
+		return diag.Errorf("failed to restore policy: %v", err)
+	}
+	_ = d.Set("rule_ids", ruleIDs)
+	return resourcePolicyRestoreRead(ctx, d, m)
+}
+
+func resourcePolicyRestoreRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	policy, resp, err := getAPISupplementFromMetadata(m).GetPolicyJSON(ctx, d.Id())
+	if err := suppressErrorOn404(resp, err); err != nil {
+		return diag.Errorf("failed to get restored policy: %v", err)
+	}
+	if policy == nil {
+		d.SetId("")
+		return nil
+	}
+	_ = d.Set("policy_id", d.Id())
+	return nil
+}
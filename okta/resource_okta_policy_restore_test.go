@@ -0,0 +1,33 @@
+package okta
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccOktaPolicyRestore_crud(t *testing.T) {
+	mgr := newFixtureManager(policyRestore, t.Name())
+	config := mgr.GetFixtures("basic.tf", t)
+	resourceName := fmt.Sprintf("%s.test", policyRestore)
+
+	// NOTE destroying okta_policy_restore keeps the restored policy, it's
+	// removed by the sweeper
+	oktaResourceTest(t, resource.TestCase{
+		PreCheck:          testAccPreCheck(t),
+		ErrorCheck:        testAccErrorChecks(t),
+		ProviderFactories: testAccProvidersFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					ensurePolicyExists(resourceName),
+					resource.TestCheckResourceAttrSet(resourceName, "policy_id"),
+					resource.TestCheckResourceAttr(resourceName, "rule_ids.%", "1"),
+					resource.TestCheckResourceAttrSet(resourceName, fmt.Sprintf("rule_ids.%s", buildResourceName(mgr.Seed))),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,305 @@
+package okta
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/okta/okta-sdk-golang/v3/okta"
+)
+
+func resourcePolicyRule() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePolicyRuleCreate,
+		ReadContext:   resourcePolicyRuleRead,
+		UpdateContext: resourcePolicyRuleUpdate,
+		DeleteContext: resourcePolicyRuleDelete,
+		Importer:      createPolicyRuleImporter(),
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+			for _, k := range []string{"type", "name", "conditions", "actions"} {
+				if !d.NewValueKnown(k) {
+					return nil
+				}
+			}
+			_, err := buildGenericPolicyRule(d.Get("type").(string), d.Get("name").(string), 0,
+				d.Get("conditions").(string), d.Get("actions").(string))
+			return err
+		},
+		Schema: map[string]*schema.Schema{
+			"policy_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the policy of the rule",
+			},
+			"type": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Type of the rule, it has to match the type of the policy: ACCESS_POLICY, PASSWORD, PROFILE_ENROLLMENT or SIGN_ON.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Policy Rule Name",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     statusActive,
+				Description: "Policy Rule Status: ACTIVE or INACTIVE.",
+			},
+			"priority": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Policy Rule Priority, this attribute can be set to a valid priority. To avoid endless diff situation we error if an invalid priority is provided. API defaults it to the last (lowest) if not there. Leave it unset when the rules of the policy are ordered with okta_policy_rule_order.",
+				// Suppress diff if config is empty.
+				DiffSuppressFunc: createValueDiffSuppression("0"),
+			},
+			"conditions": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ValidateDiagFunc: stringIsJSON,
+				StateFunc:        normalizeDataJSON,
+				Description:      "JSON of the conditions of the rule as expected by the Okta API. Only the configured attributes are compared with the ones returned by Okta, all of them are read when it isn't set.",
+			},
+			"actions": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ValidateDiagFunc: stringIsJSON,
+				StateFunc:        normalizeDataJSON,
+				Description:      "JSON of the actions of the rule as expected by the Okta API. Only the configured attributes are compared with the ones returned by Okta, all of them are read when it isn't set.",
+			},
+		},
+	}
+}
+
+func resourcePolicyRuleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := ensureNotDefaultRule(d); err != nil {
+		return diag.FromErr(err)
+	}
+	rule, err := buildPolicyRuleV3(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	policyID := d.Get("policy_id").(string)
+	logger(m).Info("creating policy rule", "policy_id", policyID, "name", d.Get("name").(string))
+	// creating a rule shifts the priorities of the other rules of the policy
+	oktaMutexKV.Lock(policyID)
+	defer oktaMutexKV.Unlock(policyID)
+	created, _, err := getOktaV3ClientFromMetadata(m).PolicyApi.CreatePolicyRule(ctx, policyID).PolicyRule(*rule).Execute()
+	if err != nil {
+		return diag.Errorf("failed to create policy rule: %v", err)
+	}
+	raw, err := genericPolicyRuleMap(created)
+	if err != nil {
+		return diag.Errorf("failed to read created policy rule: %v", err)
+	}
+	d.SetId(getMapString(raw, "id"))
+	if err = policyRuleActivateV3(ctx, d, m); err != nil {
+		return diag.FromErr(err)
+	}
+	// We want to put this under Terraform's control even if priority is invalid.
+	if err = validatePriority(int64(d.Get("priority").(int)), mapPriority(raw)); err != nil {
+		return diag.FromErr(err)
+	}
+	return resourcePolicyRuleRead(ctx, d, m)
+}
+
+func resourcePolicyRuleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	logger(m).Info("reading policy rule", "id", d.Id(), "policy_id", d.Get("policy_id").(string))
+	rule, resp, err := getOktaV3ClientFromMetadata(m).PolicyApi.GetPolicyRule(ctx, d.Get("policy_id").(string), d.Id()).Execute()
+	if err := v3suppressErrorOn404(resp, err); err != nil {
+		return diag.Errorf("failed to get policy rule: %v", err)
+	}
+	if rule == nil || rule.GetActualInstance() == nil {
+		d.SetId("")
+		return nil
+	}
+	raw, err := genericPolicyRuleMap(rule)
+	if err != nil {
+		return diag.Errorf("failed to read policy rule: %v", err)
+	}
+	_ = d.Set("type", getMapString(raw, "type"))
+	_ = d.Set("name", getMapString(raw, "name"))
+	_ = d.Set("status", getMapString(raw, "status"))
+	_ = d.Set("priority", mapPriority(raw))
+	for _, k := range []string{"conditions", "actions"} {
+		value, err := projectPolicyRuleJSON(d.Get(k).(string), raw[k])
+		if err != nil {
+			return diag.Errorf("failed to set policy rule %s: %v", k, err)
+		}
+		_ = d.Set(k, value)
+	}
+	return nil
+}
+
+func resourcePolicyRuleUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := ensureNotDefaultRule(d); err != nil {
+		return diag.FromErr(err)
+	}
+	rule, err := buildPolicyRuleV3(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	policyID := d.Get("policy_id").(string)
+	logger(m).Info("updating policy rule", "id", d.Id(), "policy_id", policyID, "name", d.Get("name").(string))
+	oktaMutexKV.Lock(policyID)
+	defer oktaMutexKV.Unlock(policyID)
+	updated, _, err := getOktaV3ClientFromMetadata(m).PolicyApi.ReplacePolicyRule(ctx, policyID, d.Id()).PolicyRule(*rule).Execute()
+	if err != nil {
+		return diag.Errorf("failed to update policy rule: %v", err)
+	}
+	raw, err := genericPolicyRuleMap(updated)
+	if err != nil {
+		return diag.Errorf("failed to read updated policy rule: %v", err)
+	}
+	if err = validatePriority(int64(d.Get("priority").(int)), mapPriority(raw)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = policyRuleActivateV3(ctx, d, m); err != nil {
+		return diag.FromErr(err)
+	}
+	return resourcePolicyRuleRead(ctx, d, m)
+}
+
+func resourcePolicyRuleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	policyID := d.Get("policy_id").(string)
+	oktaMutexKV.Lock(policyID)
+	defer oktaMutexKV.Unlock(policyID)
+	resp, err := getOktaV3ClientFromMetadata(m).PolicyApi.DeletePolicyRule(ctx, policyID, d.Id()).Execute()
+	if err := v3suppressErrorOn404(resp, err); err != nil {
+		return diag.Errorf("failed to delete policy rule: %v", err)
+	}
+	return nil
+}
+
+func buildPolicyRuleV3(d *schema.ResourceData) (*okta.ListPolicyRules200ResponseInner, error) {
+	return buildGenericPolicyRule(d.Get("type").(string), d.Get("name").(string), d.Get("priority").(int),
+		d.Get("conditions").(string), d.Get("actions").(string))
+}
+
+// buildGenericPolicyRule decodes the rule into the v3 model matching its type,
+// this validates the conditions and actions against the model. Attributes the
+// model doesn't know about are kept, so new rule features can be used before
+// the SDK supports them.
+func buildGenericPolicyRule(ruleType, name string, priority int, conditions, actions string) (*okta.ListPolicyRules200ResponseInner, error) {
+	body := map[string]interface{}{
+		"type": ruleType,
+		"name": name,
+	}
+	if priority > 0 {
+		body["priority"] = priority
+	}
+	if conditions != "" {
+		body["conditions"] = json.RawMessage(conditions)
+	}
+	if actions != "" {
+		body["actions"] = json.RawMessage(actions)
+	}
+	b, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("invalid policy rule: %v", err)
+	}
+	var rule okta.ListPolicyRules200ResponseInner
+	if err = json.Unmarshal(b, &rule); err != nil {
+		return nil, fmt.Errorf("invalid %s policy rule: %v", ruleType, err)
+	}
+	if rule.GetActualInstance() == nil {
+		return nil, fmt.Errorf("unsupported policy rule type '%s', expected one of ACCESS_POLICY, PASSWORD, PROFILE_ENROLLMENT or SIGN_ON", ruleType)
+	}
+	return &rule, nil
+}
+
+func genericPolicyRuleMap(rule *okta.ListPolicyRules200ResponseInner) (map[string]interface{}, error) {
+	b, err := json.Marshal(rule)
+	if err != nil {
+		return nil, err
+	}
+	var raw map[string]interface{}
+	if err = json.Unmarshal(b, &raw); err != nil {
+		return nil, err
+	}
+	if raw == nil {
+		return nil, fmt.Errorf("unsupported policy rule")
+	}
+	return raw, nil
+}
+
+func mapPriority(raw map[string]interface{}) int64 {
+	priority, _ := raw["priority"].(float64)
+	return int64(priority)
+}
+
+// activate or deactivate a policy rule according to the terraform schema status field
+func policyRuleActivateV3(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	client := getOktaV3ClientFromMetadata(m).PolicyApi
+	policyID := d.Get("policy_id").(string)
+	if d.Get("status").(string) == statusActive {
+		if _, err := client.ActivatePolicyRule(ctx, policyID, d.Id()).Execute(); err != nil {
+			return fmt.Errorf("activation has failed: %v", err)
+		}
+		return nil
+	}
+	if _, err := client.DeactivatePolicyRule(ctx, policyID, d.Id()).Execute(); err != nil {
+		return fmt.Errorf("deactivation has failed: %v", err)
+	}
+	return nil
+}
+
+// projectPolicyRuleJSON returns the JSON of the actual value reduced to the
+// attributes present in the known JSON, so the defaults Okta adds to the
+// conditions and actions of a rule don't show up as drift. The full actual
+// value is returned when nothing is known yet, e.g. on import.
+func projectPolicyRuleJSON(known string, actual interface{}) (string, error) {
+	if actual == nil {
+		return "", nil
+	}
+	if known != "" {
+		var shape interface{}
+		if err := json.Unmarshal([]byte(known), &shape); err != nil {
+			return "", err
+		}
+		actual = projectJSON(shape, actual)
+	}
+	b, err := json.Marshal(actual)
+	if err != nil {
+		return "", err
+	}
+	return normalizeDataJSON(string(b)), nil
+}
+
+// projectJSON keeps the keys of the maps in actual that exist in shape. Lists
+// are projected element-wise when they have the same length, a list of another
+// length is a change of the configured value and is kept as it is.
+func projectJSON(shape, actual interface{}) interface{} {
+	switch s := shape.(type) {
+	case map[string]interface{}:
+		a, ok := actual.(map[string]interface{})
+		if !ok {
+			return actual
+		}
+		result := make(map[string]interface{}, len(s))
+		for k, v := range s {
+			if av, ok := a[k]; ok {
+				result[k] = projectJSON(v, av)
+			}
+		}
+		return result
+	case []interface{}:
+		a, ok := actual.([]interface{})
+		if !ok || len(a) != len(s) {
+			return actual
+		}
+		result := make([]interface{}, len(a))
+		for i := range a {
+			result[i] = projectJSON(s[i], a[i])
+		}
+		return result
+	}
+	return actual
+}
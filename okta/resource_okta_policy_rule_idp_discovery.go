@@ -9,6 +9,8 @@ import (
 	"github.com/okta/terraform-provider-okta/sdk"
 )
 
+var idpDiscoveryPolicyRuleShadowing = policyRuleShadowing{"app_include", "app_exclude", "user_identifier_type", "user_identifier_patterns"}
+
 func resourcePolicyRuleIdpDiscovery() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourcePolicyRuleIdpDiscoveryCreate,
@@ -16,6 +18,7 @@ func resourcePolicyRuleIdpDiscovery() *schema.Resource {
 		UpdateContext: resourcePolicyRuleIdpDiscoveryUpdate,
 		DeleteContext: resourcePolicyRuleIdpDiscoveryDelete,
 		Importer:      createPolicyRuleImporter(),
+		CustomizeDiff: idpDiscoveryPolicyRuleShadowing.customizeDiff,
 		Schema: buildBaseRuleSchema(map[string]*schema.Schema{
 			"idp_id": {
 				Type:     schema.TypeString,
@@ -80,7 +83,7 @@ func resourcePolicyRuleIdpDiscoveryCreate(ctx context.Context, d *schema.Resourc
 	if err != nil {
 		return diag.Errorf("failed to set IDP discovery policy rule status: %v", err)
 	}
-	return resourcePolicyRuleIdpDiscoveryRead(ctx, d, m)
+	return append(resourcePolicyRuleIdpDiscoveryRead(ctx, d, m), idpDiscoveryPolicyRuleShadowing.warnings(ctx, d, m)...)
 }
 
 func resourcePolicyRuleIdpDiscoveryRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -141,7 +144,7 @@ func resourcePolicyRuleIdpDiscoveryUpdate(ctx context.Context, d *schema.Resourc
 	if err != nil {
 		return diag.Errorf("failed to set IDP discovery policy rule status: %v", err)
 	}
-	return resourcePolicyRuleIdpDiscoveryRead(ctx, d, m)
+	return append(resourcePolicyRuleIdpDiscoveryRead(ctx, d, m), idpDiscoveryPolicyRuleShadowing.warnings(ctx, d, m)...)
 }
 
 func resourcePolicyRuleIdpDiscoveryDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -0,0 +1,476 @@
+package okta
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/okta/terraform-provider-okta/sdk"
+)
+
+const idpSelectionDynamic = "DYNAMIC"
+
+// resourcePolicyRuleIdpRouting manages IDP_DISCOVERY rules routing users to
+// several IdPs, or to an IdP selected with an expression. The rule itself is
+// sent with the API supplement as the v3 SDK can't decode IDP_DISCOVERY
+// rules, the v3 client activates, deactivates and deletes it.
+func resourcePolicyRuleIdpRouting() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePolicyRuleIdpRoutingCreate,
+		ReadContext:   resourcePolicyRuleIdpRoutingRead,
+		UpdateContext: resourcePolicyRuleIdpRoutingUpdate,
+		DeleteContext: resourcePolicyRuleIdpRoutingDelete,
+		Importer:      createPolicyRuleImporter(),
+		Schema: map[string]*schema.Schema{
+			"policy_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the IDP_DISCOVERY policy of the rule",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Policy Rule Name",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     statusActive,
+				Description: "Policy Rule Status: ACTIVE or INACTIVE.",
+			},
+			"priority": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Policy Rule Priority, this attribute can be set to a valid priority. To avoid endless diff situation we error if an invalid priority is provided. API defaults it to the last (lowest) if not there. Leave it unset when the rules of the policy are ordered with okta_policy_rule_order.",
+				// Suppress diff if config is empty.
+				DiffSuppressFunc: createValueDiffSuppression("0"),
+			},
+			"idp": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				ExactlyOneOf: []string{"idp", "dynamic_idp"},
+				Description:  "IdPs the users are routed to, users choose one of them when there are several",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "ID of the IdP, not set for the OKTA IdP",
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Type of the IdP: OKTA, SAML2, OIDC, GOOGLE, MICROSOFT, ...",
+						},
+					},
+				},
+			},
+			"dynamic_idp": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Routes the users to the IdP whose property matches the value of an expression",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"provider_expression": {
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateDiagFunc: expressionIsValid("login", "user"),
+							Description:      "Okta Expression Language expression evaluated to select the IdP, e.g. login.identifier.substringAfter('@')",
+						},
+						"property_name": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "name",
+							Description: "Property of the IdPs compared to the value of the expression",
+						},
+					},
+				},
+			},
+			"conditions": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Conditions of the rule, the rule applies to every login when not set",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"user_identifier": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"type": {
+										Type:        schema.TypeString,
+										Required:    true,
+										Description: "IDENTIFIER to match the login, ATTRIBUTE to match a user attribute",
+									},
+									"attribute": {
+										Type:        schema.TypeString,
+										Optional:    true,
+										Description: "User attribute matched when type is ATTRIBUTE",
+									},
+									"patterns": {
+										Type:     schema.TypeSet,
+										Optional: true,
+										Elem:     userIDPatternResource,
+									},
+								},
+							},
+						},
+						"app_include": {
+							Type:        schema.TypeSet,
+							Elem:        appResource,
+							Optional:    true,
+							Description: "Applications to include in the rule",
+						},
+						"app_exclude": {
+							Type:        schema.TypeSet,
+							Elem:        appResource,
+							Optional:    true,
+							Description: "Applications to exclude from the rule",
+						},
+						"network": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"connection": {
+										Type:        schema.TypeString,
+										Optional:    true,
+										Default:     "ANYWHERE",
+										Description: "Network selection mode: ANYWHERE, ZONE, ON_NETWORK, or OFF_NETWORK.",
+									},
+									"include": {
+										Type:          schema.TypeList,
+										Optional:      true,
+										Description:   "The zones to include",
+										ConflictsWith: []string{"conditions.0.network.0.exclude"},
+										Elem:          &schema.Schema{Type: schema.TypeString},
+									},
+									"exclude": {
+										Type:          schema.TypeList,
+										Optional:      true,
+										Description:   "The zones to exclude",
+										ConflictsWith: []string{"conditions.0.network.0.include"},
+										Elem:          &schema.Schema{Type: schema.TypeString},
+									},
+								},
+							},
+						},
+						"platform_include": {
+							Type:     schema.TypeSet,
+							Elem:     platformIncludeResource,
+							Optional: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourcePolicyRuleIdpRoutingCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := ensureNotDefaultRule(d); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := validatePolicyRuleIdpRouting(d); err != nil {
+		return diag.FromErr(err)
+	}
+	policyID := d.Get("policy_id").(string)
+	logger(m).Info("creating IdP routing policy rule", "policy_id", policyID, "name", d.Get("name").(string))
+	// creating a rule shifts the priorities of the other rules of the policy
+	oktaMutexKV.Lock(policyID)
+	defer oktaMutexKV.Unlock(policyID)
+	rule, _, err := getAPISupplementFromMetadata(m).CreateIdpDiscoveryRule(ctx, policyID, *buildIdpRoutingRule(d), nil)
+	if err != nil {
+		return diag.Errorf("failed to create IdP routing policy rule: %v", err)
+	}
+	d.SetId(rule.ID)
+	if err = policyRuleActivateV3(ctx, d, m); err != nil {
+		return diag.FromErr(err)
+	}
+	// We want to put this under Terraform's control even if priority is invalid.
+	if err = validatePriority(int64(d.Get("priority").(int)), int64(rule.Priority)); err != nil {
+		return diag.FromErr(err)
+	}
+	return resourcePolicyRuleIdpRoutingRead(ctx, d, m)
+}
+
+func resourcePolicyRuleIdpRoutingRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	policyID := d.Get("policy_id").(string)
+	logger(m).Info("reading IdP routing policy rule", "id", d.Id(), "policy_id", policyID)
+	rule, resp, err := getAPISupplementFromMetadata(m).GetIdpDiscoveryRule(ctx, policyID, d.Id())
+	if err := suppressErrorOn404(resp, err); err != nil {
+		return diag.Errorf("failed to get IdP routing policy rule: %v", err)
+	}
+	if rule == nil {
+		d.SetId("")
+		return nil
+	}
+	_ = d.Set("name", rule.Name)
+	_ = d.Set("status", rule.Status)
+	_ = d.Set("priority", rule.Priority)
+	var idp *sdk.IdpDiscoveryRuleIdp
+	if rule.Actions != nil {
+		idp = rule.Actions.IDP
+	}
+	err = setNonPrimitives(d, map[string]interface{}{
+		"idp":         flattenIdpRoutingProviders(idp),
+		"dynamic_idp": flattenIdpRoutingDynamicIdp(idp),
+		"conditions":  flattenIdpRoutingConditions(rule.Conditions, len(d.Get("conditions.0.network").([]interface{})) > 0),
+	})
+	if err != nil {
+		return diag.Errorf("failed to set IdP routing policy rule properties: %v", err)
+	}
+	return nil
+}
+
+func resourcePolicyRuleIdpRoutingUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := ensureNotDefaultRule(d); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := validatePolicyRuleIdpRouting(d); err != nil {
+		return diag.FromErr(err)
+	}
+	policyID := d.Get("policy_id").(string)
+	logger(m).Info("updating IdP routing policy rule", "id", d.Id(), "policy_id", policyID, "name", d.Get("name").(string))
+	oktaMutexKV.Lock(policyID)
+	defer oktaMutexKV.Unlock(policyID)
+	rule, _, err := getAPISupplementFromMetadata(m).UpdateIdpDiscoveryRule(ctx, policyID, d.Id(), *buildIdpRoutingRule(d), nil)
+	if err != nil {
+		return diag.Errorf("failed to update IdP routing policy rule: %v", err)
+	}
+	if err = validatePriority(int64(d.Get("priority").(int)), int64(rule.Priority)); err != nil {
+		return diag.FromErr(err)
+	}
+	if d.HasChange("status") {
+		if err = policyRuleActivateV3(ctx, d, m); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return resourcePolicyRuleIdpRoutingRead(ctx, d, m)
+}
+
+func resourcePolicyRuleIdpRoutingDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	policyID := d.Get("policy_id").(string)
+	logger(m).Info("deleting IdP routing policy rule", "id", d.Id(), "policy_id", policyID)
+	oktaMutexKV.Lock(policyID)
+	defer oktaMutexKV.Unlock(policyID)
+	resp, err := getOktaV3ClientFromMetadata(m).PolicyApi.DeletePolicyRule(ctx, policyID, d.Id()).Execute()
+	if err := v3suppressErrorOn404(resp, err); err != nil {
+		return diag.Errorf("failed to delete IdP routing policy rule: %v", err)
+	}
+	return nil
+}
+
+func buildIdpRoutingRule(d *schema.ResourceData) *sdk.IdpDiscoveryRule {
+	idp := &sdk.IdpDiscoveryRuleIdp{
+		Providers: []*sdk.IdpDiscoveryRuleProvider{},
+	}
+	for _, v := range d.Get("idp").([]interface{}) {
+		provider := v.(map[string]interface{})
+		idp.Providers = append(idp.Providers, &sdk.IdpDiscoveryRuleProvider{
+			ID:   getMapString(provider, "id"),
+			Type: getMapString(provider, "type"),
+		})
+	}
+	if dynamic, ok := d.Get("dynamic_idp").([]interface{}); ok && len(dynamic) > 0 && dynamic[0] != nil {
+		criteria := dynamic[0].(map[string]interface{})
+		idp.IdpSelectionType = idpSelectionDynamic
+		idp.MatchCriteria = []*sdk.IdpDiscoveryRuleMatchCriteria{
+			{
+				ProviderExpression: getMapString(criteria, "provider_expression"),
+				PropertyName:       getMapString(criteria, "property_name"),
+			},
+		}
+	}
+	rule := &sdk.IdpDiscoveryRule{
+		Actions: &sdk.IdpDiscoveryRuleActions{
+			IDP: idp,
+		},
+		Conditions: buildIdpRoutingConditions(d.Get("conditions").([]interface{})),
+		Type:       sdk.IdpDiscoveryType,
+		Name:       d.Get("name").(string),
+		Status:     d.Get("status").(string),
+	}
+	if priority, ok := d.GetOk("priority"); ok {
+		rule.Priority = priority.(int)
+	}
+	return rule
+}
+
+func buildIdpRoutingConditions(raw []interface{}) *sdk.IdpDiscoveryRuleConditions {
+	conditions := &sdk.IdpDiscoveryRuleConditions{
+		App:     &sdk.IdpDiscoveryRuleApp{},
+		Network: &sdk.IdpDiscoveryRuleNetwork{Connection: "ANYWHERE"},
+	}
+	if len(raw) == 0 || raw[0] == nil {
+		return conditions
+	}
+	c := raw[0].(map[string]interface{})
+	conditions.App.Include = buildIdpRoutingApps(c["app_include"])
+	conditions.App.Exclude = buildIdpRoutingApps(c["app_exclude"])
+	if network, ok := c["network"].([]interface{}); ok && len(network) > 0 && network[0] != nil {
+		n := network[0].(map[string]interface{})
+		conditions.Network = &sdk.IdpDiscoveryRuleNetwork{
+			Connection: getMapString(n, "connection"),
+			Include:    convertInterfaceToStringArr(n["include"]),
+			Exclude:    convertInterfaceToStringArr(n["exclude"]),
+		}
+	}
+	if platforms, ok := c["platform_include"].(*schema.Set); ok && platforms.Len() > 0 {
+		conditions.Platform = &sdk.IdpDiscoveryRulePlatform{}
+		for _, v := range platforms.List() {
+			platform := v.(map[string]interface{})
+			conditions.Platform.Include = append(conditions.Platform.Include, &sdk.IdpDiscoveryRulePlatformInclude{
+				Os: &sdk.IdpDiscoveryRulePlatformOS{
+					Expression: getMapString(platform, "os_expression"),
+					Type:       getMapString(platform, "os_type"),
+				},
+				Type: getMapString(platform, "type"),
+			})
+		}
+	}
+	if identifier, ok := c["user_identifier"].([]interface{}); ok && len(identifier) > 0 && identifier[0] != nil {
+		uid := identifier[0].(map[string]interface{})
+		conditions.UserIdentifier = &sdk.IdpDiscoveryRuleUserIdentifier{
+			Attribute: getMapString(uid, "attribute"),
+			Type:      getMapString(uid, "type"),
+		}
+		if patterns, ok := uid["patterns"].(*schema.Set); ok {
+			for _, v := range patterns.List() {
+				pattern := v.(map[string]interface{})
+				conditions.UserIdentifier.Patterns = append(conditions.UserIdentifier.Patterns, &sdk.IdpDiscoveryRulePattern{
+					MatchType: getMapString(pattern, "match_type"),
+					Value:     getMapString(pattern, "value"),
+				})
+			}
+		}
+	}
+	return conditions
+}
+
+func buildIdpRoutingApps(raw interface{}) []*sdk.IdpDiscoveryRuleAppObj {
+	apps, ok := raw.(*schema.Set)
+	if !ok {
+		return nil
+	}
+	var arr []*sdk.IdpDiscoveryRuleAppObj
+	for _, v := range apps.List() {
+		app := v.(map[string]interface{})
+		arr = append(arr, &sdk.IdpDiscoveryRuleAppObj{
+			ID:   getMapString(app, "id"),
+			Type: getMapString(app, "type"),
+			Name: getMapString(app, "name"),
+		})
+	}
+	return arr
+}
+
+func flattenIdpRoutingProviders(idp *sdk.IdpDiscoveryRuleIdp) []interface{} {
+	arr := []interface{}{}
+	if idp == nil || idp.IdpSelectionType == idpSelectionDynamic {
+		return arr
+	}
+	for _, provider := range idp.Providers {
+		arr = append(arr, map[string]interface{}{
+			"id":   provider.ID,
+			"type": provider.Type,
+		})
+	}
+	return arr
+}
+
+func flattenIdpRoutingDynamicIdp(idp *sdk.IdpDiscoveryRuleIdp) []interface{} {
+	if idp == nil || idp.IdpSelectionType != idpSelectionDynamic || len(idp.MatchCriteria) == 0 {
+		return []interface{}{}
+	}
+	return []interface{}{
+		map[string]interface{}{
+			"provider_expression": idp.MatchCriteria[0].ProviderExpression,
+			"property_name":       idp.MatchCriteria[0].PropertyName,
+		},
+	}
+}
+
+// flattenIdpRoutingConditions returns the conditions block, or no block when
+// the rule has no conditions. The network block is only returned when it
+// restricts the rule or when it's configured, Okta sets the ANYWHERE
+// connection on every rule.
+func flattenIdpRoutingConditions(conditions *sdk.IdpDiscoveryRuleConditions, hasNetwork bool) []interface{} {
+	if conditions == nil {
+		return []interface{}{}
+	}
+	c := map[string]interface{}{
+		"user_identifier":  []interface{}{},
+		"app_include":      flattenDiscoveryRuleAppInclude(conditions.App),
+		"app_exclude":      flattenDiscoveryRuleAppExclude(conditions.App),
+		"network":          []interface{}{},
+		"platform_include": flattenPlatformInclude(conditions.Platform),
+	}
+	empty := c["app_include"].(*schema.Set).Len() == 0 && c["app_exclude"].(*schema.Set).Len() == 0 &&
+		c["platform_include"].(*schema.Set).Len() == 0
+	if uid := conditions.UserIdentifier; uid != nil && uid.Type != "" {
+		c["user_identifier"] = []interface{}{
+			map[string]interface{}{
+				"type":      uid.Type,
+				"attribute": uid.Attribute,
+				"patterns":  flattenUserIDPatterns(uid.Patterns),
+			},
+		}
+		empty = false
+	}
+	if network := conditions.Network; network != nil {
+		restricted := network.Connection != "" && network.Connection != "ANYWHERE" ||
+			len(network.Include) > 0 || len(network.Exclude) > 0
+		if restricted || hasNetwork {
+			c["network"] = []interface{}{
+				map[string]interface{}{
+					"connection": network.Connection,
+					"include":    convertStringSliceToInterfaceSlice(network.Include),
+					"exclude":    convertStringSliceToInterfaceSlice(network.Exclude),
+				},
+			}
+			empty = false
+		}
+	}
+	if empty {
+		return []interface{}{}
+	}
+	return []interface{}{c}
+}
+
+func validatePolicyRuleIdpRouting(d *schema.ResourceData) error {
+	for i, v := range d.Get("idp").([]interface{}) {
+		provider := v.(map[string]interface{})
+		if getMapString(provider, "type") != "OKTA" && getMapString(provider, "id") == "" {
+			return fmt.Errorf("'id' is required in 'idp.%d' unless the type is OKTA", i)
+		}
+	}
+	conditions := d.Get("conditions").([]interface{})
+	if len(conditions) == 0 || conditions[0] == nil {
+		return nil
+	}
+	for _, appCondition := range []string{"app_include", "app_exclude"} {
+		apps, ok := conditions[0].(map[string]interface{})[appCondition].(*schema.Set)
+		if !ok {
+			continue
+		}
+		for _, item := range apps.List() {
+			value := item.(map[string]interface{})
+			id := getMapString(value, "id")
+			name := getMapString(value, "name")
+			if id == "" && name == "" {
+				return fmt.Errorf(errFDiscoveryRuleIdPAppConditionID, appCondition)
+			}
+			if getMapString(value, "type") == "APP_TYPE" && name == "" {
+				return fmt.Errorf(errFDiscoveryRuleIdPAppConditionName, appCondition)
+			}
+		}
+	}
+	return nil
+}
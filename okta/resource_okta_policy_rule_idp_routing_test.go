@@ -0,0 +1,185 @@
+package okta
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/okta/terraform-provider-okta/sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildIdpRoutingRule(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourcePolicyRuleIdpRouting().Schema, map[string]interface{}{
+		"policy_id": "pol1",
+		"name":      "partners",
+		"priority":  2,
+		"idp": []interface{}{
+			map[string]interface{}{"type": "SAML2", "id": "idp1"},
+			map[string]interface{}{"type": "OIDC", "id": "idp2"},
+		},
+		"conditions": []interface{}{
+			map[string]interface{}{
+				"user_identifier": []interface{}{
+					map[string]interface{}{
+						"type": "IDENTIFIER",
+						"patterns": []interface{}{
+							map[string]interface{}{"match_type": "SUFFIX", "value": "@example.com"},
+						},
+					},
+				},
+				"app_exclude": []interface{}{
+					map[string]interface{}{"type": "APP", "id": "app1"},
+				},
+				"network": []interface{}{
+					map[string]interface{}{"connection": "ZONE", "include": []interface{}{"zone1"}},
+				},
+			},
+		},
+	})
+	rule := buildIdpRoutingRule(d)
+	b, err := json.Marshal(rule.Actions)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"idp":{"providers":[{"type":"SAML2","id":"idp1"},{"type":"OIDC","id":"idp2"}]}}`, string(b))
+	b, err = json.Marshal(rule.Conditions)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"app": {"include": null, "exclude": [{"type": "APP", "id": "app1"}]},
+		"network": {"connection": "ZONE", "include": ["zone1"]},
+		"userIdentifier": {"type": "IDENTIFIER", "patterns": [{"matchType": "SUFFIX", "value": "@example.com"}]}
+	}`, string(b))
+	assert.Equal(t, 2, rule.Priority)
+	assert.Equal(t, sdk.IdpDiscoveryType, rule.Type)
+	require.NoError(t, validatePolicyRuleIdpRouting(d))
+
+	flattened := flattenIdpRoutingConditions(rule.Conditions, false)
+	require.Len(t, flattened, 1)
+	conditions := flattened[0].(map[string]interface{})
+	assert.Len(t, conditions["network"], 1)
+	assert.Equal(t, 1, conditions["app_exclude"].(*schema.Set).Len())
+	assert.Equal(t, "IDENTIFIER", conditions["user_identifier"].([]interface{})[0].(map[string]interface{})["type"])
+	assert.Equal(t, rule.Actions.IDP.Providers[1].ID, flattenIdpRoutingProviders(rule.Actions.IDP)[1].(map[string]interface{})["id"])
+	assert.Empty(t, flattenIdpRoutingDynamicIdp(rule.Actions.IDP))
+}
+
+func TestBuildIdpRoutingRuleDynamic(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourcePolicyRuleIdpRouting().Schema, map[string]interface{}{
+		"policy_id": "pol1",
+		"name":      "partners",
+		"dynamic_idp": []interface{}{
+			map[string]interface{}{"provider_expression": "login.identifier.substringAfter('@')"},
+		},
+	})
+	rule := buildIdpRoutingRule(d)
+	b, err := json.Marshal(rule.Actions)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"idp":{"providers":[],"idpSelectionType":"DYNAMIC","matchCriteria":[{"providerExpression":"login.identifier.substringAfter('@')","propertyName":"name"}]}}`, string(b))
+	assert.Empty(t, flattenIdpRoutingProviders(rule.Actions.IDP))
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{
+			"provider_expression": "login.identifier.substringAfter('@')",
+			"property_name":       "name",
+		},
+	}, flattenIdpRoutingDynamicIdp(rule.Actions.IDP))
+	// Okta sets the ANYWHERE network connection on every rule
+	assert.Empty(t, flattenIdpRoutingConditions(rule.Conditions, false))
+	assert.Len(t, flattenIdpRoutingConditions(rule.Conditions, true), 1)
+}
+
+func TestValidatePolicyRuleIdpRouting(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourcePolicyRuleIdpRouting().Schema, map[string]interface{}{
+		"policy_id": "pol1",
+		"name":      "partners",
+		"idp": []interface{}{
+			map[string]interface{}{"type": "OKTA"},
+			map[string]interface{}{"type": "SAML2"},
+		},
+	})
+	assert.EqualError(t, validatePolicyRuleIdpRouting(d), "'id' is required in 'idp.1' unless the type is OKTA")
+
+	d = schema.TestResourceDataRaw(t, resourcePolicyRuleIdpRouting().Schema, map[string]interface{}{
+		"policy_id": "pol1",
+		"name":      "partners",
+		"idp": []interface{}{
+			map[string]interface{}{"type": "OKTA"},
+		},
+		"conditions": []interface{}{
+			map[string]interface{}{
+				"app_include": []interface{}{
+					map[string]interface{}{"type": "APP_TYPE", "id": "app1"},
+				},
+			},
+		},
+	})
+	assert.EqualError(t, validatePolicyRuleIdpRouting(d), fmt.Sprintf(errFDiscoveryRuleIdPAppConditionName, "app_include"))
+}
+
+func TestAccOktaPolicyRuleIdpRouting_crud(t *testing.T) {
+	mgr := newFixtureManager(policyRuleIdpRouting, t.Name())
+	config := mgr.GetFixtures("basic.tf", t)
+	updatedConfig := mgr.GetFixtures("updated.tf", t)
+	dynamicConfig := mgr.GetFixtures("dynamic.tf", t)
+	resourceName := fmt.Sprintf("%s.test", policyRuleIdpRouting)
+
+	oktaResourceTest(t, resource.TestCase{
+		PreCheck:          testAccPreCheck(t),
+		ErrorCheck:        testAccErrorChecks(t),
+		ProviderFactories: testAccProvidersFactories,
+		CheckDestroy:      checkRuleDestroy(policyRuleIdpRouting),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					ensureRuleExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "name", buildResourceName(mgr.Seed)),
+					resource.TestCheckResourceAttr(resourceName, "status", statusActive),
+					resource.TestCheckResourceAttr(resourceName, "idp.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "idp.0.type", "SAML2"),
+					resource.TestCheckResourceAttrPair(resourceName, "idp.1.id", "okta_idp_saml.partner_b", "id"),
+					resource.TestCheckResourceAttr(resourceName, "conditions.0.user_identifier.0.type", "IDENTIFIER"),
+					resource.TestCheckResourceAttr(resourceName, "conditions.0.user_identifier.0.patterns.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "conditions.0.app_include.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "conditions.0.network.#", "0"),
+				),
+			},
+			{
+				Config: updatedConfig,
+				Check: resource.ComposeTestCheckFunc(
+					ensureRuleExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "status", statusInactive),
+					resource.TestCheckResourceAttr(resourceName, "idp.#", "3"),
+					resource.TestCheckResourceAttr(resourceName, "idp.2.type", "OKTA"),
+					resource.TestCheckResourceAttr(resourceName, "conditions.0.user_identifier.0.type", "ATTRIBUTE"),
+					resource.TestCheckResourceAttr(resourceName, "conditions.0.user_identifier.0.attribute", "company"),
+					resource.TestCheckResourceAttr(resourceName, "conditions.0.app_include.#", "0"),
+					resource.TestCheckResourceAttr(resourceName, "conditions.0.app_exclude.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "conditions.0.network.0.connection", "ZONE"),
+					resource.TestCheckResourceAttr(resourceName, "conditions.0.network.0.include.#", "1"),
+				),
+			},
+			{
+				Config: dynamicConfig,
+				Check: resource.ComposeTestCheckFunc(
+					ensureRuleExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "status", statusActive),
+					resource.TestCheckResourceAttr(resourceName, "idp.#", "0"),
+					resource.TestCheckResourceAttr(resourceName, "dynamic_idp.0.provider_expression", "login.identifier.substringAfter('@')"),
+					resource.TestCheckResourceAttr(resourceName, "dynamic_idp.0.property_name", "name"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs := s.RootModule().Resources[resourceName]
+					return fmt.Sprintf("%s/%s", rs.Primary.Attributes["policy_id"], rs.Primary.ID), nil
+				},
+			},
+		},
+	})
+}
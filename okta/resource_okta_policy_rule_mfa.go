@@ -8,6 +8,8 @@ import (
 	"github.com/okta/terraform-provider-okta/sdk"
 )
 
+var mfaPolicyRuleShadowing = policyRuleShadowing{"app_include", "app_exclude"}
+
 func resourcePolicyMfaRule() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourcePolicyMfaRuleCreate,
@@ -15,6 +17,8 @@ func resourcePolicyMfaRule() *schema.Resource {
 		UpdateContext: resourcePolicyMfaRuleUpdate,
 		DeleteContext: resourcePolicyMfaRuleDelete,
 		Importer:      createPolicyRuleImporter(),
+		CustomizeDiff: mfaPolicyRuleShadowing.customizeDiff,
+		Timeouts:      policyCreateTimeouts(),
 		Schema: buildRuleSchema(map[string]*schema.Schema{
 			"enroll": {
 				Type:        schema.TypeString,
@@ -44,7 +48,7 @@ func resourcePolicyMfaRuleCreate(ctx context.Context, d *schema.ResourceData, m
 	if err != nil {
 		return diag.Errorf("failed to create MFA policy rule: %v", err)
 	}
-	return resourcePolicyMfaRuleRead(ctx, d, m)
+	return append(resourcePolicyMfaRuleRead(ctx, d, m), mfaPolicyRuleShadowing.warnings(ctx, d, m)...)
 }
 
 func resourcePolicyMfaRuleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -79,7 +83,7 @@ func resourcePolicyMfaRuleUpdate(ctx context.Context, d *schema.ResourceData, m
 	if err != nil {
 		return diag.Errorf("failed to update MFA policy rule: %v", err)
 	}
-	return resourcePolicyMfaRuleRead(ctx, d, m)
+	return append(resourcePolicyMfaRuleRead(ctx, d, m), mfaPolicyRuleShadowing.warnings(ctx, d, m)...)
 }
 
 func resourcePolicyMfaRuleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
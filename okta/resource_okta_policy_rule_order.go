@@ -0,0 +1,212 @@
+package okta
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/okta/terraform-provider-okta/sdk"
+)
+
+func resourcePolicyRuleOrder() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePolicyRuleOrderCreate,
+		ReadContext:   resourcePolicyRuleOrderRead,
+		UpdateContext: resourcePolicyRuleOrderUpdate,
+		DeleteContext: resourcePolicyRuleOrderDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+				_ = d.Set("policy_id", d.Id())
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+		Schema: map[string]*schema.Schema{
+			"policy_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the policy whose rules are ordered",
+			},
+			"rule_ids": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "IDs of the policy rules in the order of their priority. The rules take the highest priorities of the policy, rules not in the list are evaluated after them.",
+			},
+		},
+	}
+}
+
+func resourcePolicyRuleOrderCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	policyID := d.Get("policy_id").(string)
+	err := applyPolicyRuleOrder(ctx, m, policyID, convertInterfaceToStringArr(d.Get("rule_ids")))
+	if err != nil {
+		return diag.Errorf("failed to order policy rules: %v", err)
+	}
+	d.SetId(policyID)
+	return resourcePolicyRuleOrderRead(ctx, d, m)
+}
+
+func resourcePolicyRuleOrderRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	ruleIDs, resp, err := listOrderedPolicyRuleIDs(ctx, m, d.Id())
+	if err := suppressErrorOn404(resp, err); err != nil {
+		return diag.Errorf("failed to list policy rules: %v", err)
+	}
+	if ruleIDs == nil {
+		d.SetId("")
+		return nil
+	}
+	// Only the leading rules are owned by this resource, a new rule with a
+	// higher priority shows up as drift of the configured order.
+	if n := len(d.Get("rule_ids").([]interface{})); n > 0 && n < len(ruleIDs) {
+		ruleIDs = ruleIDs[:n]
+	}
+	_ = d.Set("policy_id", d.Id())
+	_ = d.Set("rule_ids", ruleIDs)
+	return nil
+}
+
+func resourcePolicyRuleOrderUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	err := applyPolicyRuleOrder(ctx, m, d.Id(), convertInterfaceToStringArr(d.Get("rule_ids")))
+	if err != nil {
+		return diag.Errorf("failed to order policy rules: %v", err)
+	}
+	return resourcePolicyRuleOrderRead(ctx, d, m)
+}
+
+// The order of the rules is left as it is when the resource is removed.
+func resourcePolicyRuleOrderDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return nil
+}
+
+// listOrderedPolicyRuleIDs returns the IDs of the non system rules of the
+// policy sorted by their priority. The system rule is always evaluated last and
+// can't be moved.
+func listOrderedPolicyRuleIDs(ctx context.Context, m interface{}, policyID string) ([]string, *sdk.Response, error) {
+	rules, resp, err := getAPISupplementFromMetadata(m).ListPolicyRulePositions(ctx, policyID)
+	if err != nil {
+		return nil, resp, err
+	}
+	sort.SliceStable(rules, func(i, j int) bool {
+		return rules[i].Priority < rules[j].Priority
+	})
+	ruleIDs := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		if rule.System != nil && *rule.System {
+			continue
+		}
+		ruleIDs = append(ruleIDs, rule.Id)
+	}
+	return ruleIDs, resp, nil
+}
+
+func applyPolicyRuleOrder(ctx context.Context, m interface{}, policyID string, desired []string) error {
+	oktaMutexKV.Lock(policyID)
+	defer oktaMutexKV.Unlock(policyID)
+
+	current, _, err := listOrderedPolicyRuleIDs(ctx, m, policyID)
+	if err != nil {
+		return fmt.Errorf("failed to list policy rules: %v", err)
+	}
+	moves, err := policyRuleMoves(current, desired)
+	if err != nil {
+		return err
+	}
+	client := getAPISupplementFromMetadata(m)
+	for _, move := range moves {
+		logger(m).Info("changing policy rule priority", "policy_id", policyID, "rule_id", move.ruleID, "priority", move.priority)
+		_, err := client.UpdatePolicyRulePriority(ctx, policyID, move.ruleID, move.priority)
+		if err != nil {
+			return fmt.Errorf("failed to set priority %d of policy rule '%s': %v", move.priority, move.ruleID, err)
+		}
+	}
+	return nil
+}
+
+type policyRuleMove struct {
+	ruleID   string
+	priority int64
+}
+
+// policyRuleMoves computes the smallest set of priority updates reordering the
+// current rules so that the desired rules come first, in the given order,
+// followed by the remaining rules in their current order. Setting the priority
+// of a rule shifts the rules after it, so the moves have to be applied in the
+// returned order.
+//
+// The rules forming the longest subsequence of current that already is in the
+// target order stay where they are, every other rule is moved right after its
+// predecessor in the target order.
+func policyRuleMoves(current, desired []string) ([]policyRuleMove, error) {
+	currentIndex := make(map[string]int, len(current))
+	for i, id := range current {
+		currentIndex[id] = i
+	}
+	target := make([]string, 0, len(current))
+	targetIndex := make(map[string]int, len(current))
+	for _, id := range desired {
+		if _, ok := targetIndex[id]; ok {
+			return nil, fmt.Errorf("policy rule '%s' is listed more than once", id)
+		}
+		if _, ok := currentIndex[id]; !ok {
+			return nil, fmt.Errorf("policy rule '%s' does not exist or is a system rule", id)
+		}
+		targetIndex[id] = len(target)
+		target = append(target, id)
+	}
+	for _, id := range current {
+		if _, ok := targetIndex[id]; !ok {
+			targetIndex[id] = len(target)
+			target = append(target, id)
+		}
+	}
+
+	// longest increasing subsequence of the target indexes in current order
+	n := len(current)
+	length := make([]int, n)
+	prev := make([]int, n)
+	best := -1
+	for i := range current {
+		length[i], prev[i] = 1, -1
+		for j := 0; j < i; j++ {
+			if targetIndex[current[j]] < targetIndex[current[i]] && length[j]+1 > length[i] {
+				length[i], prev[i] = length[j]+1, j
+			}
+		}
+		if best == -1 || length[i] > length[best] {
+			best = i
+		}
+	}
+	keep := make(map[string]bool, n)
+	for i := best; i >= 0; i = prev[i] {
+		keep[current[i]] = true
+	}
+
+	order := append([]string(nil), current...)
+	var moves []policyRuleMove
+	for i, id := range target {
+		if keep[id] {
+			continue
+		}
+		order = remove(order, id)
+		pos := 0
+		if i > 0 {
+			pos = indexOfString(order, target[i-1]) + 1
+		}
+		order = append(order[:pos], append([]string{id}, order[pos:]...)...)
+		moves = append(moves, policyRuleMove{ruleID: id, priority: int64(pos + 1)})
+	}
+	return moves, nil
+}
+
+func indexOfString(s []string, v string) int {
+	for i := range s {
+		if s[i] == v {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,83 @@
+package okta
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicyRuleMoves(t *testing.T) {
+	// apply simulates how Okta shifts the other rules when a priority is set
+	apply := func(order []string, moves []policyRuleMove) []string {
+		for _, move := range moves {
+			order = remove(order, move.ruleID)
+			pos := int(move.priority) - 1
+			order = append(order[:pos], append([]string{move.ruleID}, order[pos:]...)...)
+		}
+		return order
+	}
+	tests := []struct {
+		name     string
+		current  []string
+		desired  []string
+		expected []string
+		moves    int
+	}{
+		{"already ordered", []string{"a", "b", "c"}, []string{"a", "b", "c"}, []string{"a", "b", "c"}, 0},
+		{"prefix ordered", []string{"a", "b", "c"}, []string{"a", "b"}, []string{"a", "b", "c"}, 0},
+		{"last to first", []string{"c", "a", "b"}, []string{"a", "b", "c"}, []string{"a", "b", "c"}, 1},
+		{"first to last", []string{"b", "c", "a"}, []string{"a", "b", "c"}, []string{"a", "b", "c"}, 1},
+		{"reversed", []string{"a", "b", "c"}, []string{"c", "b", "a"}, []string{"c", "b", "a"}, 2},
+		{"unlisted rules follow", []string{"x", "a", "y", "b"}, []string{"b", "a"}, []string{"b", "a", "x", "y"}, 2},
+		{"swap", []string{"a", "b", "c", "d"}, []string{"a", "c", "b", "d"}, []string{"a", "c", "b", "d"}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			moves, err := policyRuleMoves(tt.current, tt.desired)
+			require.NoError(t, err)
+			assert.Len(t, moves, tt.moves)
+			assert.Equal(t, tt.expected, apply(append([]string(nil), tt.current...), moves))
+		})
+	}
+
+	_, err := policyRuleMoves([]string{"a", "b"}, []string{"a", "a"})
+	assert.Error(t, err)
+	_, err = policyRuleMoves([]string{"a", "b"}, []string{"c"})
+	assert.Error(t, err)
+}
+
+func TestAccOktaPolicyRuleOrder_crud(t *testing.T) {
+	mgr := newFixtureManager(policyRuleOrder, t.Name())
+	config := mgr.GetFixtures("basic.tf", t)
+	updatedConfig := mgr.GetFixtures("updated.tf", t)
+	resourceName := fmt.Sprintf("%s.test", policyRuleOrder)
+
+	oktaResourceTest(t, resource.TestCase{
+		PreCheck:          testAccPreCheck(t),
+		ErrorCheck:        testAccErrorChecks(t),
+		ProviderFactories: testAccProvidersFactories,
+		CheckDestroy:      checkPolicyDestroy(policySignOn),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "rule_ids.#", "3"),
+					resource.TestCheckResourceAttrPair(resourceName, "rule_ids.0", "okta_policy_rule_signon.first", "id"),
+					resource.TestCheckResourceAttrPair(resourceName, "rule_ids.1", "okta_policy_rule_signon.second", "id"),
+					resource.TestCheckResourceAttrPair(resourceName, "rule_ids.2", "okta_policy_rule_signon.third", "id"),
+				),
+			},
+			{
+				Config: updatedConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "rule_ids.#", "2"),
+					resource.TestCheckResourceAttrPair(resourceName, "rule_ids.0", "okta_policy_rule_signon.third", "id"),
+					resource.TestCheckResourceAttrPair(resourceName, "rule_ids.1", "okta_policy_rule_signon.first", "id"),
+				),
+			},
+		},
+	})
+}
@@ -8,6 +8,8 @@ import (
 	"github.com/okta/terraform-provider-okta/sdk"
 )
 
+var passwordPolicyRuleShadowing = policyRuleShadowing{}
+
 func resourcePolicyPasswordRule() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourcePolicyPasswordRuleCreate,
@@ -15,6 +17,8 @@ func resourcePolicyPasswordRule() *schema.Resource {
 		UpdateContext: resourcePolicyPasswordRuleUpdate,
 		DeleteContext: resourcePolicyPasswordRuleDelete,
 		Importer:      createPolicyRuleImporter(),
+		CustomizeDiff: passwordPolicyRuleShadowing.customizeDiff,
+		Timeouts:      policyCreateTimeouts(),
 		Schema: buildRuleSchema(map[string]*schema.Schema{
 			"password_change": {
 				Type:        schema.TypeString,
@@ -44,7 +48,7 @@ func resourcePolicyPasswordRuleCreate(ctx context.Context, d *schema.ResourceDat
 	if err != nil {
 		return diag.Errorf("failed to create password policy rule: %v", err)
 	}
-	return resourcePolicyPasswordRuleRead(ctx, d, m)
+	return append(resourcePolicyPasswordRuleRead(ctx, d, m), passwordPolicyRuleShadowing.warnings(ctx, d, m)...)
 }
 
 func resourcePolicyPasswordRuleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -72,7 +76,7 @@ func resourcePolicyPasswordRuleUpdate(ctx context.Context, d *schema.ResourceDat
 	if err != nil {
 		return diag.Errorf("failed to update password policy rule: %v", err)
 	}
-	return resourcePolicyPasswordRuleRead(ctx, d, m)
+	return append(resourcePolicyPasswordRuleRead(ctx, d, m), passwordPolicyRuleShadowing.warnings(ctx, d, m)...)
 }
 
 func resourcePolicyPasswordRuleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
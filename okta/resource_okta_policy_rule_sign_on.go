@@ -10,6 +10,8 @@ import (
 	"github.com/okta/terraform-provider-okta/sdk"
 )
 
+var signOnPolicyRuleShadowing = policyRuleShadowing{"authtype", "risc_level", "behaviors", "identity_provider", "identity_provider_ids"}
+
 func resourcePolicySignOnRule() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourcePolicySignOnRuleCreate,
@@ -17,6 +19,8 @@ func resourcePolicySignOnRule() *schema.Resource {
 		UpdateContext: resourcePolicySignOnRuleUpdate,
 		DeleteContext: resourcePolicySignOnRuleDelete,
 		Importer:      createPolicyRuleImporter(),
+		CustomizeDiff: signOnPolicyRuleShadowing.customizeDiff,
+		Timeouts:      policyCreateTimeouts(),
 		Schema: buildRuleSchema(map[string]*schema.Schema{
 			"authtype": {
 				Type:        schema.TypeString,
@@ -150,7 +154,7 @@ func resourcePolicySignOnRuleCreate(ctx context.Context, d *schema.ResourceData,
 	if err != nil {
 		return diag.Errorf("failed to create sign-on policy rule: %v", err)
 	}
-	return resourcePolicySignOnRuleRead(ctx, d, m)
+	return append(resourcePolicySignOnRuleRead(ctx, d, m), signOnPolicyRuleShadowing.warnings(ctx, d, m)...)
 }
 
 func resourcePolicySignOnRuleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -241,7 +245,7 @@ func resourcePolicySignOnRuleUpdate(ctx context.Context, d *schema.ResourceData,
 	if err != nil {
 		return diag.Errorf("failed to update sign-on policy rule: %v", err)
 	}
-	return resourcePolicySignOnRuleRead(ctx, d, m)
+	return append(resourcePolicySignOnRuleRead(ctx, d, m), signOnPolicyRuleShadowing.warnings(ctx, d, m)...)
 }
 
 func resourcePolicySignOnRuleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -122,6 +122,30 @@ func TestAccOktaPolicyRuleSignon_crud(t *testing.T) {
 	})
 }
 
+func TestAccOktaPolicyRuleSignon_shadowing(t *testing.T) {
+	mgr := newFixtureManager(policyRuleSignOn, t.Name())
+	config := mgr.GetFixtures("basic.tf", t)
+	shadowed := mgr.GetFixtures("shadowed.tf", t)
+	resourceName := fmt.Sprintf("%s.test", policyRuleSignOn)
+
+	oktaResourceTest(t, resource.TestCase{
+		PreCheck:          testAccPreCheck(t),
+		ErrorCheck:        testAccErrorChecks(t),
+		ProviderFactories: testAccProvidersFactories,
+		CheckDestroy:      checkRuleDestroy(policyRuleSignOn),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check:  ensureRuleExists(resourceName),
+			},
+			{
+				Config:      shadowed,
+				ExpectError: regexp.MustCompile("is shadowed by rule"),
+			},
+		},
+	})
+}
+
 func TestAccOktaPolicyRuleSignon_multiple(t *testing.T) {
 	mgr := newFixtureManager(policyRuleSignOn, t.Name())
 	config := mgr.GetFixtures("basic.tf", t)
@@ -0,0 +1,93 @@
+package okta
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectPolicyRuleJSON(t *testing.T) {
+	var actual interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"people": {"groups": {"include": ["g1"]}, "users": {"exclude": []}},
+		"network": {"connection": "ANYWHERE"},
+		"platform": {"include": [{"type": "MOBILE", "os": {"type": "IOS"}}]}
+	}`), &actual))
+	tests := []struct {
+		name     string
+		known    string
+		expected string
+	}{
+		{"nothing known", "", `{"network":{"connection":"ANYWHERE"},"people":{"groups":{"include":["g1"]},"users":{"exclude":[]}},"platform":{"include":[{"os":{"type":"IOS"},"type":"MOBILE"}]}}`},
+		{"defaults dropped", `{"people":{"groups":{"include":["g1"]}}}`, `{"people":{"groups":{"include":["g1"]}}}`},
+		{"changed value kept", `{"people":{"groups":{"include":["g2","g3"]}}}`, `{"people":{"groups":{"include":["g1"]}}}`},
+		{"list elements projected", `{"platform":{"include":[{"type":"MOBILE"}]}}`, `{"platform":{"include":[{"type":"MOBILE"}]}}`},
+		{"removed attribute dropped", `{"riskScore":{"level":"HIGH"}}`, `{}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := projectPolicyRuleJSON(tt.known, actual)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, value)
+		})
+	}
+	value, err := projectPolicyRuleJSON(`{"people":{}}`, nil)
+	require.NoError(t, err)
+	assert.Empty(t, value)
+}
+
+func TestBuildGenericPolicyRule(t *testing.T) {
+	rule, err := buildGenericPolicyRule("ACCESS_POLICY", "test", 1,
+		`{"network":{"connection":"ZONE","include":["z1"]},"newCondition":{"enabled":true}}`,
+		`{"appSignOn":{"access":"ALLOW"}}`)
+	require.NoError(t, err)
+	require.NotNil(t, rule.AccessPolicyRule)
+	b, err := json.Marshal(rule)
+	require.NoError(t, err)
+	assert.Contains(t, string(b), `"newCondition":{"enabled":true}`)
+	assert.Contains(t, string(b), `"priority":1`)
+
+	_, err = buildGenericPolicyRule("ACCESS_POLICY", "test", 0, `{"network":{"connection":"SOMEWHERE"}}`, "")
+	assert.Error(t, err)
+	_, err = buildGenericPolicyRule("UNKNOWN", "test", 0, "", "")
+	assert.Error(t, err)
+}
+
+func TestAccOktaPolicyRule_crud(t *testing.T) {
+	mgr := newFixtureManager(policyRule, t.Name())
+	config := mgr.GetFixtures("basic.tf", t)
+	updatedConfig := mgr.GetFixtures("updated.tf", t)
+	resourceName := fmt.Sprintf("%s.test", policyRule)
+
+	oktaResourceTest(t, resource.TestCase{
+		PreCheck:          testAccPreCheck(t),
+		ErrorCheck:        testAccErrorChecks(t),
+		ProviderFactories: testAccProvidersFactories,
+		CheckDestroy:      checkRuleDestroy(policyRule),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					ensureRuleExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "name", buildResourceName(mgr.Seed)),
+					resource.TestCheckResourceAttr(resourceName, "type", "ACCESS_POLICY"),
+					resource.TestCheckResourceAttr(resourceName, "status", statusActive),
+					resource.TestCheckResourceAttr(resourceName, "actions", `{"appSignOn":{"access":"ALLOW","verificationMethod":{"factorMode":"1FA","reauthenticateIn":"PT2H","type":"ASSURANCE"}}}`),
+				),
+			},
+			{
+				Config: updatedConfig,
+				Check: resource.ComposeTestCheckFunc(
+					ensureRuleExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "name", buildResourceName(mgr.Seed)+"_updated"),
+					resource.TestCheckResourceAttr(resourceName, "status", statusInactive),
+					resource.TestCheckResourceAttr(resourceName, "actions", `{"appSignOn":{"access":"DENY"}}`),
+				),
+			},
+		},
+	})
+}
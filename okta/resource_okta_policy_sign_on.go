@@ -17,7 +17,8 @@ func resourcePolicySignOn() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
-		Schema: basePolicySchema,
+		Timeouts: policyCreateTimeouts(),
+		Schema:   basePolicySchema,
 	}
 }
 
@@ -22,6 +22,7 @@ func resourceProfileMapping() *schema.Resource {
 		ReadContext:   resourceProfileMappingRead,
 		UpdateContext: resourceProfileMappingUpdate,
 		DeleteContext: resourceProfileMappingDelete,
+		CustomizeDiff: profileMappingCustomizeDiff,
 		Schema: map[string]*schema.Schema{
 			"source_id": {
 				Type:        schema.TypeString,
@@ -77,8 +78,10 @@ var mappingResource = &schema.Resource{
 			Description: "The mapping property key.",
 		},
 		"expression": {
-			Type:     schema.TypeString,
-			Required: true,
+			Type:             schema.TypeString,
+			Required:         true,
+			ValidateDiagFunc: expressionIsValid(),
+			Description:      "Okta Expression Language expression for the mapping. Referenced source attributes are validated against the source schema at plan time.",
 		},
 		"push_status": {
 			Type:     schema.TypeString,
@@ -78,6 +78,7 @@ func resourceThemeRead(ctx context.Context, d *schema.ResourceData, m interface{
 	if err != nil {
 		return diag.Errorf("failed to set theme properties: %v", err)
 	}
+	syncThemeImages(ctx, d, m, theme)
 
 	return nil
 }
@@ -93,19 +94,19 @@ func resourceThemeUpdate(ctx context.Context, d *schema.ResourceData, m interfac
 
 	// peform delete/upload on the logo/favicon/background_image first so any
 	// errors there will interrupt apply on the theme itself
-	if d.HasChange("logo") {
+	if logoAttributes.hasChange(d) {
 		err := handleThemeLogo(ctx, d, m, brandID, d.Id())
 		if err != nil {
 			return diag.Errorf("failed to handle logo for theme: %v", err)
 		}
 	}
-	if d.HasChange("favicon") {
+	if themeFaviconAttributes.hasChange(d) {
 		err := handleThemeFavicon(ctx, d, m, brandID, d.Id())
 		if err != nil {
 			return diag.Errorf("failed to handle favicon for theme: %v", err)
 		}
 	}
-	if d.HasChange("background_image") {
+	if themeBackgroundImageAttributes.hasChange(d) {
 		err := handleThemeBackgroundImage(ctx, d, m, brandID, d.Id())
 		if err != nil {
 			return diag.Errorf("failed to handle background_image for theme: %v", err)
@@ -164,6 +165,7 @@ func resourceThemeUpdate(ctx context.Context, d *schema.ResourceData, m interfac
 	if err != nil {
 		return diag.Errorf("failed to set theme properties: %v", err)
 	}
+	syncThemeImages(ctx, d, m, themeResp)
 
 	return nil
 }
@@ -198,46 +200,60 @@ func resourceThemeImportStateContext(ctx context.Context, d *schema.ResourceData
 }
 
 func handleThemeLogo(ctx context.Context, d *schema.ResourceData, m interface{}, brandID, themeID string) error {
-	_, newPath := d.GetChange("logo")
-	if newPath == "" {
-		_, err := getOktaV3ClientFromMetadata(m).CustomizationApi.DeleteBrandThemeLogo(ctx, brandID, themeID).Execute()
-		return err
-	}
-	fo, err := os.Open(newPath.(string))
-	if err != nil {
-		return err
-	}
-	defer fo.Close()
-	_, _, err = getOktaV3ClientFromMetadata(m).CustomizationApi.UploadBrandThemeLogo(ctx, brandID, themeID).File(fo).Execute()
-	return err
+	api := getOktaV3ClientFromMetadata(m).CustomizationApi
+	return handleThemeImage(d, logoAttributes,
+		func(fo *os.File) error {
+			_, _, err := api.UploadBrandThemeLogo(ctx, brandID, themeID).File(fo).Execute()
+			return err
+		},
+		func() error {
+			_, err := api.DeleteBrandThemeLogo(ctx, brandID, themeID).Execute()
+			return err
+		})
 }
 
 func handleThemeFavicon(ctx context.Context, d *schema.ResourceData, m interface{}, brandID, themeID string) error {
-	_, newPath := d.GetChange("favicon")
-	if newPath == "" {
-		_, err := getOktaV3ClientFromMetadata(m).CustomizationApi.DeleteBrandThemeFavicon(ctx, brandID, themeID).Execute()
-		return err
-	}
-	fo, err := os.Open(newPath.(string))
-	if err != nil {
-		return err
-	}
-	defer fo.Close()
-	_, _, err = getOktaV3ClientFromMetadata(m).CustomizationApi.UploadBrandThemeFavicon(ctx, brandID, themeID).File(fo).Execute()
-	return err
+	api := getOktaV3ClientFromMetadata(m).CustomizationApi
+	return handleThemeImage(d, themeFaviconAttributes,
+		func(fo *os.File) error {
+			_, _, err := api.UploadBrandThemeFavicon(ctx, brandID, themeID).File(fo).Execute()
+			return err
+		},
+		func() error {
+			_, err := api.DeleteBrandThemeFavicon(ctx, brandID, themeID).Execute()
+			return err
+		})
 }
 
 func handleThemeBackgroundImage(ctx context.Context, d *schema.ResourceData, m interface{}, brandID, themeID string) error {
-	_, newPath := d.GetChange("background_image")
-	if newPath == "" {
-		_, err := getOktaV3ClientFromMetadata(m).CustomizationApi.DeleteBrandThemeBackgroundImage(ctx, brandID, themeID).Execute()
-		return err
-	}
-	fo, err := os.Open(newPath.(string))
+	api := getOktaV3ClientFromMetadata(m).CustomizationApi
+	return handleThemeImage(d, themeBackgroundImageAttributes,
+		func(fo *os.File) error {
+			_, _, err := api.UploadBrandThemeBackgroundImage(ctx, brandID, themeID).File(fo).Execute()
+			return err
+		},
+		func() error {
+			_, err := api.DeleteBrandThemeBackgroundImage(ctx, brandID, themeID).Execute()
+			return err
+		})
+}
+
+// handleThemeImage uploads the configured image from either the local file or
+// the base64 content, or deletes the image when neither is set.
+func handleThemeImage(d *schema.ResourceData, attrs imageAttributes, upload func(*os.File) error, remove func() error) error {
+	fo, cleanup, err := attrs.open(d)
 	if err != nil {
 		return err
 	}
-	defer fo.Close()
-	_, _, err = getOktaV3ClientFromMetadata(m).CustomizationApi.UploadBrandThemeBackgroundImage(ctx, brandID, themeID).File(fo).Execute()
-	return err
+	if fo == nil {
+		return remove()
+	}
+	defer cleanup()
+	return upload(fo)
+}
+
+func syncThemeImages(ctx context.Context, d *schema.ResourceData, m interface{}, theme *okta.ThemeResponse) {
+	logoAttributes.syncHash(ctx, d, m, theme.GetLogo())
+	themeFaviconAttributes.syncHash(ctx, d, m, theme.GetFavicon())
+	themeBackgroundImageAttributes.syncHash(ctx, d, m, theme.GetBackgroundImage())
 }
@@ -1,6 +1,12 @@
 package okta
 
 import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"fmt"
 	"strings"
 
 	"github.com/crewjam/saml"
@@ -48,3 +54,91 @@ func syncSamlCertificates(d *schema.ResourceData, descriptors []saml.KeyDescript
 		}
 	}
 }
+
+// samlIdpMetadata is the configuration of okta_idp_saml derived from the
+// metadata of a SAML IdP.
+type samlIdpMetadata struct {
+	entityID string
+	// ssoURLs are the SingleSignOnService locations keyed by binding alias,
+	// e.g. HTTP-POST.
+	ssoURLs map[string]string
+	// signingCertificate is the base64 DER encoded signing certificate.
+	signingCertificate string
+}
+
+// parseSamlIdpMetadata parses the metadata of a SAML IdP, either an
+// EntityDescriptor or an EntitiesDescriptor holding a single IdP.
+func parseSamlIdpMetadata(raw []byte) (*samlIdpMetadata, error) {
+	entity, err := samlIdpEntityDescriptor(raw)
+	if err != nil {
+		return nil, err
+	}
+	desc := entity.IDPSSODescriptors[0]
+	metadata := &samlIdpMetadata{
+		entityID: entity.EntityID,
+		ssoURLs:  map[string]string{},
+	}
+	for _, service := range desc.SingleSignOnServices {
+		switch service.Binding {
+		case postBinding:
+			metadata.ssoURLs[postBindingAlias] = service.Location
+		case redirectBinding:
+			metadata.ssoURLs[redirectBindingAlias] = service.Location
+		}
+	}
+	if len(metadata.ssoURLs) == 0 {
+		return nil, errors.New("metadata has no HTTP-POST or HTTP-Redirect SingleSignOnService")
+	}
+	for _, key := range desc.KeyDescriptors {
+		// a key without use is used for both signing and encryption
+		if key.Use != "signing" && key.Use != "" || len(key.KeyInfo.X509Data.X509Certificates) == 0 {
+			continue
+		}
+		cert := strings.Join(strings.Fields(key.KeyInfo.X509Data.X509Certificates[0].Data), "")
+		der, err := base64.StdEncoding.DecodeString(cert)
+		if err != nil {
+			return nil, fmt.Errorf("invalid signing certificate: %w", err)
+		}
+		if _, err := x509.ParseCertificate(der); err != nil {
+			return nil, fmt.Errorf("invalid signing certificate: %w", err)
+		}
+		metadata.signingCertificate = cert
+		break
+	}
+	if metadata.signingCertificate == "" {
+		return nil, errors.New("metadata has no signing certificate")
+	}
+	return metadata, nil
+}
+
+func samlIdpEntityDescriptor(raw []byte) (*saml.EntityDescriptor, error) {
+	entity := saml.EntityDescriptor{}
+	if err := xml.Unmarshal(raw, &entity); err == nil {
+		if len(entity.IDPSSODescriptors) == 0 {
+			return nil, fmt.Errorf("entity '%s' has no IDPSSODescriptor", entity.EntityID)
+		}
+		return &entity, nil
+	}
+	entities := saml.EntitiesDescriptor{}
+	if err := xml.Unmarshal(raw, &entities); err != nil {
+		return nil, fmt.Errorf("expected an EntityDescriptor or an EntitiesDescriptor: %w", err)
+	}
+	var idps []saml.EntityDescriptor
+	for _, entity := range entities.EntityDescriptors {
+		if len(entity.IDPSSODescriptors) > 0 {
+			idps = append(idps, entity)
+		}
+	}
+	if len(idps) != 1 {
+		return nil, fmt.Errorf("expected a single entity with an IDPSSODescriptor, got %d", len(idps))
+	}
+	return &idps[0], nil
+}
+
+// x5tS256 is the base64url encoded SHA-256 thumbprint of a base64 DER
+// encoded certificate, the x5t#S256 Okta reports for IdP keys.
+func x5tS256(cert string) string {
+	der, _ := base64.StdEncoding.DecodeString(cert)
+	sum := sha256.Sum256(der)
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
@@ -0,0 +1,43 @@
+package okta
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSamlIdpMetadata(t *testing.T) {
+	raw, err := os.ReadFile("../examples/okta_idp_saml/idp_metadata.xml")
+	require.NoError(t, err)
+
+	metadata, err := parseSamlIdpMetadata(raw)
+	require.NoError(t, err)
+	assert.Equal(t, "https://idp.example.com/metadata", metadata.entityID)
+	assert.Equal(t, map[string]string{
+		postBindingAlias:     "https://idp.example.com/sso/post",
+		redirectBindingAlias: "https://idp.example.com/sso/redirect",
+	}, metadata.ssoURLs)
+	assert.NotEmpty(t, metadata.signingCertificate)
+	assert.NotContains(t, metadata.signingCertificate, " ")
+
+	entity := string(raw[strings.Index(string(raw), "<md:EntityDescriptor"):])
+	wrapped := `<md:EntitiesDescriptor xmlns:md="urn:oasis:names:tc:SAML:2.0:metadata">` + entity + `</md:EntitiesDescriptor>`
+	metadata, err = parseSamlIdpMetadata([]byte(wrapped))
+	require.NoError(t, err)
+	assert.Equal(t, "https://idp.example.com/metadata", metadata.entityID)
+
+	_, err = parseSamlIdpMetadata([]byte(strings.ReplaceAll(entity, `use="signing"`, `use="encryption"`)))
+	assert.EqualError(t, err, "metadata has no signing certificate")
+
+	_, err = parseSamlIdpMetadata([]byte(strings.ReplaceAll(entity, "SAML:2.0:bindings:HTTP-", "SAML:2.0:bindings:SOAP-")))
+	assert.EqualError(t, err, "metadata has no HTTP-POST or HTTP-Redirect SingleSignOnService")
+
+	_, err = parseSamlIdpMetadata([]byte(`<md:EntityDescriptor xmlns:md="urn:oasis:names:tc:SAML:2.0:metadata" entityID="sp"></md:EntityDescriptor>`))
+	assert.EqualError(t, err, "entity 'sp' has no IDPSSODescriptor")
+
+	_, err = parseSamlIdpMetadata([]byte("not xml"))
+	assert.Error(t, err)
+}
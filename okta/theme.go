@@ -29,36 +29,81 @@ var themeResourceSchema = map[string]*schema.Schema{
 		Description:      "Path to local file",
 		DiffSuppressFunc: suppressDuringCreateFunc("theme_id"),
 		StateFunc:        localFileStateFunc,
+		ConflictsWith:    []string{"logo_content"},
+	},
+	"logo_content": {
+		Type:             schema.TypeString,
+		Optional:         true,
+		Description:      "Base64 encoded content of the file, alternative to `logo`",
+		ValidateDiagFunc: imageContentIsValid(),
+		DiffSuppressFunc: suppressDuringCreateFunc("theme_id"),
+		StateFunc:        base64ContentStateFunc,
+		ConflictsWith:    []string{"logo"},
 	},
 	"logo_url": {
 		Type:        schema.TypeString,
 		Computed:    true,
 		Description: "Logo URL",
 	},
+	"logo_hash": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "SHA-256 hash of the logo served by Okta, used to detect changes made outside of Terraform",
+	},
 	"favicon": {
 		Type:             schema.TypeString,
 		Optional:         true,
 		Description:      "Path to local file",
 		DiffSuppressFunc: suppressDuringCreateFunc("theme_id"),
 		StateFunc:        localFileStateFunc,
+		ConflictsWith:    []string{"favicon_content"},
+	},
+	"favicon_content": {
+		Type:             schema.TypeString,
+		Optional:         true,
+		Description:      "Base64 encoded content of the file, alternative to `favicon`",
+		ValidateDiagFunc: imageContentIsValid(),
+		DiffSuppressFunc: suppressDuringCreateFunc("theme_id"),
+		StateFunc:        base64ContentStateFunc,
+		ConflictsWith:    []string{"favicon"},
 	},
 	"favicon_url": {
 		Type:        schema.TypeString,
 		Computed:    true,
 		Description: "Favicon URL",
 	},
+	"favicon_hash": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "SHA-256 hash of the favicon served by Okta, used to detect changes made outside of Terraform",
+	},
 	"background_image": {
 		Type:             schema.TypeString,
 		Optional:         true,
 		Description:      "Path to local file",
 		DiffSuppressFunc: suppressDuringCreateFunc("theme_id"),
 		StateFunc:        localFileStateFunc,
+		ConflictsWith:    []string{"background_image_content"},
+	},
+	"background_image_content": {
+		Type:             schema.TypeString,
+		Optional:         true,
+		Description:      "Base64 encoded content of the file, alternative to `background_image`",
+		ValidateDiagFunc: imageContentIsValid(),
+		DiffSuppressFunc: suppressDuringCreateFunc("theme_id"),
+		StateFunc:        base64ContentStateFunc,
+		ConflictsWith:    []string{"background_image"},
 	},
 	"background_image_url": {
 		Type:        schema.TypeString,
 		Computed:    true,
 		Description: "Background image URL",
 	},
+	"background_image_hash": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "SHA-256 hash of the background image served by Okta, used to detect changes made outside of Terraform",
+	},
 	"primary_color_hex": {
 		Type: schema.TypeString,
 		// Required:         true,
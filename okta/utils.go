@@ -16,6 +16,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"sync"
 	"unicode"
 
 	"github.com/hashicorp/go-hclog"
@@ -336,6 +337,46 @@ func is404(resp *sdk.Response) bool {
 	return resp != nil && resp.StatusCode == http.StatusNotFound
 }
 
+// getParallelism returns the number of concurrent requests a resource may make
+// for operations where bulk APIs are not available.
+func getParallelism(meta interface{}) int {
+	if config, ok := meta.(*Config); ok && config.parallelism > 0 {
+		return config.parallelism
+	}
+	return 1
+}
+
+// runConcurrently runs the tasks with at most parallelism of them in flight and
+// returns the joined errors of the tasks that failed.
+func runConcurrently(parallelism int, tasks []func() error) error {
+	if parallelism < 1 {
+		parallelism = 1
+	}
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	sem := make(chan struct{}, parallelism)
+	for _, task := range tasks {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(task func() error) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			if err := task(); err != nil {
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+			}
+		}(task)
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
 func logger(meta interface{}) hclog.Logger {
 	return meta.(*Config).logger
 }
@@ -1,14 +1,20 @@
 package okta
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/okta/terraform-provider-okta/sdk"
+	"github.com/stretchr/testify/require"
 )
 
 type checkUpstream func(string) (bool, error)
@@ -220,3 +226,28 @@ func sleepInSecondsForTest(t int) resource.TestCheckFunc {
 		return nil
 	}
 }
+
+// newTestConfig returns the provider configuration of an org whose API is
+// served by the handler, for unit tests of the provider functions.
+func newTestConfig(t *testing.T, handler http.HandlerFunc) (context.Context, *Config) {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	ctx, client, err := sdk.NewClient(context.Background(),
+		sdk.WithOrgUrl(server.URL),
+		sdk.WithToken("token"),
+		sdk.WithCache(false),
+		sdk.WithTestingDisableHttpsCheck(true),
+		sdk.WithRateLimitMaxRetries(0),
+	)
+	require.NoError(t, err)
+	return ctx, &Config{
+		oktaClient:       client,
+		supplementClient: &sdk.APISupplement{RequestExecutor: client.CloneRequestExecutor()},
+		logger:           hclog.NewNullLogger(),
+	}
+}
+
+// nextPageLink returns the Link header of the next page of the request.
+func nextPageLink(r *http.Request, after string) string {
+	return fmt.Sprintf(`<http://%s%s?after=%s>; rel="next"`, r.Host, r.URL.Path, after)
+}
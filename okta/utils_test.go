@@ -1,10 +1,13 @@
 package okta
 
 import (
+	"fmt"
 	"log"
 	"reflect"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/okta/terraform-provider-okta/sdk"
 	"github.com/stretchr/testify/assert"
@@ -215,3 +218,33 @@ xqneNjZf70DMNAFNXG1VltldQ3hOnRML
 		t.Fatalf("certs do not match: A: %s, B: %s", cert.Issuer.CommonName, cert2.Issuer.CommonName)
 	}
 }
+
+func TestRunConcurrently(t *testing.T) {
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+	var tasks []func() error
+	for i := 0; i < 10; i++ {
+		i := i
+		tasks = append(tasks, func() error {
+			mu.Lock()
+			inFlight++
+			if inFlight > maxInFlight {
+				maxInFlight = inFlight
+			}
+			mu.Unlock()
+			time.Sleep(5 * time.Millisecond)
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+			if i%5 == 0 {
+				return fmt.Errorf("task %d failed", i)
+			}
+			return nil
+		})
+	}
+	err := runConcurrently(3, tasks)
+	assert.LessOrEqual(t, maxInFlight, 3)
+	assert.ErrorContains(t, err, "task 0 failed")
+	assert.ErrorContains(t, err, "task 5 failed")
+	assert.NoError(t, runConcurrently(2, nil))
+}
@@ -1,12 +1,17 @@
 package okta
 
 import (
+	"fmt"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
+	"github.com/okta/terraform-provider-okta/okta/internal/el"
+	"github.com/okta/terraform-provider-okta/okta/internal/eventtypes"
 )
 
 func intBetween(min, max int) schema.SchemaValidateDiagFunc {
@@ -35,6 +40,19 @@ func intAtMost(max int) schema.SchemaValidateDiagFunc {
 	}
 }
 
+func stringInSlice(valid ...string) schema.SchemaValidateDiagFunc {
+	return func(i interface{}, k cty.Path) diag.Diagnostics {
+		v, ok := i.(string)
+		if !ok {
+			return diag.Errorf("expected type of %v to be string", k)
+		}
+		if !contains(valid, v) {
+			return diag.Errorf("expected %v to be one of %s, got %s", k, strings.Join(valid, ", "), v)
+		}
+		return nil
+	}
+}
+
 func logoFileIsValid() schema.SchemaValidateDiagFunc {
 	return func(i interface{}, k cty.Path) diag.Diagnostics {
 		v, ok := i.(string)
@@ -65,3 +83,141 @@ func stringIsJSON(i interface{}, k cty.Path) diag.Diagnostics {
 	}
 	return nil
 }
+
+// eventHookEventTypeIsValid warns about event types which aren't in the
+// catalog, suggesting the closest known one for likely typos. It never fails
+// as the catalog may be older than the org.
+func eventHookEventTypeIsValid(i interface{}, k cty.Path) diag.Diagnostics {
+	v, ok := i.(string)
+	if !ok {
+		return diag.Errorf("expected type of %v to be string", k)
+	}
+	if eventtypes.IsEligible(v) {
+		return nil
+	}
+	summary := fmt.Sprintf("'%s' is not a known event hook eligible event type", v)
+	if suggestion := eventtypes.Suggest(v); suggestion != "" {
+		summary = fmt.Sprintf("%s, did you mean '%s'?", summary, suggestion)
+	}
+	return diag.Diagnostics{{
+		Severity:      diag.Warning,
+		Summary:       summary,
+		Detail:        "The event type is not in the catalog of this provider, Okta won't deliver it to the hook unless it is event hook eligible. See the okta_event_hook_event_types data source for the known event types.",
+		AttributePath: k,
+	}}
+}
+
+func durationIsValid(i interface{}, k cty.Path) diag.Diagnostics {
+	v, ok := i.(string)
+	if !ok {
+		return diag.Errorf("expected type of %v to be string", k)
+	}
+	if d, err := time.ParseDuration(v); err != nil || d < 0 {
+		return diag.Errorf("expected %v to be a positive duration such as '15m', got '%s'", k, v)
+	}
+	return nil
+}
+
+// oktaScopeIsValid checks that a scope is an Okta scope, such as
+// okta.users.read, the scopes of the Org Authorization Server.
+func oktaScopeIsValid(i interface{}, k cty.Path) diag.Diagnostics {
+	v, ok := i.(string)
+	if !ok {
+		return diag.Errorf("expected type of %v to be string", k)
+	}
+	if !strings.HasPrefix(v, "okta.") {
+		return diag.Errorf("expected %v to be an Okta scope such as 'okta.users.read', got '%s', the consent to the scopes of custom authorization servers can't be granted to an application", k, v)
+	}
+	return nil
+}
+
+// expressionIsValid checks the syntax, function calls and types of an Okta
+// Expression Language expression. When variables are given, the expression
+// can only use those variables. Calls of unknown functions and methods only
+// warn.
+func expressionIsValid(variables ...string) schema.SchemaValidateDiagFunc {
+	return func(i interface{}, k cty.Path) diag.Diagnostics {
+		v, ok := i.(string)
+		if !ok {
+			return diag.Errorf("expected type of %v to be string", k)
+		}
+		warnings, err := el.Check(v, variables)
+		if err != nil {
+			return diag.Errorf("expected %v to be a valid Okta Expression Language expression, got '%s': %v", k, v, err)
+		}
+		return expressionWarnings(v, warnings, k)
+	}
+}
+
+func expressionWarnings(expr string, warnings []*el.Error, k cty.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, w := range warnings {
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Warning,
+			Summary:       fmt.Sprintf("'%s' might not be a valid Okta Expression Language expression: %v", expr, w),
+			AttributePath: k,
+		})
+	}
+	return diags
+}
+
+// userNameTemplateIsValid checks an application user name template, which is
+// either an expression, e.g. 'String.toLowerCase(source.login)', or text with
+// '${...}' expressions, e.g. '${source.login}@example.com'. Legacy 'fn:'
+// functions are not checked.
+func userNameTemplateIsValid(i interface{}, k cty.Path) diag.Diagnostics {
+	v, ok := i.(string)
+	if !ok {
+		return diag.Errorf("expected type of %v to be string", k)
+	}
+	if !strings.Contains(v, "${") {
+		return expressionIsValid()(v, k)
+	}
+	var diags diag.Diagnostics
+	for rest := v; ; {
+		start := strings.Index(rest, "${")
+		if start < 0 {
+			return diags
+		}
+		end := strings.Index(rest[start:], "}")
+		if end < 0 {
+			return diag.Errorf("expected %v to be a valid user name template, got '%s': unterminated '${'", k, v)
+		}
+		expr := strings.TrimSpace(rest[start+2 : start+end])
+		rest = rest[start+end+1:]
+		if strings.HasPrefix(expr, "fn:") {
+			continue
+		}
+		warnings, err := el.Check(expr, nil)
+		if err != nil {
+			return diag.Errorf("expected %v to be a valid user name template, got '%s': '%s': %v", k, v, expr, err)
+		}
+		diags = append(diags, expressionWarnings(expr, warnings, k)...)
+	}
+}
+
+func samlIdpMetadataIsValid(i interface{}, k cty.Path) diag.Diagnostics {
+	v, ok := i.(string)
+	if !ok {
+		return diag.Errorf("expected type of %v to be string", k)
+	}
+	if _, err := parseSamlIdpMetadata([]byte(v)); err != nil {
+		return diag.Errorf("invalid SAML IdP metadata in %v: %v", k, err)
+	}
+	return nil
+}
+
+func samlIdpMetadataFileIsValid(i interface{}, k cty.Path) diag.Diagnostics {
+	v, ok := i.(string)
+	if !ok {
+		return diag.Errorf("expected type of %v to be string", k)
+	}
+	raw, err := os.ReadFile(v)
+	if err != nil {
+		return diag.Errorf("invalid '%s' file: %v", v, err)
+	}
+	if _, err := parseSamlIdpMetadata(raw); err != nil {
+		return diag.Errorf("invalid SAML IdP metadata in '%s' file: %v", v, err)
+	}
+	return nil
+}
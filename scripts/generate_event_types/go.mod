@@ -0,0 +1,10 @@
+module github.com/okta/terraform-provider-okta/scripts/generate_event_types
+
+go 1.19
+
+require github.com/spf13/cobra v1.3.0
+
+require (
+	github.com/inconshreveable/mousetrap v1.0.0 // indirect
+	github.com/spf13/pflag v1.0.5 // indirect
+)
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"text/template"
+
+	"github.com/spf13/cobra"
+)
+
+// eventHookEligibleTag is the tag of the event types which can be delivered
+// to event hooks.
+const eventHookEligibleTag = "event-hook-eligible"
+
+type catalog struct {
+	Versions []struct {
+		EventTypes []struct {
+			ID   string   `json:"id"`
+			Tags []string `json:"tags"`
+		} `json:"eventTypes"`
+	} `json:"versions"`
+}
+
+var (
+	eventTypesCatalogJSON string
+	docsRevision          string
+	rootCmd               = &cobra.Command{
+		Use:   "go run main.go -h",
+		Short: "generate event hook event types code",
+		Run: func(cmd *cobra.Command, args []string) {
+			catalogPath := cmd.Flag("catalog").Value
+			catalogFile, err := os.ReadFile(catalogPath.String())
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "failed to read %q: %v\n", catalogPath, err)
+				return
+			}
+			var c catalog
+			if err := json.Unmarshal(catalogFile, &c); err != nil {
+				fmt.Fprintf(os.Stderr, "unknown format of catalog %q: %v\n", catalogPath, err)
+				return
+			}
+
+			seen := map[string]bool{}
+			eventTypes := []string{}
+			for _, version := range c.Versions {
+				for _, eventType := range version.EventTypes {
+					if seen[eventType.ID] {
+						continue
+					}
+					for _, tag := range eventType.Tags {
+						if tag == eventHookEligibleTag {
+							seen[eventType.ID] = true
+							eventTypes = append(eventTypes, eventType.ID)
+							break
+						}
+					}
+				}
+			}
+			if len(eventTypes) == 0 {
+				fmt.Fprintf(os.Stderr, "no %s event types in %q\n", eventHookEligibleTag, catalogPath)
+				return
+			}
+			sort.Strings(eventTypes)
+
+			tmplPath := "event_types.tmpl"
+			tmpl, err := os.ReadFile(tmplPath)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "failed to read %q: %v\n", tmplPath, err)
+				return
+			}
+
+			t := template.Must(template.New("tmpl").Parse(string(tmpl)))
+			etGoPath := "../../okta/internal/eventtypes/event_types.go"
+			etGoFile, err := os.Create(etGoPath)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "failed to open %q: %v\n", etGoPath, err)
+				return
+			}
+			defer etGoFile.Close()
+			t.Execute(etGoFile, struct {
+				Revision   string
+				EventTypes []string
+			}{docsRevision, eventTypes})
+		},
+	}
+)
+
+func init() {
+	rootCmd.PersistentFlags().StringVarP(&eventTypesCatalogJSON, "catalog", "c", "", "path to the event types catalog of the Okta developer documentation, event-types.json in github.com/okta/okta-developer-docs")
+	rootCmd.PersistentFlags().StringVarP(&docsRevision, "revision", "r", "", "commit of github.com/okta/okta-developer-docs the catalog comes from, recorded in the generated code")
+	rootCmd.MarkPersistentFlagRequired("revision")
+}
+
+func Execute() error {
+	return rootCmd.Execute()
+}
+
+func main() {
+	if err := Execute(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: '%s'", err)
+		rootCmd.Help()
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,30 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+)
+
+// JsonWebKeySet is a JWKS as published by the keys endpoint of an
+// authorization server.
+type JsonWebKeySet struct {
+	Keys []*JsonWebKey `json:"keys"`
+}
+
+// GetAuthorizationServerPublicKeys gets the keys published by the public
+// keys endpoint of an authorization server, which is what relying parties
+// fetch and cache to validate tokens.
+func (m *APISupplement) GetAuthorizationServerPublicKeys(ctx context.Context, authServerID string) (*JsonWebKeySet, *Response, error) {
+	url := fmt.Sprintf("/oauth2/%v/v1/keys", authServerID)
+	re := m.cloneRequestExecutor()
+	req, err := re.WithAccept("application/json").WithContentType("application/json").NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	var keySet JsonWebKeySet
+	resp, err := re.Do(ctx, req, &keySet)
+	if err != nil {
+		return nil, resp, err
+	}
+	return &keySet, resp, nil
+}
@@ -0,0 +1,103 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// DeviceAssurance is a device assurance policy. The v3 SDK doesn't support the
+// CHROMEOS platform and its third party signal providers yet.
+type DeviceAssurance struct {
+	Id                        string                           `json:"id,omitempty"`
+	Name                      string                           `json:"name"`
+	Platform                  string                           `json:"platform"`
+	OsVersion                 *DeviceAssuranceOsVersion        `json:"osVersion,omitempty"`
+	DiskEncryptionType        *DeviceAssuranceIncludeCondition `json:"diskEncryptionType,omitempty"`
+	ScreenLockType            *DeviceAssuranceIncludeCondition `json:"screenLockType,omitempty"`
+	Jailbreak                 *bool                            `json:"jailbreak,omitempty"`
+	SecureHardwarePresent     *bool                            `json:"secureHardwarePresent,omitempty"`
+	ThirdPartySignalProviders json.RawMessage                  `json:"thirdPartySignalProviders,omitempty"`
+	CreatedDate               *time.Time                       `json:"createdDate,omitempty"`
+	LastUpdatedDate           *time.Time                       `json:"lastUpdatedDate,omitempty"`
+}
+
+type DeviceAssuranceOsVersion struct {
+	Minimum string `json:"minimum,omitempty"`
+}
+
+type DeviceAssuranceIncludeCondition struct {
+	Include []string `json:"include"`
+}
+
+// ListDeviceAssurancePolicies lists all device assurance policies
+func (m *APISupplement) ListDeviceAssurancePolicies(ctx context.Context) ([]*DeviceAssurance, *Response, error) {
+	url := "/api/v1/device-assurances"
+	req, err := m.RequestExecutor.WithAccept("application/json").WithContentType("application/json").NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	var policies []*DeviceAssurance
+	resp, err := m.RequestExecutor.Do(ctx, req, &policies)
+	if err != nil {
+		return nil, resp, err
+	}
+	return policies, resp, nil
+}
+
+// GetDeviceAssurancePolicy gets device assurance policy by ID
+func (m *APISupplement) GetDeviceAssurancePolicy(ctx context.Context, id string) (*DeviceAssurance, *Response, error) {
+	url := fmt.Sprintf("/api/v1/device-assurances/%s", id)
+	req, err := m.RequestExecutor.WithAccept("application/json").WithContentType("application/json").NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	var policy *DeviceAssurance
+	resp, err := m.RequestExecutor.Do(ctx, req, &policy)
+	if err != nil {
+		return nil, resp, err
+	}
+	return policy, resp, nil
+}
+
+// CreateDeviceAssurancePolicy creates device assurance policy
+func (m *APISupplement) CreateDeviceAssurancePolicy(ctx context.Context, body DeviceAssurance) (*DeviceAssurance, *Response, error) {
+	url := "/api/v1/device-assurances"
+	req, err := m.RequestExecutor.WithAccept("application/json").WithContentType("application/json").NewRequest(http.MethodPost, url, body)
+	if err != nil {
+		return nil, nil, err
+	}
+	var policy *DeviceAssurance
+	resp, err := m.RequestExecutor.Do(ctx, req, &policy)
+	if err != nil {
+		return nil, resp, err
+	}
+	return policy, resp, nil
+}
+
+// UpdateDeviceAssurancePolicy replaces device assurance policy
+func (m *APISupplement) UpdateDeviceAssurancePolicy(ctx context.Context, id string, body DeviceAssurance) (*DeviceAssurance, *Response, error) {
+	url := fmt.Sprintf("/api/v1/device-assurances/%s", id)
+	req, err := m.RequestExecutor.WithAccept("application/json").WithContentType("application/json").NewRequest(http.MethodPut, url, body)
+	if err != nil {
+		return nil, nil, err
+	}
+	var policy *DeviceAssurance
+	resp, err := m.RequestExecutor.Do(ctx, req, &policy)
+	if err != nil {
+		return nil, resp, err
+	}
+	return policy, resp, nil
+}
+
+// DeleteDeviceAssurancePolicy deletes device assurance policy by ID
+func (m *APISupplement) DeleteDeviceAssurancePolicy(ctx context.Context, id string) (*Response, error) {
+	url := fmt.Sprintf("/api/v1/device-assurances/%s", id)
+	req, err := m.RequestExecutor.NewRequest(http.MethodDelete, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return m.RequestExecutor.Do(ctx, req, nil)
+}
@@ -0,0 +1,179 @@
+// Package eventhooks receives the deliveries of event hooks, as set with
+// okta_event_hook. Its http.Handler answers the one-time verification request
+// sent by okta_event_hook_verification, checks the deliveries against the
+// channel configuration of the hook and dispatches the events they carry to
+// the callbacks registered for their type.
+package eventhooks
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/okta/terraform-provider-okta/sdk"
+)
+
+const (
+	// VerificationHeader is the header of the one-time verification request
+	// holding the challenge to echo back.
+	VerificationHeader = "X-Okta-Verification-Challenge"
+
+	// DeliveryEventType is the event type of the deliveries.
+	DeliveryEventType = "com.okta.event_hook"
+)
+
+// maxDeliverySize bounds the size of the deliveries read by the handler.
+const maxDeliverySize = 10 << 20
+
+// Delivery is a batch of events sent to an event hook.
+type Delivery struct {
+	EventID            string       `json:"eventId,omitempty"`
+	EventTime          string       `json:"eventTime,omitempty"`
+	EventType          string       `json:"eventType,omitempty"`
+	EventTypeVersion   string       `json:"eventTypeVersion,omitempty"`
+	ContentType        string       `json:"contentType,omitempty"`
+	CloudEventsVersion string       `json:"cloudEventsVersion,omitempty"`
+	Source             string       `json:"source,omitempty"`
+	Data               DeliveryData `json:"data"`
+}
+
+type DeliveryData struct {
+	Events []*sdk.LogEvent `json:"events"`
+}
+
+// Verification is the response to the one-time verification request.
+type Verification struct {
+	Verification string `json:"verification"`
+}
+
+// Func is a callback receiving an event of a delivery.
+type Func func(ctx context.Context, event *sdk.LogEvent) error
+
+// Handler is an http.Handler receiving the requests of an event hook. Its
+// zero value accepts all requests and ignores all events.
+type Handler struct {
+	config    *sdk.EventHookChannelConfig
+	mu        sync.RWMutex
+	callbacks map[string][]Func
+	fallback  Func
+}
+
+// NewHandler returns a handler accepting the requests which have the
+// authorization header and the custom headers of config.
+func NewHandler(config *sdk.EventHookChannelConfig) *Handler {
+	return &Handler{config: config}
+}
+
+// Handle registers fn as a callback of the events of type eventType, such as
+// user.lifecycle.create. Callbacks are called in the order of registration.
+func (h *Handler) Handle(eventType string, fn Func) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.callbacks == nil {
+		h.callbacks = make(map[string][]Func)
+	}
+	h.callbacks[eventType] = append(h.callbacks[eventType], fn)
+}
+
+// HandleOther registers fn as the callback of the events without callbacks
+// of their type.
+func (h *Handler) HandleOther(fn Func) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.fallback = fn
+}
+
+// Dispatch calls the callbacks of each event of the delivery. All events are
+// dispatched, the errors of the callbacks are joined.
+func (h *Handler) Dispatch(ctx context.Context, delivery *Delivery) error {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	var errs []error
+	for _, event := range delivery.Data.Events {
+		if event == nil {
+			continue
+		}
+		callbacks := h.callbacks[event.EventType]
+		if len(callbacks) == 0 && h.fallback != nil {
+			callbacks = []Func{h.fallback}
+		}
+		for _, fn := range callbacks {
+			if err := fn(ctx, event); err != nil {
+				errs = append(errs, fmt.Errorf("failed to handle event '%s' of type '%s': %w", event.Uuid, event.EventType, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !Authorized(h.config, r) {
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		challenge := r.Header.Get(VerificationHeader)
+		if challenge == "" {
+			http.Error(w, fmt.Sprintf("missing %s header", VerificationHeader), http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(&Verification{Verification: challenge})
+	case http.MethodPost:
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxDeliverySize))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		var delivery Delivery
+		if err = json.Unmarshal(body, &delivery); err != nil {
+			http.Error(w, fmt.Sprintf("invalid event hook delivery: %v", err), http.StatusBadRequest)
+			return
+		}
+		if delivery.EventType != DeliveryEventType {
+			http.Error(w, fmt.Sprintf("unexpected event type '%s', expected '%s'", delivery.EventType, DeliveryEventType), http.StatusBadRequest)
+			return
+		}
+		if err = h.Dispatch(r.Context(), &delivery); err != nil {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+	}
+}
+
+// Authorized tells whether the request has the authorization header and the
+// custom headers of the channel configuration of the hook. A nil config
+// accepts all requests.
+func Authorized(config *sdk.EventHookChannelConfig, r *http.Request) bool {
+	if config == nil {
+		return true
+	}
+	if auth := config.AuthScheme; auth != nil && auth.Key != "" {
+		if !equalValues(r.Header.Get(auth.Key), auth.Value) {
+			return false
+		}
+	}
+	for _, header := range config.Headers {
+		if header == nil || header.Key == "" {
+			continue
+		}
+		if !equalValues(r.Header.Get(header.Key), header.Value) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalValues(actual, expected string) bool {
+	return subtle.ConstantTimeCompare([]byte(actual), []byte(expected)) == 1
+}
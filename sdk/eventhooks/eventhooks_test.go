@@ -0,0 +1,122 @@
+package eventhooks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/okta/terraform-provider-okta/sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const deliveryBody = `{
+  "eventType": "com.okta.event_hook",
+  "eventTypeVersion": "1.0",
+  "cloudEventsVersion": "0.1",
+  "source": "https://example.okta.com/api/v1/eventHooks/whoql0HfiLGPWc8Jk0g3",
+  "eventId": "b5a188b9-5ece-4636-b041-482ffda96311",
+  "data": {
+    "events": [
+      {"uuid": "1", "eventType": "user.lifecycle.create", "displayMessage": "Create Okta user",
+       "target": [{"id": "00u1", "type": "User", "alternateId": "john.doe@example.com"}]},
+      {"uuid": "2", "eventType": "user.lifecycle.delete.initiated"},
+      {"uuid": "3", "eventType": "group.user_membership.add"}
+    ]
+  }
+}`
+
+var testChannelConfig = &sdk.EventHookChannelConfig{
+	AuthScheme: &sdk.EventHookChannelConfigAuthScheme{Key: "Authorization", Type: "HEADER", Value: "secret"},
+	Headers:    []*sdk.EventHookChannelConfigHeader{{Key: "X-Tenant", Value: "example"}},
+}
+
+func serve(h http.Handler, method, body string, headers map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "/", strings.NewReader(body))
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestVerification(t *testing.T) {
+	h := NewHandler(testChannelConfig)
+	rec := serve(h, http.MethodGet, "", map[string]string{
+		"Authorization":    "secret",
+		"X-Tenant":         "example",
+		VerificationHeader: "JhDn3JQv6FvTYVV4Xm8z",
+	})
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"verification": "JhDn3JQv6FvTYVV4Xm8z"}`, rec.Body.String())
+
+	rec = serve(h, http.MethodGet, "", map[string]string{"Authorization": "secret", "X-Tenant": "example"})
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	rec = serve(h, http.MethodGet, "", map[string]string{VerificationHeader: "JhDn3JQv6FvTYVV4Xm8z"})
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestDelivery(t *testing.T) {
+	var created, other []string
+	h := NewHandler(nil)
+	h.Handle("user.lifecycle.create", func(_ context.Context, event *sdk.LogEvent) error {
+		created = append(created, event.Target[0].AlternateId)
+		return nil
+	})
+	h.HandleOther(func(_ context.Context, event *sdk.LogEvent) error {
+		other = append(other, event.EventType)
+		return nil
+	})
+	rec := serve(h, http.MethodPost, deliveryBody, nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, []string{"john.doe@example.com"}, created)
+	assert.Equal(t, []string{"user.lifecycle.delete.initiated", "group.user_membership.add"}, other)
+}
+
+func TestDeliveryFailures(t *testing.T) {
+	h := NewHandler(testChannelConfig)
+	h.Handle("user.lifecycle.delete.initiated", func(context.Context, *sdk.LogEvent) error {
+		return errors.New("boom")
+	})
+	valid := map[string]string{"Authorization": "secret", "X-Tenant": "example"}
+	tests := []struct {
+		name    string
+		method  string
+		body    string
+		headers map[string]string
+		status  int
+	}{
+		{"unauthorized", http.MethodPost, deliveryBody, map[string]string{"Authorization": "secret"}, http.StatusUnauthorized},
+		{"method", http.MethodPut, deliveryBody, valid, http.StatusMethodNotAllowed},
+		{"invalid json", http.MethodPost, `{"data": [`, valid, http.StatusBadRequest},
+		{"event type", http.MethodPost, `{"eventType": "com.okta.oauth2.tokens.transform"}`, valid, http.StatusBadRequest},
+		{"callback error", http.MethodPost, deliveryBody, valid, http.StatusInternalServerError},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rec := serve(h, test.method, test.body, test.headers)
+			assert.Equal(t, test.status, rec.Code)
+		})
+	}
+}
+
+func TestDispatchJoinsErrors(t *testing.T) {
+	var delivery Delivery
+	require.NoError(t, json.Unmarshal([]byte(deliveryBody), &delivery))
+	var h Handler
+	calls := 0
+	h.HandleOther(func(context.Context, *sdk.LogEvent) error {
+		calls++
+		return errors.New("boom")
+	})
+	err := h.Dispatch(context.Background(), &delivery)
+	assert.Equal(t, 3, calls)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to handle event '2' of type 'user.lifecycle.delete.initiated'")
+}
@@ -0,0 +1,95 @@
+package hooks
+
+import (
+	"strings"
+)
+
+// Types of the commands of the inline hook responses.
+const (
+	IdentityPatchCommand     = "com.okta.identity.patch"
+	AccessPatchCommand       = "com.okta.access.patch"
+	UserProfileUpdateCommand = "com.okta.user.profile.update"
+	ActionUpdateCommand      = "com.okta.action.update"
+	AssertionPatchCommand    = "com.okta.assertion.patch"
+	TelephonyActionCommand   = "com.okta.telephony.action"
+)
+
+// Add returns an operation adding value at path.
+func Add(path string, value interface{}) *Operation {
+	return &Operation{Op: "add", Path: path, Value: value}
+}
+
+// Replace returns an operation replacing the value at path.
+func Replace(path string, value interface{}) *Operation {
+	return &Operation{Op: "replace", Path: path, Value: value}
+}
+
+// Remove returns an operation removing the value at path.
+func Remove(path string) *Operation {
+	return &Operation{Op: "remove", Path: path}
+}
+
+// ClaimPath returns the path of a claim of a token or an assertion, escaping
+// the name as a JSON Pointer token.
+func ClaimPath(name string) string {
+	return "/claims/" + strings.NewReplacer("~", "~0", "/", "~1").Replace(name)
+}
+
+// TokenLifetimePath is the path of the lifetime of a token in seconds.
+const TokenLifetimePath = "/token/lifetime/expiration"
+
+// PatchIdentityToken returns a command patching the ID token.
+func PatchIdentityToken(operations ...*Operation) *Command {
+	return &Command{Type: IdentityPatchCommand, Value: operations}
+}
+
+// PatchAccessToken returns a command patching the access token.
+func PatchAccessToken(operations ...*Operation) *Command {
+	return &Command{Type: AccessPatchCommand, Value: operations}
+}
+
+// PatchAssertion returns a command patching the SAML assertion.
+func PatchAssertion(operations ...*Operation) *Command {
+	return &Command{Type: AssertionPatchCommand, Value: operations}
+}
+
+// UpdateProfile returns a command updating the profile of the user being
+// registered.
+func UpdateProfile(profile map[string]interface{}) *Command {
+	return &Command{Type: UserProfileUpdateCommand, Value: profile}
+}
+
+// AllowRegistration returns a command allowing or denying the registration
+// of the user.
+func AllowRegistration(allow bool) *Command {
+	action := "DENY"
+	if allow {
+		action = "ALLOW"
+	}
+	return &Command{Type: ActionUpdateCommand, Value: map[string]interface{}{"registration": action}}
+}
+
+// VerifyCredential returns a command telling whether the imported password
+// is valid, Okta then stores the password when it is.
+func VerifyCredential(verified bool) *Command {
+	credential := "UNVERIFIED"
+	if verified {
+		credential = "VERIFIED"
+	}
+	return &Command{Type: ActionUpdateCommand, Value: map[string]interface{}{"credential": credential}}
+}
+
+// TelephonyResult is the outcome of sending an OTP through a telephony
+// provider.
+type TelephonyResult struct {
+	Status              string `json:"status"`
+	Provider            string `json:"provider"`
+	TransactionID       string `json:"transactionId,omitempty"`
+	TransactionMetadata string `json:"transactionMetadata,omitempty"`
+}
+
+// ReportTelephonyResult returns a command reporting the outcome of sending an
+// OTP, status being SUCCESSFUL, PENDING or FAILED.
+func ReportTelephonyResult(result TelephonyResult) *Command {
+	return &Command{Type: TelephonyActionCommand, Value: []TelephonyResult{result}}
+}
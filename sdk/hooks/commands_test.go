@@ -0,0 +1,46 @@
+package hooks
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/okta/terraform-provider-okta/sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClaimPath(t *testing.T) {
+	assert.Equal(t, "/claims/groups", ClaimPath("groups"))
+	assert.Equal(t, "/claims/https:~1~1example.com~1a~0b", ClaimPath("https://example.com/a~b"))
+}
+
+func TestCommandsJSON(t *testing.T) {
+	tests := []struct {
+		command  *Command
+		expected string
+	}{
+		{AllowRegistration(false), `{"type":"com.okta.action.update","value":{"registration":"DENY"}}`},
+		{VerifyCredential(true), `{"type":"com.okta.action.update","value":{"credential":"VERIFIED"}}`},
+		{UpdateProfile(map[string]interface{}{"department": "R&D"}), `{"type":"com.okta.user.profile.update","value":{"department":"R&D"}}`},
+		{PatchAccessToken(Replace(TokenLifetimePath, 600), Remove(ClaimPath("tmp"))), `{"type":"com.okta.access.patch","value":[{"op":"replace","path":"/token/lifetime/expiration","value":600},{"op":"remove","path":"/claims/tmp"}]}`},
+	}
+	for _, test := range tests {
+		b, err := json.Marshal(test.command)
+		require.NoError(t, err)
+		assert.JSONEq(t, test.expected, string(b))
+	}
+}
+
+func TestResponseFromSDK(t *testing.T) {
+	response := ResponseFromSDK(&sdk.InlineHookResponse{
+		Commands: []*sdk.InlineHookResponseCommands{{
+			Type: AssertionPatchCommand,
+			Value: []*sdk.InlineHookResponseCommandValue{{
+				Op: "add", Path: "/claims/role", Value: "admin",
+			}},
+		}},
+	})
+	require.Len(t, response.Commands, 1)
+	assert.Equal(t, AssertionPatchCommand, response.Commands[0].Type)
+	assert.Equal(t, []*Operation{{Op: "add", Path: "/claims/role", Value: "admin"}}, response.Commands[0].Value)
+}
@@ -0,0 +1,175 @@
+package hooks
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/okta/terraform-provider-okta/sdk"
+)
+
+// maxRequestSize bounds the size of the requests read by the handlers.
+const maxRequestSize = 1 << 20
+
+// NewTokenHandler returns a handler of com.okta.oauth2.tokens.transform hooks.
+func NewTokenHandler(config *sdk.InlineHookChannelConfig, fn func(context.Context, *TokenRequest) (*Response, error)) http.Handler {
+	return &handler{config: config, eventType: TokenTransformType, handle: func(ctx context.Context, body []byte) (*Response, error) {
+		var req TokenRequest
+		if err := decodeRequest(body, &req); err != nil {
+			return nil, err
+		}
+		return fn(ctx, &req)
+	}}
+}
+
+// NewRegistrationHandler returns a handler of com.okta.user.pre-registration
+// hooks.
+func NewRegistrationHandler(config *sdk.InlineHookChannelConfig, fn func(context.Context, *RegistrationRequest) (*Response, error)) http.Handler {
+	return &handler{config: config, eventType: UserPreRegistrationType, handle: func(ctx context.Context, body []byte) (*Response, error) {
+		var req RegistrationRequest
+		if err := decodeRequest(body, &req); err != nil {
+			return nil, err
+		}
+		return fn(ctx, &req)
+	}}
+}
+
+// NewPasswordImportHandler returns a handler of
+// com.okta.user.credential.password.import hooks.
+func NewPasswordImportHandler(config *sdk.InlineHookChannelConfig, fn func(context.Context, *PasswordImportRequest) (*Response, error)) http.Handler {
+	return &handler{config: config, eventType: PasswordImportType, handle: func(ctx context.Context, body []byte) (*Response, error) {
+		var req PasswordImportRequest
+		if err := decodeRequest(body, &req); err != nil {
+			return nil, err
+		}
+		return fn(ctx, &req)
+	}}
+}
+
+// NewSAMLHandler returns a handler of com.okta.saml.tokens.transform hooks.
+func NewSAMLHandler(config *sdk.InlineHookChannelConfig, fn func(context.Context, *SAMLRequest) (*Response, error)) http.Handler {
+	return &handler{config: config, eventType: SAMLTokensTransformType, handle: func(ctx context.Context, body []byte) (*Response, error) {
+		var req SAMLRequest
+		if err := decodeRequest(body, &req); err != nil {
+			return nil, err
+		}
+		return fn(ctx, &req)
+	}}
+}
+
+// NewTelephonyHandler returns a handler of com.okta.telephony.provider hooks.
+func NewTelephonyHandler(config *sdk.InlineHookChannelConfig, fn func(context.Context, *TelephonyRequest) (*Response, error)) http.Handler {
+	return &handler{config: config, eventType: TelephonyProviderType, handle: func(ctx context.Context, body []byte) (*Response, error) {
+		var req TelephonyRequest
+		if err := decodeRequest(body, &req); err != nil {
+			return nil, err
+		}
+		return fn(ctx, &req)
+	}}
+}
+
+// Authorized tells whether the request has the authorization header and the
+// custom headers of the channel configuration of the hook. A nil config
+// accepts all requests.
+func Authorized(config *sdk.InlineHookChannelConfig, r *http.Request) bool {
+	if config == nil {
+		return true
+	}
+	if auth := config.AuthScheme; auth != nil && auth.Key != "" {
+		if !equalValues(r.Header.Get(auth.Key), auth.Value) {
+			return false
+		}
+	}
+	for _, header := range config.Headers {
+		if header == nil || header.Key == "" {
+			continue
+		}
+		if !equalValues(r.Header.Get(header.Key), header.Value) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalValues(actual, expected string) bool {
+	return subtle.ConstantTimeCompare([]byte(actual), []byte(expected)) == 1
+}
+
+// handler verifies and decodes the requests of an inline hook, then writes
+// the response of the handler function. An *Error returned by the function
+// is sent as the error of the response, other errors fail the request.
+type handler struct {
+	config    *sdk.InlineHookChannelConfig
+	eventType string
+	handle    func(context.Context, []byte) (*Response, error)
+}
+
+// decodeError is returned when the body of the request isn't a request of
+// the expected type.
+type decodeError struct {
+	err error
+}
+
+func (e *decodeError) Error() string {
+	return fmt.Sprintf("invalid inline hook request: %v", e.err)
+}
+
+func decodeRequest(body []byte, req interface{}) error {
+	if err := json.Unmarshal(body, req); err != nil {
+		return &decodeError{err: err}
+	}
+	return nil
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	method := http.MethodPost
+	if h.config != nil && h.config.Method != "" {
+		method = h.config.Method
+	}
+	if r.Method != method {
+		w.Header().Set("Allow", method)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	if !Authorized(h.config, r) {
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestSize))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var event Event
+	if err = json.Unmarshal(body, &event); err != nil {
+		http.Error(w, (&decodeError{err: err}).Error(), http.StatusBadRequest)
+		return
+	}
+	if event.EventType != h.eventType {
+		http.Error(w, fmt.Sprintf("unexpected event type '%s', expected '%s'", event.EventType, h.eventType), http.StatusBadRequest)
+		return
+	}
+	response, err := h.handle(r.Context(), body)
+	var hookErr *Error
+	var decodeErr *decodeError
+	switch {
+	case errors.As(err, &hookErr):
+		response = &Response{Error: hookErr}
+	case errors.As(err, &decodeErr):
+		http.Error(w, decodeErr.Error(), http.StatusBadRequest)
+		return
+	case err != nil:
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	if response == nil {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+}
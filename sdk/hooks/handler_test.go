@@ -0,0 +1,161 @@
+package hooks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/okta/terraform-provider-okta/sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const tokenRequestBody = `{
+  "eventId": "3OWo4oo-QQ-rBWfRyTmQYw",
+  "eventType": "com.okta.oauth2.tokens.transform",
+  "data": {
+    "context": {
+      "user": {"id": "00uq8tMo3zV0OfJON0g3", "profile": {"login": "john.doe@example.com"}}
+    },
+    "identity": {"claims": {"sub": "00uq8tMo3zV0OfJON0g3"}, "token": {"lifetime": {"expiration": 3600}}}
+  }
+}`
+
+var testChannelConfig = &sdk.InlineHookChannelConfig{
+	AuthScheme: &sdk.InlineHookChannelConfigAuthScheme{Key: "Authorization", Type: "HEADER", Value: "secret"},
+	Headers:    []*sdk.InlineHookChannelConfigHeaders{{Key: "X-Tenant", Value: "example"}},
+}
+
+func postHook(t *testing.T, h http.Handler, body string, headers map[string]string) *http.Response {
+	t.Helper()
+	server := httptest.NewServer(h)
+	defer server.Close()
+	req, err := http.NewRequest(http.MethodPost, server.URL, strings.NewReader(body))
+	require.NoError(t, err)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := server.Client().Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { resp.Body.Close() })
+	return resp
+}
+
+var validHeaders = map[string]string{"Authorization": "secret", "X-Tenant": "example"}
+
+func TestTokenHandler(t *testing.T) {
+	h := NewTokenHandler(testChannelConfig, func(_ context.Context, req *TokenRequest) (*Response, error) {
+		assert.Equal(t, "00uq8tMo3zV0OfJON0g3", req.Data.Context.User.ID)
+		assert.EqualValues(t, 3600, req.Data.Identity.Token.Lifetime.Expiration)
+		return NewResponse(PatchIdentityToken(Add(ClaimPath("extPatientId"), "1234"))), nil
+	})
+	resp := postHook(t, h, tokenRequestBody, validHeaders)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	var body map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, map[string]interface{}{
+		"commands": []interface{}{map[string]interface{}{
+			"type": IdentityPatchCommand,
+			"value": []interface{}{map[string]interface{}{
+				"op": "add", "path": "/claims/extPatientId", "value": "1234",
+			}},
+		}},
+	}, body)
+}
+
+func TestHandlerRejectsRequests(t *testing.T) {
+	called := false
+	h := NewTokenHandler(testChannelConfig, func(context.Context, *TokenRequest) (*Response, error) {
+		called = true
+		return nil, nil
+	})
+	tests := []struct {
+		name    string
+		body    string
+		headers map[string]string
+		status  int
+	}{
+		{"missing auth", tokenRequestBody, map[string]string{"X-Tenant": "example"}, http.StatusUnauthorized},
+		{"wrong auth", tokenRequestBody, map[string]string{"Authorization": "guess", "X-Tenant": "example"}, http.StatusUnauthorized},
+		{"missing header", tokenRequestBody, map[string]string{"Authorization": "secret"}, http.StatusUnauthorized},
+		{"invalid json", `{"eventType":`, validHeaders, http.StatusBadRequest},
+		{"other event type", `{"eventType": "com.okta.user.pre-registration"}`, validHeaders, http.StatusBadRequest},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resp := postHook(t, h, test.body, test.headers)
+			assert.Equal(t, test.status, resp.StatusCode)
+		})
+	}
+	assert.False(t, called)
+}
+
+func TestHandlerMethod(t *testing.T) {
+	h := NewTokenHandler(nil, func(context.Context, *TokenRequest) (*Response, error) {
+		return nil, nil
+	})
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	assert.Equal(t, http.MethodPost, rec.Header().Get("Allow"))
+}
+
+func TestHandlerResults(t *testing.T) {
+	tests := []struct {
+		name   string
+		fn     func(context.Context, *RegistrationRequest) (*Response, error)
+		status int
+		body   string
+	}{
+		{
+			name:   "no response",
+			fn:     func(context.Context, *RegistrationRequest) (*Response, error) { return nil, nil },
+			status: http.StatusNoContent,
+		},
+		{
+			name: "hook error",
+			fn: func(context.Context, *RegistrationRequest) (*Response, error) {
+				return nil, &Error{ErrorSummary: "Registration is closed"}
+			},
+			status: http.StatusOK,
+			body:   `{"error":{"errorSummary":"Registration is closed"}}`,
+		},
+		{
+			name: "internal error",
+			fn: func(context.Context, *RegistrationRequest) (*Response, error) {
+				return nil, errors.New("database unavailable")
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			h := NewRegistrationHandler(nil, test.fn)
+			resp := postHook(t, h, `{"eventType": "com.okta.user.pre-registration"}`, nil)
+			require.Equal(t, test.status, resp.StatusCode)
+			if test.body != "" {
+				var body strings.Builder
+				_, err := io.Copy(&body, resp.Body)
+				require.NoError(t, err)
+				assert.JSONEq(t, test.body, body.String())
+			}
+		})
+	}
+}
+
+func TestAuthorized(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	assert.True(t, Authorized(nil, req))
+	assert.False(t, Authorized(testChannelConfig, req))
+	for k, v := range validHeaders {
+		req.Header.Set(k, v)
+	}
+	assert.True(t, Authorized(testChannelConfig, req))
+}
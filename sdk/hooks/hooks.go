@@ -0,0 +1,104 @@
+// Package hooks has the requests Okta sends to inline hooks and the responses
+// it expects back, along with an http.Handler verifying the requests against
+// the channel configuration of the hook, as set with okta_inline_hook.
+package hooks
+
+import (
+	"encoding/json"
+
+	"github.com/okta/terraform-provider-okta/sdk"
+)
+
+// Types of the inline hooks, the okta_inline_hook type of a hook and the
+// event type of the requests it receives.
+const (
+	TokenTransformType      = "com.okta.oauth2.tokens.transform"
+	UserPreRegistrationType = "com.okta.user.pre-registration"
+	PasswordImportType      = "com.okta.user.credential.password.import"
+	SAMLTokensTransformType = "com.okta.saml.tokens.transform"
+	TelephonyProviderType   = "com.okta.telephony.provider"
+)
+
+// Event holds the attributes common to the requests of all inline hooks.
+type Event struct {
+	EventID           string `json:"eventId,omitempty"`
+	EventTime         string `json:"eventTime,omitempty"`
+	EventType         string `json:"eventType,omitempty"`
+	EventTypeVersion  string `json:"eventTypeVersion,omitempty"`
+	ContentType       string `json:"contentType,omitempty"`
+	CloudEventVersion string `json:"cloudEventVersion,omitempty"`
+	Source            string `json:"source,omitempty"`
+}
+
+// Response is the response of an inline hook. All its attributes are
+// optional, an empty response leaves the outcome of the flow unchanged.
+type Response struct {
+	Commands     []*Command             `json:"commands,omitempty"`
+	Error        *Error                 `json:"error,omitempty"`
+	DebugContext map[string]interface{} `json:"debugContext,omitempty"`
+}
+
+// Command is a change to apply to the outcome of the flow. Its value depends
+// on its type, a list of operations for patches or an object for updates.
+type Command struct {
+	Type  string      `json:"type"`
+	Value interface{} `json:"value"`
+}
+
+// Operation is a JSON Patch operation of a patch command.
+type Operation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// Error is the error of an inline hook shown to the end user. A handler
+// function returning an *Error responds with it instead of failing.
+type Error struct {
+	ErrorSummary string        `json:"errorSummary"`
+	ErrorCauses  []*ErrorCause `json:"errorCauses,omitempty"`
+}
+
+type ErrorCause struct {
+	ErrorSummary string `json:"errorSummary,omitempty"`
+	Reason       string `json:"reason,omitempty"`
+	LocationType string `json:"locationType,omitempty"`
+	Location     string `json:"location,omitempty"`
+	Domain       string `json:"domain,omitempty"`
+}
+
+func (e *Error) Error() string {
+	return e.ErrorSummary
+}
+
+// NewResponse returns a response with the given commands.
+func NewResponse(commands ...*Command) *Response {
+	return &Response{Commands: commands}
+}
+
+// CommandFromSDK converts a command of the sdk package, whose operation
+// values can only be strings.
+func CommandFromSDK(command *sdk.InlineHookResponseCommands) *Command {
+	operations := make([]*Operation, len(command.Value))
+	for i, value := range command.Value {
+		operations[i] = &Operation{Op: value.Op, Path: value.Path, Value: value.Value}
+	}
+	return &Command{Type: command.Type, Value: operations}
+}
+
+// ResponseFromSDK converts a response of the sdk package.
+func ResponseFromSDK(response *sdk.InlineHookResponse) *Response {
+	commands := make([]*Command, len(response.Commands))
+	for i := range response.Commands {
+		commands[i] = CommandFromSDK(response.Commands[i])
+	}
+	return NewResponse(commands...)
+}
+
+// User is the Okta user a request is about.
+type User struct {
+	ID              string                 `json:"id,omitempty"`
+	PasswordChanged string                 `json:"passwordChanged,omitempty"`
+	Profile         map[string]interface{} `json:"profile,omitempty"`
+	Identities      json.RawMessage        `json:"identities,omitempty"`
+}
@@ -0,0 +1,150 @@
+package hooks
+
+import "encoding/json"
+
+// Request is the HTTP request that triggered the flow, as seen by Okta.
+type Request struct {
+	ID        string      `json:"id,omitempty"`
+	Method    string      `json:"method,omitempty"`
+	URL       *RequestURL `json:"url,omitempty"`
+	IPAddress string      `json:"ipAddress,omitempty"`
+}
+
+type RequestURL struct {
+	Value string `json:"value,omitempty"`
+}
+
+// TokenRequest is the request of a com.okta.oauth2.tokens.transform hook.
+type TokenRequest struct {
+	Event
+	Data TokenData `json:"data"`
+}
+
+type TokenData struct {
+	Context  TokenContext `json:"context"`
+	Identity *Token       `json:"identity,omitempty"`
+	Access   *Token       `json:"access,omitempty"`
+}
+
+type TokenContext struct {
+	Request  *Request               `json:"request,omitempty"`
+	Protocol map[string]interface{} `json:"protocol,omitempty"`
+	Session  map[string]interface{} `json:"session,omitempty"`
+	User     *User                  `json:"user,omitempty"`
+	Policy   map[string]interface{} `json:"policy,omitempty"`
+}
+
+// Token is an ID or access token about to be minted.
+type Token struct {
+	Claims map[string]interface{} `json:"claims,omitempty"`
+	Token  *TokenLifetime         `json:"token,omitempty"`
+	Scopes map[string]interface{} `json:"scopes,omitempty"`
+}
+
+type TokenLifetime struct {
+	Lifetime struct {
+		Expiration int64 `json:"expiration,omitempty"`
+	} `json:"lifetime"`
+}
+
+// RegistrationRequest is the request of a com.okta.user.pre-registration hook.
+type RegistrationRequest struct {
+	Event
+	Data RegistrationData `json:"data"`
+}
+
+type RegistrationData struct {
+	Context     RegistrationContext    `json:"context"`
+	UserProfile map[string]interface{} `json:"userProfile,omitempty"`
+	Action      string                 `json:"action,omitempty"`
+}
+
+type RegistrationContext struct {
+	Request *Request `json:"request,omitempty"`
+}
+
+// PasswordImportRequest is the request of a
+// com.okta.user.credential.password.import hook.
+type PasswordImportRequest struct {
+	Event
+	Data PasswordImportData `json:"data"`
+}
+
+type PasswordImportData struct {
+	Context PasswordImportContext `json:"context"`
+	Action  PasswordImportAction  `json:"action"`
+}
+
+type PasswordImportContext struct {
+	Request    *Request           `json:"request,omitempty"`
+	Credential PasswordCredential `json:"credential"`
+}
+
+type PasswordCredential struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+type PasswordImportAction struct {
+	Credential string `json:"credential,omitempty"`
+}
+
+// SAMLRequest is the request of a com.okta.saml.tokens.transform hook.
+type SAMLRequest struct {
+	Event
+	Data SAMLData `json:"data"`
+}
+
+type SAMLData struct {
+	Context   SAMLContext   `json:"context"`
+	Assertion SAMLAssertion `json:"assertion"`
+}
+
+type SAMLContext struct {
+	Request  *Request               `json:"request,omitempty"`
+	Protocol map[string]interface{} `json:"protocol,omitempty"`
+	Session  map[string]interface{} `json:"session,omitempty"`
+	User     *User                  `json:"user,omitempty"`
+}
+
+// SAMLAssertion is the assertion about to be sent to the app, its parts are
+// kept as JSON so that they can be patched as they are.
+type SAMLAssertion struct {
+	Subject        json.RawMessage            `json:"subject,omitempty"`
+	Authentication json.RawMessage            `json:"authentication,omitempty"`
+	Conditions     json.RawMessage            `json:"conditions,omitempty"`
+	Claims         map[string]json.RawMessage `json:"claims,omitempty"`
+	Lifetime       json.RawMessage            `json:"lifetime,omitempty"`
+}
+
+// TelephonyRequest is the request of a com.okta.telephony.provider hook.
+type TelephonyRequest struct {
+	Event
+	Data TelephonyData `json:"data"`
+}
+
+type TelephonyData struct {
+	Context        TelephonyContext        `json:"context"`
+	UserProfile    TelephonyUserProfile    `json:"userProfile"`
+	MessageProfile TelephonyMessageProfile `json:"messageProfile"`
+}
+
+type TelephonyContext struct {
+	Request *Request `json:"request,omitempty"`
+}
+
+type TelephonyUserProfile struct {
+	UserID    string `json:"userId,omitempty"`
+	Login     string `json:"login,omitempty"`
+	FirstName string `json:"firstName,omitempty"`
+	LastName  string `json:"lastName,omitempty"`
+}
+
+type TelephonyMessageProfile struct {
+	MsgTemplate     string `json:"msgTemplate,omitempty"`
+	PhoneNumber     string `json:"phoneNumber,omitempty"`
+	OtpExpires      string `json:"otpExpires,omitempty"`
+	DeliveryChannel string `json:"deliveryChannel,omitempty"`
+	OtpCode         string `json:"otpCode,omitempty"`
+	Locale          string `json:"locale,omitempty"`
+}
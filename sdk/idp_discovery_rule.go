@@ -32,7 +32,14 @@ type (
 	}
 
 	IdpDiscoveryRuleIdp struct {
-		Providers []*IdpDiscoveryRuleProvider `json:"providers"`
+		Providers        []*IdpDiscoveryRuleProvider      `json:"providers"`
+		IdpSelectionType string                           `json:"idpSelectionType,omitempty"`
+		MatchCriteria    []*IdpDiscoveryRuleMatchCriteria `json:"matchCriteria,omitempty"`
+	}
+
+	IdpDiscoveryRuleMatchCriteria struct {
+		ProviderExpression string `json:"providerExpression,omitempty"`
+		PropertyName       string `json:"propertyName,omitempty"`
 	}
 
 	IdpDiscoveryRuleNetwork struct {
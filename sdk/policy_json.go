@@ -0,0 +1,54 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// GetPolicyJSON gets a policy by ID as a generic JSON object, keeping the
+// attributes SdkPolicy doesn't cover.
+func (m *APISupplement) GetPolicyJSON(ctx context.Context, policyID string) (map[string]interface{}, *Response, error) {
+	url := fmt.Sprintf("/api/v1/policies/%v", policyID)
+	return m.sendPolicyJSON(ctx, http.MethodGet, url, nil)
+}
+
+// CreatePolicyJSON creates a policy from a generic JSON object.
+func (m *APISupplement) CreatePolicyJSON(ctx context.Context, body map[string]interface{}) (map[string]interface{}, *Response, error) {
+	return m.sendPolicyJSON(ctx, http.MethodPost, "/api/v1/policies", body)
+}
+
+// UpdatePolicyJSON replaces a policy with a generic JSON object.
+func (m *APISupplement) UpdatePolicyJSON(ctx context.Context, policyID string, body map[string]interface{}) (map[string]interface{}, *Response, error) {
+	url := fmt.Sprintf("/api/v1/policies/%v", policyID)
+	return m.sendPolicyJSON(ctx, http.MethodPut, url, body)
+}
+
+// CreatePolicyRuleJSON creates a policy rule from a generic JSON object.
+func (m *APISupplement) CreatePolicyRuleJSON(ctx context.Context, policyID string, body map[string]interface{}) (map[string]interface{}, *Response, error) {
+	url := fmt.Sprintf("/api/v1/policies/%v/rules", policyID)
+	return m.sendPolicyJSON(ctx, http.MethodPost, url, body)
+}
+
+// UpdatePolicyRuleJSON replaces a policy rule with a generic JSON object.
+func (m *APISupplement) UpdatePolicyRuleJSON(ctx context.Context, policyID, ruleID string, body map[string]interface{}) (map[string]interface{}, *Response, error) {
+	url := fmt.Sprintf("/api/v1/policies/%v/rules/%v", policyID, ruleID)
+	return m.sendPolicyJSON(ctx, http.MethodPut, url, body)
+}
+
+func (m *APISupplement) sendPolicyJSON(ctx context.Context, method, url string, body map[string]interface{}) (map[string]interface{}, *Response, error) {
+	var payload interface{}
+	if body != nil {
+		payload = body
+	}
+	req, err := m.RequestExecutor.WithAccept("application/json").WithContentType("application/json").NewRequest(method, url, payload)
+	if err != nil {
+		return nil, nil, err
+	}
+	var out map[string]interface{}
+	resp, err := m.RequestExecutor.Do(ctx, req, &out)
+	if err != nil {
+		return nil, resp, err
+	}
+	return out, resp, nil
+}
@@ -2,6 +2,7 @@ package sdk
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"time"
@@ -86,6 +87,22 @@ func (m *APISupplement) ListPolicyRules(ctx context.Context, policyID string) ([
 	return policyRule, resp, nil
 }
 
+// ListPolicyRulesJSON enumerates all policy rules as raw JSON, for callers
+// needing attributes of rule types SdkPolicyRule doesn't cover.
+func (m *APISupplement) ListPolicyRulesJSON(ctx context.Context, policyID string) ([]json.RawMessage, *Response, error) {
+	url := fmt.Sprintf("/api/v1/policies/%v/rules", policyID)
+	req, err := m.RequestExecutor.WithAccept("application/json").WithContentType("application/json").NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	var policyRules []json.RawMessage
+	resp, err := m.RequestExecutor.Do(ctx, req, &policyRules)
+	if err != nil {
+		return nil, resp, err
+	}
+	return policyRules, resp, nil
+}
+
 // CreatePolicyRule creates a policy rule.
 func (m *APISupplement) CreatePolicyRule(ctx context.Context, policyID string, body SdkPolicyRule) (*SdkPolicyRule, *Response, error) {
 	url := fmt.Sprintf("/api/v1/policies/%v/rules", policyID)
@@ -130,3 +147,49 @@ func (m *APISupplement) UpdatePolicyRule(ctx context.Context, policyID, ruleId s
 	}
 	return policyRule, resp, nil
 }
+
+// PolicyRulePosition is the subset of a policy rule describing its position
+// within the policy, shared by all rule types.
+type PolicyRulePosition struct {
+	Id       string `json:"id,omitempty"`
+	Name     string `json:"name,omitempty"`
+	Priority int64  `json:"priority,omitempty"`
+	System   *bool  `json:"system,omitempty"`
+}
+
+// ListPolicyRulePositions enumerates the positions of all rules of a policy
+// regardless of the policy type.
+func (m *APISupplement) ListPolicyRulePositions(ctx context.Context, policyID string) ([]PolicyRulePosition, *Response, error) {
+	url := fmt.Sprintf("/api/v1/policies/%v/rules", policyID)
+	req, err := m.RequestExecutor.WithAccept("application/json").WithContentType("application/json").NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	var positions []PolicyRulePosition
+	resp, err := m.RequestExecutor.Do(ctx, req, &positions)
+	if err != nil {
+		return nil, resp, err
+	}
+	return positions, resp, nil
+}
+
+// UpdatePolicyRulePriority changes the priority of a policy rule. The rule is
+// sent back as it was read so that attributes unknown to this SDK are kept.
+func (m *APISupplement) UpdatePolicyRulePriority(ctx context.Context, policyID, ruleID string, priority int64) (*Response, error) {
+	url := fmt.Sprintf("/api/v1/policies/%v/rules/%v", policyID, ruleID)
+	req, err := m.RequestExecutor.WithAccept("application/json").WithContentType("application/json").NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	var rule map[string]interface{}
+	resp, err := m.RequestExecutor.Do(ctx, req, &rule)
+	if err != nil {
+		return resp, err
+	}
+	rule["priority"] = priority
+	req, err = m.RequestExecutor.WithAccept("application/json").WithContentType("application/json").NewRequest(http.MethodPut, url, rule)
+	if err != nil {
+		return nil, err
+	}
+	return m.RequestExecutor.Do(ctx, req, nil)
+}
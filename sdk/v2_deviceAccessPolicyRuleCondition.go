@@ -1,12 +1,17 @@
 package sdk
 
 type DeviceAccessPolicyRuleCondition struct {
-	Migrated   *bool                              `json:"migrated,omitempty"`
-	Platform   *DevicePolicyRuleConditionPlatform `json:"platform,omitempty"`
-	Rooted     *bool                              `json:"rooted,omitempty"`
-	TrustLevel string                             `json:"trustLevel,omitempty"`
-	Managed    *bool                              `json:"managed,omitempty"`
-	Registered *bool                              `json:"registered,omitempty"`
+	Migrated   *bool                               `json:"migrated,omitempty"`
+	Platform   *DevicePolicyRuleConditionPlatform  `json:"platform,omitempty"`
+	Rooted     *bool                               `json:"rooted,omitempty"`
+	TrustLevel string                              `json:"trustLevel,omitempty"`
+	Managed    *bool                               `json:"managed,omitempty"`
+	Registered *bool                               `json:"registered,omitempty"`
+	Assurance  *DeviceAssurancePolicyRuleCondition `json:"assurance,omitempty"`
+}
+
+type DeviceAssurancePolicyRuleCondition struct {
+	Include []string `json:"include,omitempty"`
 }
 
 func NewDeviceAccessPolicyRuleCondition() *DeviceAccessPolicyRuleCondition {
@@ -1,9 +1,10 @@
 package sdk
 
 type PasswordPolicyPasswordSettings struct {
-	Age        *PasswordPolicyPasswordSettingsAge        `json:"age,omitempty"`
-	Complexity *PasswordPolicyPasswordSettingsComplexity `json:"complexity,omitempty"`
-	Lockout    *PasswordPolicyPasswordSettingsLockout    `json:"lockout,omitempty"`
+	Age                *PasswordPolicyPasswordSettingsAge                `json:"age,omitempty"`
+	BreachedProtection *PasswordPolicyPasswordSettingsBreachedProtection `json:"breachedProtection,omitempty"`
+	Complexity         *PasswordPolicyPasswordSettingsComplexity         `json:"complexity,omitempty"`
+	Lockout            *PasswordPolicyPasswordSettingsLockout            `json:"lockout,omitempty"`
 }
 
 func NewPasswordPolicyPasswordSettings() *PasswordPolicyPasswordSettings {
@@ -0,0 +1,15 @@
+package sdk
+
+type PasswordPolicyPasswordSettingsBreachedProtection struct {
+	DelegatedWorkflowId string `json:"delegatedWorkflowId,omitempty"`
+	ExpireAfterDaysPtr  *int64 `json:"expireAfterDays,omitempty"`
+	LogoutEnabled       *bool  `json:"logoutEnabled,omitempty"`
+}
+
+func NewPasswordPolicyPasswordSettingsBreachedProtection() *PasswordPolicyPasswordSettingsBreachedProtection {
+	return &PasswordPolicyPasswordSettingsBreachedProtection{}
+}
+
+func (a *PasswordPolicyPasswordSettingsBreachedProtection) IsPolicyInstance() bool {
+	return true
+}
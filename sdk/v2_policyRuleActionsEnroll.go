@@ -1,7 +1,15 @@
 package sdk
 
 type PolicyRuleActionsEnroll struct {
-	Self string `json:"self,omitempty"`
+	Self        string                              `json:"self,omitempty"`
+	GracePeriod *PolicyRuleActionsEnrollGracePeriod `json:"gracePeriod,omitempty"`
+}
+
+// PolicyRuleActionsEnrollGracePeriod lets users postpone the enrollment in
+// the required authenticators of an authenticator enrollment policy.
+type PolicyRuleActionsEnrollGracePeriod struct {
+	Type     string `json:"type,omitempty"`
+	Duration string `json:"duration,omitempty"`
 }
 
 func NewPolicyRuleActionsEnroll() *PolicyRuleActionsEnroll {